@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/coreos/pkg/flagutil"
+
+	"github.com/coreos/matchbox/matchbox/secret"
+)
+
+// encryptMain runs "matchbox encrypt", which encrypts a secret read from
+// stdin and prints it as a secret value for Group metadata, for groups
+// written directly to a -data-path or git repository.
+func encryptMain(args []string) {
+	flags := struct {
+		secretKeyFile string
+	}{}
+	fs := flag.NewFlagSet("encrypt", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: matchbox encrypt [flags] < SECRET\n\nEncrypt a secret read from stdin and print it as a Group metadata value.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&flags.secretKeyFile, "secret-key-file", "/etc/matchbox/secret.key", "Path to the key file of secret metadata values")
+
+	fs.Parse(args)
+	if err := flagutil.SetFlagsFromEnv(fs, "MATCHBOX"); err != nil {
+		log.Fatal(err.Error())
+	}
+	key, err := secret.LoadKey(flags.secretKeyFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	plaintext, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ciphertext, err := key.Encrypt(string(plaintext))
+	if err != nil {
+		log.Fatal(err)
+	}
+	value, err := json.Marshal(map[string]string{secret.EncryptedMember: ciphertext})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(value))
+}
@@ -1,18 +1,22 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/coreos/pkg/flagutil"
 
 	web "github.com/coreos/matchbox/matchbox/http"
 	"github.com/coreos/matchbox/matchbox/rpc"
+	"github.com/coreos/matchbox/matchbox/secret"
 	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
 	"github.com/coreos/matchbox/matchbox/sign"
 	"github.com/coreos/matchbox/matchbox/storage"
 	"github.com/coreos/matchbox/matchbox/tlsutil"
@@ -25,22 +29,38 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrateMain(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "encrypt" {
+		encryptMain(os.Args[2:])
+		return
+	}
+
 	flags := struct {
 		address     string
 		rpcAddress  string
-		dataPath    string
+		store       storeFlags
 		assetsPath  string
 		logLevel    string
 		certFile    string
 		keyFile     string
 		caFile      string
 		keyRingPath string
+		secretKey   string
+		mergeMeta   bool
+		nsAccess    string
+		nsHosts     string
+		nsAddresses string
+		nsQuery     bool
+		validate    bool
+		strict      bool
 		version     bool
 		help        bool
 	}{}
 	flag.StringVar(&flags.address, "address", "127.0.0.1:8080", "HTTP listen address")
 	flag.StringVar(&flags.rpcAddress, "rpc-address", "", "RPC listen address")
-	flag.StringVar(&flags.dataPath, "data-path", "/var/lib/matchbox", "Path to data directory")
 	flag.StringVar(&flags.assetsPath, "assets-path", "/var/lib/matchbox/assets", "Path to static assets")
 
 	// Log levels https://github.com/Sirupsen/logrus/blob/master/logrus.go#L36
@@ -55,7 +75,24 @@ func main() {
 	// Signing
 	flag.StringVar(&flags.keyRingPath, "key-ring-path", "", "Path to a private keyring file")
 
+	// Secrets
+	flag.StringVar(&flags.secretKey, "secret-key-file", "", "Path to the key file of secret metadata values")
+	flag.BoolVar(&flags.mergeMeta, "merge-metadata", false, "Merge the metadata of all groups matching a machine, from least to most specific")
+
+	// storage
+	flags.store.register(flag.CommandLine, "")
+
+	// namespaces
+	flag.StringVar(&flags.nsAccess, "namespace-access", "", "Comma separated name=namespace grants of namespaces to client certificate common names")
+	flag.StringVar(&flags.nsHosts, "namespace-hosts", "", "Comma separated host=namespace namespaces of HTTP requests by host name")
+	flag.StringVar(&flags.nsAddresses, "namespace-addresses", "", "Comma separated address=namespace HTTP listen addresses serving a single namespace")
+	flag.BoolVar(&flags.nsQuery, "namespace-query", false, "Select the namespace of HTTP requests by their namespace query parameter")
+
+	// validation
+	flag.BoolVar(&flags.strict, "strict", false, "Refuse to start if groups, profiles, or templates have problems")
+
 	// subcommands
+	flag.BoolVar(&flags.validate, "validate", false, "print problems of groups, profiles, and templates and exit")
 	flag.BoolVar(&flags.version, "version", false, "print version and exit")
 	flag.BoolVar(&flags.help, "help", false, "print usage and exit")
 
@@ -78,8 +115,8 @@ func main() {
 	}
 
 	// validate arguments
-	if finfo, err := os.Stat(flags.dataPath); err != nil || !finfo.IsDir() {
-		log.Fatal("A valid -data-path is required")
+	if err := flags.store.validate(); err != nil {
+		log.Fatal(err)
 	}
 	if flags.assetsPath != "" {
 		if finfo, err := os.Stat(flags.assetsPath); err != nil || !finfo.IsDir() {
@@ -98,6 +135,19 @@ func main() {
 		}
 	}
 
+	access, err := parseNamespaceAccess(flags.nsAccess)
+	if err != nil {
+		log.Fatalf("Invalid -namespace-access: %v", err)
+	}
+	nsHosts, err := parseNamespaces(flags.nsHosts)
+	if err != nil {
+		log.Fatalf("Invalid -namespace-hosts: %v", err)
+	}
+	nsAddresses, err := parseNamespaces(flags.nsAddresses)
+	if err != nil {
+		log.Fatalf("Invalid -namespace-addresses: %v", err)
+	}
+
 	// logging setup
 	lvl, err := logrus.ParseLevel(flags.logLevel)
 	if err != nil {
@@ -116,17 +166,42 @@ func main() {
 		armoredSigner = sign.NewArmoredGPGSigner(entity)
 	}
 
+	// (optional) secret metadata values
+	var secretKey *secret.Key
+	if flags.secretKey != "" {
+		secretKey, err = secret.LoadKey(flags.secretKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// storage
-	store := storage.NewFileStore(&storage.Config{
-		Root:   flags.dataPath,
-		Logger: log,
-	})
+	store, err := flags.store.open()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// core logic
 	server := server.NewServer(&server.Config{
-		Store: store,
+		Store:         store,
+		SecretKey:     secretKey,
+		MergeMetadata: flags.mergeMeta,
+		Logger:        log,
 	})
 
+	// validation of the default namespace and those served over HTTP
+	problems, failures := validate(server, nsHosts, nsAddresses)
+	if flags.validate {
+		if problems > 0 || failures > 0 {
+			os.Exit(1)
+		}
+		log.Info("No problems found")
+		return
+	}
+	if flags.strict && (problems > 0 || failures > 0) {
+		log.Fatalf("Refusing to start with %d problems and %d namespaces which failed validation (-strict)", problems, failures)
+	}
+
 	// gRPC Server (feature disabled by default)
 	if flags.rpcAddress != "" {
 		log.Infof("Starting matchbox gRPC server on %s", flags.rpcAddress)
@@ -146,18 +221,31 @@ func main() {
 		if err != nil {
 			log.Fatalf("Invalid TLS credentials: %v", err)
 		}
-		grpcServer := rpc.NewServer(server, tlscfg)
+		grpcServer := rpc.NewServer(server, tlscfg, access)
 		go grpcServer.Serve(lis)
 		defer grpcServer.Stop()
 	}
 
 	// HTTP Server
 	config := &web.Config{
-		Core:          server,
-		Logger:        log,
-		AssetsPath:    flags.assetsPath,
-		Signer:        signer,
-		ArmoredSigner: armoredSigner,
+		Core:           server,
+		Logger:         log,
+		AssetsPath:     flags.assetsPath,
+		Signer:         signer,
+		ArmoredSigner:  armoredSigner,
+		SecretKey:      secretKey,
+		NamespaceHosts: nsHosts,
+		NamespaceQuery: flags.nsQuery,
+	}
+	// (optional) HTTP Servers of a single namespace
+	for address, namespace := range nsAddresses {
+		nsConfig := *config
+		nsConfig.Namespace = namespace
+		handler := web.NewServer(&nsConfig).HTTPHandler()
+		log.Infof("Starting matchbox HTTP server for namespace %s on %s", namespace, address)
+		go func(address string) {
+			log.Fatalf("failed to start listening: %v", http.ListenAndServe(address, handler))
+		}(address)
 	}
 	httpServer := web.NewServer(config)
 	log.Infof("Starting matchbox HTTP server on %s", flags.address)
@@ -166,3 +254,83 @@ func main() {
 		log.Fatalf("failed to start listening: %v", err)
 	}
 }
+
+// validate logs the problems of the resources of the default namespace and
+// the namespaces of the given maps and returns the number of problems and the
+// number of namespaces which could not be validated.
+func validate(srv server.Server, namespaceMaps ...map[string]string) (int, int) {
+	namespaces := []string{server.DefaultNamespace}
+	seen := make(map[string]bool)
+	for _, namespaceMap := range namespaceMaps {
+		for _, namespace := range namespaceMap {
+			if namespace != server.DefaultNamespace && !seen[namespace] {
+				seen[namespace] = true
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+	count, failures := 0, 0
+	for _, namespace := range namespaces {
+		problems, err := srv.Validate(context.Background(), &pb.ValidateRequest{Namespace: namespace})
+		if err == server.ErrNamespaceNotFound {
+			log.Infof("Namespace %q has no resources yet", namespace)
+			continue
+		}
+		if err != nil {
+			log.Errorf("failed to validate namespace %q: %v", namespace, err)
+			failures++
+			continue
+		}
+		for _, problem := range problems {
+			log.WithField("namespace", namespace).Warningf("Invalid %s %q: %s", problem.Kind, problem.Name, problem.Message)
+		}
+		count += len(problems)
+	}
+	return count, failures
+}
+
+// parseNamespaceAccess parses the -namespace-access grants. Without grants,
+// all clients may access all namespaces.
+func parseNamespaceAccess(grants string) (rpc.NamespaceAccess, error) {
+	if grants == "" {
+		return nil, nil
+	}
+	access, err := rpc.ParseNamespaceAccess(grants)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespaces := range access {
+		for _, namespace := range namespaces {
+			if namespace != "*" && !validNamespace(namespace) {
+				return nil, fmt.Errorf("invalid namespace %q", namespace)
+			}
+		}
+	}
+	return access, nil
+}
+
+// parseNamespaces parses a comma separated list of key=namespace pairs.
+func parseNamespaces(pairs string) (map[string]string, error) {
+	namespaces := make(map[string]string)
+	for _, pair := range strings.Split(pairs, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || !validNamespace(parts[1]) {
+			return nil, fmt.Errorf("invalid pair %q, expected key=namespace", pair)
+		}
+		if _, ok := namespaces[parts[0]]; ok {
+			return nil, fmt.Errorf("duplicate key %q", parts[0])
+		}
+		namespaces[parts[0]] = parts[1]
+	}
+	return namespaces, nil
+}
+
+// validNamespace returns true if the name is the default namespace or a
+// valid namespace name.
+func validNamespace(name string) bool {
+	return name == server.DefaultNamespace || storage.ValidNamespace(name)
+}
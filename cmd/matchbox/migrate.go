@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/coreos/pkg/flagutil"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+)
+
+// migrateMain runs "matchbox migrate", which copies the Groups, Profiles,
+// and templates of a source Store to a destination Store, such as from a
+// -data-path directory to a bolt database.
+func migrateMain(args []string) {
+	flags := struct {
+		from       storeFlags
+		to         storeFlags
+		namespaces string
+		dryRun     bool
+		logLevel   string
+	}{}
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: matchbox migrate [flags]\n\nCopy the Groups, Profiles, and templates of the -from-store to the -to-store.\n\n")
+		fs.PrintDefaults()
+	}
+	flags.from.register(fs, "from-")
+	flags.to.register(fs, "to-")
+	fs.StringVar(&flags.namespaces, "namespaces", "", "Comma separated namespaces to migrate in addition to the default namespace")
+	fs.BoolVar(&flags.dryRun, "dry-run", false, "Print the changes a migration would make without writing them")
+	fs.StringVar(&flags.logLevel, "log-level", "info", "Set the logging level")
+
+	fs.Parse(args)
+	if err := flagutil.SetFlagsFromEnv(fs, "MATCHBOX_MIGRATE"); err != nil {
+		log.Fatal(err.Error())
+	}
+	if err := flags.from.validate(); err != nil {
+		log.Fatal(err)
+	}
+	if err := flags.to.validate(); err != nil {
+		log.Fatal(err)
+	}
+	namespaces := []string{""}
+	for _, namespace := range strings.Split(flags.namespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		if !storage.ValidNamespace(namespace) {
+			log.Fatalf("Invalid -namespaces: invalid namespace %q", namespace)
+		}
+		namespaces = append(namespaces, namespace)
+	}
+
+	lvl, err := logrus.ParseLevel(flags.logLevel)
+	if err != nil {
+		log.Fatalf("invalid log-level: %v", err)
+	}
+	log.Level = lvl
+
+	src, err := flags.from.open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeStore(src)
+	dst, err := flags.to.open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeStore(dst)
+
+	for _, namespace := range namespaces {
+		if err := migrateNamespace(src, dst, namespace, flags.dryRun); err != nil {
+			closeStore(src)
+			closeStore(dst)
+			log.Fatal(err)
+		}
+	}
+}
+
+// migrateNamespace migrates the resources of a namespace, or the default
+// namespace if empty, and prints a summary of the changes. Unless it is a
+// dry run, the migrated resources are verified afterwards.
+func migrateNamespace(src, dst storage.Store, namespace string, dryRun bool) error {
+	var err error
+	if namespace != "" {
+		if src, err = storeNamespace(src, namespace); err != nil {
+			return err
+		}
+		if dst, err = storeNamespace(dst, namespace); err != nil {
+			return err
+		}
+		fmt.Printf("namespace %s\n", namespace)
+	}
+	var diff *storage.Diff
+	if dryRun {
+		diff, err = storage.DiffStores(src, dst)
+	} else {
+		diff, err = storage.Migrate(src, dst)
+	}
+	if diff != nil {
+		printDiff(os.Stdout, diff)
+	}
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	if err := storage.Verify(src, dst); err != nil {
+		return fmt.Errorf("failed to verify migration: %v", err)
+	}
+	fmt.Printf("verified %d resources\n", len(diff.Created)+len(diff.Updated)+len(diff.Unchanged))
+	return nil
+}
+
+// printDiff prints the resources a migration writes or keeps and a summary.
+func printDiff(w io.Writer, diff *storage.Diff) {
+	for _, resource := range diff.Created {
+		fmt.Fprintf(w, "create %s\n", resource)
+	}
+	for _, resource := range diff.Updated {
+		fmt.Fprintf(w, "update %s\n", resource)
+	}
+	for _, resource := range diff.Extra {
+		fmt.Fprintf(w, "keep   %s (not in source)\n", resource)
+	}
+	for _, problem := range diff.Unreadable {
+		fmt.Fprintf(w, "skip   %s/%s (unreadable: %s)\n", problem.Kind, problem.Name, problem.Message)
+	}
+	fmt.Fprintf(w, "%d created, %d updated, %d unchanged, %d only in destination, %d unreadable\n", len(diff.Created), len(diff.Updated), len(diff.Unchanged), len(diff.Extra), len(diff.Unreadable))
+}
+
+// storeNamespace returns the Store of a namespace of a Store.
+func storeNamespace(store storage.Store, namespace string) (storage.Store, error) {
+	namespacer, ok := store.(storage.Namespacer)
+	if !ok {
+		return nil, fmt.Errorf("store does not support namespaces")
+	}
+	return namespacer.Namespace(namespace)
+}
+
+// closeStore closes a Store which holds resources, such as a database file.
+func closeStore(store storage.Store) {
+	if closer, ok := store.(io.Closer); ok {
+		closer.Close()
+	}
+}
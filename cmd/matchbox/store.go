@@ -0,0 +1,195 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/tlsutil"
+)
+
+// storeFlags are the flags which configure a storage.Store.
+type storeFlags struct {
+	// prefix of the flag names, such as "from-"
+	prefix        string
+	store         string
+	dataPaths     pathsFlag
+	etcdEndpoints string
+	etcdPrefix    string
+	etcdCertFile  string
+	etcdKeyFile   string
+	etcdCAFile    string
+	boltPath      string
+	boltImport    bool
+	gitRef        string
+}
+
+// register defines the storage flags on a FlagSet, with names prefixed by
+// the given prefix.
+func (f *storeFlags) register(fs *flag.FlagSet, prefix string) {
+	f.prefix = prefix
+	fs.StringVar(&f.store, prefix+"store", "file", "Storage backend (file, etcd, bolt, git)")
+	f.dataPaths.paths = []string{"/var/lib/matchbox"}
+	fs.Var(&f.dataPaths, prefix+"data-path", "Path to data directory, repeated (or separated by '"+string(filepath.ListSeparator)+"') to overlay writable layers on read-only ones")
+
+	// etcd storage
+	fs.StringVar(&f.etcdEndpoints, prefix+"etcd-endpoints", "127.0.0.1:2379", "Comma separated etcd v3 gRPC endpoints")
+	fs.StringVar(&f.etcdPrefix, prefix+"etcd-prefix", "/matchbox", "Key prefix for matchbox data in etcd")
+	fs.StringVar(&f.etcdCertFile, prefix+"etcd-cert-file", "", "Path to the etcd client TLS certificate file")
+	fs.StringVar(&f.etcdKeyFile, prefix+"etcd-key-file", "", "Path to the etcd client TLS key file")
+	fs.StringVar(&f.etcdCAFile, prefix+"etcd-ca-file", "", "Path to the CA bundle to verify etcd server certificates")
+
+	// bolt storage
+	fs.StringVar(&f.boltPath, prefix+"bolt-path", "/var/lib/matchbox/matchbox.db", "Path to the bolt database file")
+	fs.BoolVar(&f.boltImport, prefix+"bolt-import", false, "Import the -"+prefix+"data-path tree into the bolt database if it is empty")
+
+	// git storage
+	fs.StringVar(&f.gitRef, prefix+"git-ref", "", "Git ref to serve reads from, instead of the -"+prefix+"data-path working tree, making the store read-only")
+}
+
+// validate returns an error if the storage flags are invalid.
+func (f *storeFlags) validate() error {
+	switch f.store {
+	case "file", "git":
+		for _, dataPath := range f.dataPaths.paths {
+			if finfo, err := os.Stat(dataPath); err != nil || !finfo.IsDir() {
+				return fmt.Errorf("A valid -%sdata-path is required", f.prefix)
+			}
+		}
+	case "etcd":
+		if f.etcdEndpoints == "" {
+			return fmt.Errorf("Provide one or more -%setcd-endpoints", f.prefix)
+		}
+		if f.etcdTLS() && (f.etcdCertFile == "" || f.etcdKeyFile == "" || f.etcdCAFile == "") {
+			return fmt.Errorf("Provide all of -%[1]setcd-ca-file, -%[1]setcd-cert-file, and -%[1]setcd-key-file to connect to etcd with TLS", f.prefix)
+		}
+	case "bolt":
+		if f.boltPath == "" {
+			return fmt.Errorf("A valid -%sbolt-path is required", f.prefix)
+		}
+		if f.boltImport {
+			if len(f.dataPaths.paths) != 1 {
+				return fmt.Errorf("A single -%sdata-path is required to -%sbolt-import", f.prefix, f.prefix)
+			}
+			if finfo, err := os.Stat(f.dataPaths.paths[0]); err != nil || !finfo.IsDir() {
+				return fmt.Errorf("A valid -%sdata-path is required to -%sbolt-import", f.prefix, f.prefix)
+			}
+		}
+	default:
+		return fmt.Errorf("Invalid -%sstore %q, must be file, etcd, bolt, or git", f.prefix, f.store)
+	}
+	return nil
+}
+
+// etcdTLS returns true if any etcd TLS flag is set.
+func (f *storeFlags) etcdTLS() bool {
+	return f.etcdCertFile != "" || f.etcdKeyFile != "" || f.etcdCAFile != ""
+}
+
+// open returns the configured Store.
+func (f *storeFlags) open() (storage.Store, error) {
+	switch f.store {
+	case "etcd":
+		etcdConfig := &storage.EtcdConfig{
+			Endpoints: strings.Split(f.etcdEndpoints, ","),
+			Prefix:    f.etcdPrefix,
+			Logger:    log,
+		}
+		if f.etcdTLS() {
+			tlsinfo := tlsutil.TLSInfo{
+				CertFile: f.etcdCertFile,
+				KeyFile:  f.etcdKeyFile,
+				CAFile:   f.etcdCAFile,
+			}
+			var err error
+			etcdConfig.TLS, err = tlsinfo.ClientConfig()
+			if err != nil {
+				return nil, fmt.Errorf("Invalid etcd TLS credentials: %v", err)
+			}
+		}
+		log.Infof("Using etcd store at %s", f.etcdEndpoints)
+		store, err := storage.NewEtcdStore(etcdConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to etcd: %v", err)
+		}
+		return store, nil
+	case "bolt":
+		boltConfig := &storage.BoltConfig{
+			Path:   f.boltPath,
+			Logger: log,
+		}
+		if f.boltImport {
+			boltConfig.ImportRoot = f.dataPaths.paths[0]
+		}
+		log.Infof("Using bolt store at %s", f.boltPath)
+		store, err := storage.NewBoltStore(boltConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open bolt database: %v", err)
+		}
+		return store, nil
+	}
+	// the last -data-path is the writable top layer
+	paths := f.dataPaths.paths
+	layers := make([]storage.Store, len(paths))
+	for i, dataPath := range paths {
+		layers[i] = storage.NewFileStore(&storage.Config{
+			Root:   dataPath,
+			Logger: log,
+		})
+	}
+	top := paths[len(paths)-1]
+	if f.store == "git" {
+		log.Infof("Using git store at %s", top)
+		store, err := storage.NewGitStore(&storage.GitConfig{
+			Root:   top,
+			Ref:    f.gitRef,
+			Logger: log,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open git working tree: %v", err)
+		}
+		layers[len(layers)-1] = store
+	}
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+	log.Infof("Using %s as the writable layer over read-only layers %s", top, strings.Join(paths[:len(paths)-1], ", "))
+	store, err := storage.NewOverlayStore(&storage.OverlayConfig{
+		Layers: layers,
+		Logger: log,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to overlay -%sdata-path layers: %v", f.prefix, err)
+	}
+	return store, nil
+}
+
+// pathsFlag is a flag of paths which may be repeated or separated by the
+// path list separator. Set paths replace the default paths.
+type pathsFlag struct {
+	paths []string
+	set   bool
+}
+
+func (f *pathsFlag) String() string {
+	return strings.Join(f.paths, string(filepath.ListSeparator))
+}
+
+func (f *pathsFlag) Set(value string) error {
+	if !f.set {
+		f.paths = nil
+		f.set = true
+	}
+	for _, path := range filepath.SplitList(value) {
+		if path != "" {
+			f.paths = append(f.paths, path)
+		}
+	}
+	if len(f.paths) == 0 {
+		return fmt.Errorf("no path provided")
+	}
+	return nil
+}
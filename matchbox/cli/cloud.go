@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// cloudCmd represents the cloud command
+var cloudCmd = &cobra.Command{
+	Use:   "cloud",
+	Short: "Manage Cloud-Config templates",
+	Long:  `Manage Cloud-Config templates`,
+}
+
+func init() {
+	RootCmd.AddCommand(cloudCmd)
+}
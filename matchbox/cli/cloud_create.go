@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// cloudPutCmd creates and updates Cloud-Config templates.
+var (
+	cloudPutCmd = &cobra.Command{
+		Use:   "create --file FILENAME",
+		Short: "Create a Cloud-Config template",
+		Long:  `Create a Cloud-Config template`,
+		Run:   runCloudPutCmd,
+	}
+)
+
+func init() {
+	cloudCmd.AddCommand(cloudPutCmd)
+	cloudPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create a Cloud-Config template")
+	cloudPutCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "only update the Cloud-Config template if it has this resource version")
+	cloudPutCmd.MarkFlagRequired("filename")
+}
+
+func runCloudPutCmd(cmd *cobra.Command, args []string) {
+	if len(flagFilename) == 0 {
+		cmd.Help()
+		return
+	}
+	if err := validateArgs(cmd, args); err != nil {
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	config, err := ioutil.ReadFile(flagFilename)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	req := &pb.CloudPutRequest{Name: filepath.Base(flagFilename), Config: config, ResourceVersion: flagResourceVersion, Namespace: namespaceFromCmd(cmd)}
+	_, err = client.Cloud.CloudPut(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+var (
+	// cloudDeleteCmd deletes Cloud-Config templates.
+	cloudDeleteCmd = &cobra.Command{
+		Use:   "delete NAME",
+		Short: "Delete a Cloud-Config template",
+		Long:  `Delete a Cloud-Config template by name`,
+		Run:   runCloudDeleteCmd,
+	}
+	flagForce bool
+)
+
+func init() {
+	cloudCmd.AddCommand(cloudDeleteCmd)
+	cloudDeleteCmd.Flags().BoolVar(&flagForce, "force", false, "delete even if Profiles refer to the template")
+}
+
+func runCloudDeleteCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+	client := mustClientFromCmd(cmd)
+	_, err := client.Cloud.CloudDelete(context.TODO(), &pb.CloudDeleteRequest{Name: args[0], Namespace: namespaceFromCmd(cmd), Force: flagForce})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// cloudListCmd lists Cloud-Config templates.
+var cloudListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Cloud-Config templates",
+	Long:  `List the names of Cloud-Config templates`,
+	Run:   runCloudListCmd,
+}
+
+func init() {
+	cloudCmd.AddCommand(cloudListCmd)
+}
+
+func runCloudListCmd(cmd *cobra.Command, args []string) {
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Cloud.CloudList(context.TODO(), &pb.CloudListRequest{Namespace: namespaceFromCmd(cmd)})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\n")
+	for _, name := range resp.Names {
+		fmt.Fprintf(tw, "%s\n", name)
+	}
+}
@@ -23,6 +23,7 @@ var (
 func init() {
 	genericCmd.AddCommand(genericPutCmd)
 	genericPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create an Generic template")
+	genericPutCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "only update the Generic template if it has this resource version")
 	genericPutCmd.MarkFlagRequired("filename")
 }
 
@@ -40,7 +41,7 @@ func runGenericPutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
-	req := &pb.GenericPutRequest{Name: filepath.Base(flagFilename), Config: config}
+	req := &pb.GenericPutRequest{Name: filepath.Base(flagFilename), Config: config, ResourceVersion: flagResourceVersion, Namespace: namespaceFromCmd(cmd)}
 	_, err = client.Generic.GenericPut(context.TODO(), req)
 	if err != nil {
 		exitWithError(ExitError, err)
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// genericListCmd lists Generic templates.
+var genericListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Generic templates",
+	Long:  `List the names of Generic templates`,
+	Run:   runGenericListCmd,
+}
+
+func init() {
+	genericCmd.AddCommand(genericListCmd)
+}
+
+func runGenericListCmd(cmd *cobra.Command, args []string) {
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Generic.GenericList(context.TODO(), &pb.GenericListRequest{Namespace: namespaceFromCmd(cmd)})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\n")
+	for _, name := range resp.Names {
+		fmt.Fprintf(tw, "%s\n", name)
+	}
+}
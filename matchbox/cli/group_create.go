@@ -23,8 +23,9 @@ var (
 func init() {
 	groupCmd.AddCommand(groupPutCmd)
 	groupPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create a Group")
+	groupPutCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "only update the Group if it has this resource version")
 	groupPutCmd.MarkFlagRequired("filename")
-	groupPutCmd.MarkFlagFilename("filename", "json")
+	groupPutCmd.MarkFlagFilename("filename", "json", "yaml", "yml")
 }
 
 func runGroupPutCmd(cmd *cobra.Command, args []string) {
@@ -41,7 +42,8 @@ func runGroupPutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
-	req := &pb.GroupPutRequest{Group: group}
+	group.ResourceVersion = flagResourceVersion
+	req := &pb.GroupPutRequest{Group: group, Namespace: namespaceFromCmd(cmd)}
 	_, err = client.Groups.GroupPut(context.TODO(), req)
 	if err != nil {
 		exitWithError(ExitError, err)
@@ -53,5 +55,5 @@ func loadGroup(filename string) (*storagepb.Group, error) {
 	if err != nil {
 		return nil, err
 	}
-	return storagepb.ParseGroup(data)
+	return storagepb.ParseGroupFile(filename, data)
 }
@@ -1,13 +1,18 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"context"
 	"github.com/spf13/cobra"
 
+	"github.com/coreos/matchbox/matchbox/secret"
 	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
 )
 
 // groupDescribeCmd describes a Group.
@@ -32,16 +37,107 @@ func runGroupDescribeCmd(cmd *cobra.Command, args []string) {
 	defer tw.Flush()
 
 	// legend
-	fmt.Fprintf(tw, "ID\tNAME\tSELECTORS\tPROFILE\tMETADATA\n")
+	fmt.Fprintf(tw, "ID\tNAME\tSELECTORS\tPROFILE\tMETADATA\tVERSION\n")
 
 	client := mustClientFromCmd(cmd)
 	request := &pb.GroupGetRequest{
-		Id: args[0],
+		Id:        args[0],
+		Namespace: namespaceFromCmd(cmd),
 	}
 	resp, err := client.Groups.GroupGet(context.TODO(), request)
 	if err != nil {
 		return
 	}
 	g := resp.Group
-	fmt.Fprintf(tw, "%s\t%s\t%s\t%#v\t%s\n", g.Id, g.Name, g.Selector, g.Profile, g.Metadata)
+	// servers redact secret values, but older ones do not
+	metadata, err := secret.Redact(g.Metadata)
+	if err != nil {
+		metadata = g.Metadata
+	}
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%#v\t%s\t%d\n", g.Id, g.Name, g.Selector, g.Profile, metadata, g.ResourceVersion)
+
+	// metadata keys and the Group or Profiles they come from
+	layers := []metadataLayer{{"groups/" + g.Id, metadata}}
+	seen := make(map[string]bool)
+	for id := g.Profile; id != "" && !seen[id]; {
+		seen[id] = true
+		resp, err := client.Profiles.ProfileGet(context.TODO(), &pb.ProfileGetRequest{Id: id, Namespace: request.Namespace})
+		if err != nil {
+			break
+		}
+		layers = append(layers, metadataLayer{"profiles/" + id, resp.Profile.Metadata})
+		id = resp.Profile.Parent
+	}
+	keys, err := metadataKeys(layers)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	fmt.Fprintf(tw, "\nKEY\tVALUE\tSOURCE\n")
+	for _, key := range keys {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", key.name, key.value, strings.Join(key.sources, ", "))
+	}
+}
+
+// metadataLayer is the metadata of a Group or Profile.
+type metadataLayer struct {
+	source   string
+	metadata []byte
+}
+
+// metadataKey is a key of merged metadata, with its JSON encoded value and
+// the sources which set it, most specific first.
+type metadataKey struct {
+	name    string
+	value   string
+	sources []string
+}
+
+// metadataKeys returns the sorted keys of metadata layers, ordered from most
+// specific to least, merged as they are for rendering. A key set to an
+// object by several layers lists all of them as sources, since the objects
+// are merged, while other keys list only the layer which sets the value.
+func metadataKeys(layers []metadataLayer) ([]metadataKey, error) {
+	ordered := make([][]byte, len(layers))
+	for i, layer := range layers {
+		ordered[len(layers)-1-i] = layer.metadata
+	}
+	merged, err := storagepb.MergeMetadata(ordered...)
+	if err != nil || merged == nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(merged, &values); err != nil {
+		return nil, err
+	}
+	var keys []metadataKey
+	for name, value := range values {
+		key := metadataKey{name: name, value: string(value)}
+		for _, layer := range layers {
+			if layer.metadata == nil {
+				continue
+			}
+			var object map[string]interface{}
+			if err := json.Unmarshal(layer.metadata, &object); err != nil {
+				return nil, err
+			}
+			member, ok := object[name]
+			if !ok {
+				continue
+			}
+			_, merges := member.(map[string]interface{})
+			merges = merges && !secret.IsValue(member)
+			if len(key.sources) > 0 && !merges {
+				break
+			}
+			key.sources = append(key.sources, layer.source)
+			if !merges {
+				break
+			}
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].name < keys[j].name
+	})
+	return keys, nil
 }
@@ -29,7 +29,7 @@ func runGroupListCmd(cmd *cobra.Command, args []string) {
 	fmt.Fprintf(tw, "ID\tGROUP NAME\tSELECTORS\tPROFILE\n")
 
 	client := mustClientFromCmd(cmd)
-	resp, err := client.Groups.GroupList(context.TODO(), &pb.GroupListRequest{})
+	resp, err := client.Groups.GroupList(context.TODO(), &pb.GroupListRequest{Namespace: namespaceFromCmd(cmd)})
 	if err != nil {
 		return
 	}
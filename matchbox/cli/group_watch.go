@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// groupWatchCmd watches Groups for changes.
+var groupWatchCmd = &cobra.Command{
+	Use:   "watch [GROUP_ID]",
+	Short: "Watch machine groups for changes",
+	Long:  `Watch all machine groups, or a single machine group, and print each change as it happens`,
+	Run:   runGroupWatchCmd,
+}
+
+func init() {
+	groupCmd.AddCommand(groupWatchCmd)
+}
+
+func runGroupWatchCmd(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Help()
+		return
+	}
+	req := &pb.GroupWatchRequest{Namespace: namespaceFromCmd(cmd)}
+	if len(args) == 1 {
+		req.Id = args[0]
+	}
+
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "EVENT\tREVISION\tID\tSELECTORS\tPROFILE\n")
+	tw.Flush()
+
+	client := mustClientFromCmd(cmd)
+	stream, err := client.Groups.GroupWatch(context.Background(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			exitWithError(ExitError, err)
+		}
+		event := resp.Event
+		group := event.Group
+		if group == nil {
+			group = &storagepb.Group{}
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%#v\t%s\n", event.Type, event.Revision, event.Name, group.Selector, group.Profile)
+		tw.Flush()
+	}
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// runHistory prints the recorded revisions of a resource.
+func runHistory(cmd *cobra.Command, kind, name string) {
+	client := mustClientFromCmd(cmd)
+	resp, err := client.History.History(context.TODO(), &pb.HistoryRequest{Kind: kind, Name: name, Namespace: namespaceFromCmd(cmd)})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "VERSION\tTIME\tAUTHOR\tEVENT\n")
+	for _, rev := range resp.Revisions {
+		event := "PUT"
+		if rev.Deleted {
+			event = "DELETE"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", rev.ResourceVersion, time.Unix(rev.Time, 0).Format(time.RFC3339), rev.Author, event)
+	}
+}
+
+// runRollback restores a resource to a recorded revision and prints its new
+// resource version.
+func runRollback(cmd *cobra.Command, kind, name string, version int64) {
+	client := mustClientFromCmd(cmd)
+	req := &pb.RollbackRequest{Kind: kind, Name: name, ResourceVersion: version, Namespace: namespaceFromCmd(cmd)}
+	resp, err := client.History.Rollback(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	fmt.Printf("Rolled back %s, new resource version %d\n", name, resp.ResourceVersion)
+}
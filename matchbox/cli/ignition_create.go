@@ -23,6 +23,7 @@ var (
 func init() {
 	ignitionCmd.AddCommand(ignitionPutCmd)
 	ignitionPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create an Ignition template")
+	ignitionPutCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "only update the Ignition template if it has this resource version")
 	ignitionPutCmd.MarkFlagRequired("filename")
 }
 
@@ -40,7 +41,7 @@ func runIgnitionPutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
-	req := &pb.IgnitionPutRequest{Name: filepath.Base(flagFilename), Config: config}
+	req := &pb.IgnitionPutRequest{Name: filepath.Base(flagFilename), Config: config, ResourceVersion: flagResourceVersion, Namespace: namespaceFromCmd(cmd)}
 	_, err = client.Ignition.IgnitionPut(context.TODO(), req)
 	if err != nil {
 		exitWithError(ExitError, err)
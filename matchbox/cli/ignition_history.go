@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ignitionHistoryCmd shows the change history of an Ignition template.
+var ignitionHistoryCmd = &cobra.Command{
+	Use:   "history NAME",
+	Short: "Show the change history of an Ignition template",
+	Long:  `Show who changed an Ignition template and when, oldest change first`,
+	Run:   runIgnitionHistoryCmd,
+}
+
+func init() {
+	ignitionCmd.AddCommand(ignitionHistoryCmd)
+}
+
+func runIgnitionHistoryCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+	runHistory(cmd, "ignition", args[0])
+}
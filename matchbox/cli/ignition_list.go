@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// ignitionListCmd lists Ignition templates.
+var ignitionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Ignition templates",
+	Long:  `List the names of Ignition templates`,
+	Run:   runIgnitionListCmd,
+}
+
+func init() {
+	ignitionCmd.AddCommand(ignitionListCmd)
+}
+
+func runIgnitionListCmd(cmd *cobra.Command, args []string) {
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Ignition.IgnitionList(context.TODO(), &pb.IgnitionListRequest{Namespace: namespaceFromCmd(cmd)})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\n")
+	for _, name := range resp.Names {
+		fmt.Fprintf(tw, "%s\n", name)
+	}
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ignitionRollbackCmd restores an Ignition template to a recorded revision.
+var ignitionRollbackCmd = &cobra.Command{
+	Use:   "rollback NAME",
+	Short: "Roll back an Ignition template",
+	Long:  `Restore an Ignition template to the revision with the given resource version, or else to the revision before the latest one`,
+	Run:   runIgnitionRollbackCmd,
+}
+
+func init() {
+	ignitionCmd.AddCommand(ignitionRollbackCmd)
+	ignitionRollbackCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "resource version of the revision to restore")
+}
+
+func runIgnitionRollbackCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+	runRollback(cmd, "ignition", args[0], flagResourceVersion)
+}
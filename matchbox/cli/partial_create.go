@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// partialPutCmd creates and updates partial templates.
+var (
+	partialPutCmd = &cobra.Command{
+		Use:   "create --file FILENAME",
+		Short: "Create a partial template",
+		Long:  `Create a partial template`,
+		Run:   runPartialPutCmd,
+	}
+)
+
+func init() {
+	partialCmd.AddCommand(partialPutCmd)
+	partialPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create a partial template")
+	partialPutCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "only update the partial template if it has this resource version")
+	partialPutCmd.MarkFlagRequired("filename")
+}
+
+func runPartialPutCmd(cmd *cobra.Command, args []string) {
+	if len(flagFilename) == 0 {
+		cmd.Help()
+		return
+	}
+	if err := validateArgs(cmd, args); err != nil {
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	config, err := ioutil.ReadFile(flagFilename)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	req := &pb.PartialPutRequest{Name: filepath.Base(flagFilename), Config: config, ResourceVersion: flagResourceVersion, Namespace: namespaceFromCmd(cmd)}
+	_, err = client.Partials.PartialPut(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
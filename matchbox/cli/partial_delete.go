@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// partialDeleteCmd deletes partial templates.
+var partialDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete a partial template",
+	Long:  `Delete a partial template by name`,
+	Run:   runPartialDeleteCmd,
+}
+
+func init() {
+	partialCmd.AddCommand(partialDeleteCmd)
+	partialDeleteCmd.Flags().BoolVar(&flagForce, "force", false, "delete even if templates include the partial template")
+}
+
+func runPartialDeleteCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+	client := mustClientFromCmd(cmd)
+	_, err := client.Partials.PartialDelete(context.TODO(), &pb.PartialDeleteRequest{Name: args[0], Namespace: namespaceFromCmd(cmd), Force: flagForce})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
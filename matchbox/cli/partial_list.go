@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// partialListCmd lists partial templates.
+var partialListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List partial templates",
+	Long:  `List the names of partial templates`,
+	Run:   runPartialListCmd,
+}
+
+func init() {
+	partialCmd.AddCommand(partialListCmd)
+}
+
+func runPartialListCmd(cmd *cobra.Command, args []string) {
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Partials.PartialList(context.TODO(), &pb.PartialListRequest{Namespace: namespaceFromCmd(cmd)})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\n")
+	for _, name := range resp.Names {
+		fmt.Fprintf(tw, "%s\n", name)
+	}
+}
@@ -18,14 +18,16 @@ var (
 		Long:  `Create a machine profile`,
 		Run:   runProfilePutCmd,
 	}
-	flagFilename string
+	flagFilename        string
+	flagResourceVersion int64
 )
 
 func init() {
 	profileCmd.AddCommand(profilePutCmd)
 	profilePutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create a Profile")
+	profilePutCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "only update the Profile if it has this resource version")
 	profilePutCmd.MarkFlagRequired("filename")
-	profilePutCmd.MarkFlagFilename("filename", "json")
+	profilePutCmd.MarkFlagFilename("filename", "json", "yaml", "yml")
 }
 
 func runProfilePutCmd(cmd *cobra.Command, args []string) {
@@ -42,7 +44,8 @@ func runProfilePutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
-	req := &pb.ProfilePutRequest{Profile: profile}
+	profile.ResourceVersion = flagResourceVersion
+	req := &pb.ProfilePutRequest{Profile: profile, Namespace: namespaceFromCmd(cmd)}
 	_, err = client.Profiles.ProfilePut(context.TODO(), req)
 	if err != nil {
 		exitWithError(ExitError, err)
@@ -61,5 +64,5 @@ func loadProfile(filename string) (*storagepb.Profile, error) {
 	if err != nil {
 		return nil, err
 	}
-	return storagepb.ParseProfile(data)
+	return storagepb.ParseProfileFile(filename, data)
 }
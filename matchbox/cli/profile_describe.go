@@ -10,16 +10,20 @@ import (
 	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
 )
 
-// profileDescribeCmd describes a Profile.
-var profileDescribeCmd = &cobra.Command{
-	Use:   "describe PROFILE_ID",
-	Short: "Describe a machine profile",
-	Long:  `Describe a machine profile`,
-	Run:   runProfileDescribeCmd,
-}
+var (
+	// profileDescribeCmd describes a Profile.
+	profileDescribeCmd = &cobra.Command{
+		Use:   "describe PROFILE_ID",
+		Short: "Describe a machine profile",
+		Long:  `Describe a machine profile`,
+		Run:   runProfileDescribeCmd,
+	}
+	flagResolve bool
+)
 
 func init() {
 	profileCmd.AddCommand(profileDescribeCmd)
+	profileDescribeCmd.Flags().BoolVar(&flagResolve, "resolve", false, "describe the Profile with the settings inherited from its parents")
 }
 
 func runProfileDescribeCmd(cmd *cobra.Command, args []string) {
@@ -31,16 +35,18 @@ func runProfileDescribeCmd(cmd *cobra.Command, args []string) {
 	tw := newTabWriter(os.Stdout)
 	defer tw.Flush()
 	// legend
-	fmt.Fprintf(tw, "ID\tNAME\tIGNITION\tCLOUD\tKERNEL\tINITRD\tARGS\n")
+	fmt.Fprintf(tw, "ID\tNAME\tPARENT\tIGNITION\tCLOUD\tKERNEL\tINITRD\tARGS\tVERSION\n")
 
 	client := mustClientFromCmd(cmd)
 	request := &pb.ProfileGetRequest{
-		Id: args[0],
+		Id:        args[0],
+		Namespace: namespaceFromCmd(cmd),
+		Resolve:   flagResolve,
 	}
 	resp, err := client.Profiles.ProfileGet(context.TODO(), request)
 	if err != nil {
 		return
 	}
 	p := resp.Profile
-	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", p.Id, p.Name, p.IgnitionId, p.CloudId, p.Boot.Kernel, p.Boot.Initrd, p.Boot.Args)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n", p.Id, p.Name, p.Parent, p.IgnitionTemplates(), p.CloudId, p.GetBoot().GetKernel(), p.GetBoot().GetInitrd(), p.GetBoot().GetArgs(), p.ResourceVersion)
 }
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// profileHistoryCmd shows the change history of a profile.
+var profileHistoryCmd = &cobra.Command{
+	Use:   "history PROFILE_ID",
+	Short: "Show the change history of a profile",
+	Long:  `Show who changed a profile and when, oldest change first`,
+	Run:   runProfileHistoryCmd,
+}
+
+func init() {
+	profileCmd.AddCommand(profileHistoryCmd)
+}
+
+func runProfileHistoryCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+	runHistory(cmd, "profiles", args[0])
+}
@@ -29,11 +29,11 @@ func runProfileListCmd(cmd *cobra.Command, args []string) {
 	fmt.Fprintf(tw, "ID\tPROFILE NAME\tIGNITION\tCLOUD\n")
 
 	client := mustClientFromCmd(cmd)
-	resp, err := client.Profiles.ProfileList(context.TODO(), &pb.ProfileListRequest{})
+	resp, err := client.Profiles.ProfileList(context.TODO(), &pb.ProfileListRequest{Namespace: namespaceFromCmd(cmd)})
 	if err != nil {
 		return
 	}
 	for _, profile := range resp.Profiles {
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", profile.Id, profile.Name, profile.IgnitionId, profile.CloudId)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", profile.Id, profile.Name, profile.IgnitionTemplates(), profile.CloudId)
 	}
 }
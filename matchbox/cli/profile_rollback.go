@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// profileRollbackCmd restores a profile to a recorded revision.
+var profileRollbackCmd = &cobra.Command{
+	Use:   "rollback PROFILE_ID",
+	Short: "Roll back a profile",
+	Long:  `Restore a profile to the revision with the given resource version, or else to the revision before the latest one`,
+	Run:   runProfileRollbackCmd,
+}
+
+func init() {
+	profileCmd.AddCommand(profileRollbackCmd)
+	profileRollbackCmd.Flags().Int64Var(&flagResourceVersion, "resource-version", 0, "resource version of the revision to restore")
+}
+
+func runProfileRollbackCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+	runRollback(cmd, "profiles", args[0], flagResourceVersion)
+}
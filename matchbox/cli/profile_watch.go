@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// profileWatchCmd watches Profiles for changes.
+var profileWatchCmd = &cobra.Command{
+	Use:   "watch [PROFILE_ID]",
+	Short: "Watch profiles for changes",
+	Long:  `Watch all profiles, or a single profile, and print each change as it happens`,
+	Run:   runProfileWatchCmd,
+}
+
+func init() {
+	profileCmd.AddCommand(profileWatchCmd)
+}
+
+func runProfileWatchCmd(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Help()
+		return
+	}
+	req := &pb.ProfileWatchRequest{Namespace: namespaceFromCmd(cmd)}
+	if len(args) == 1 {
+		req.Id = args[0]
+	}
+
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "EVENT\tREVISION\tID\tPROFILE NAME\tIGNITION\tCLOUD\n")
+	tw.Flush()
+
+	client := mustClientFromCmd(cmd)
+	stream, err := client.Profiles.ProfileWatch(context.Background(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			exitWithError(ExitError, err)
+		}
+		event := resp.Event
+		profile := event.Profile
+		if profile == nil {
+			profile = &storagepb.Profile{}
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", event.Type, event.Revision, event.Name, profile.Name, profile.IgnitionTemplates(), profile.CloudId)
+		tw.Flush()
+	}
+}
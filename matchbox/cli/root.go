@@ -26,6 +26,7 @@ To get help about a resource or command, run "bootcmd help resource"`,
 		caFile    string
 		certFile  string
 		keyFile   string
+		namespace string
 	}{}
 )
 
@@ -36,6 +37,7 @@ func init() {
 	// gRPC TLS Client Authentication
 	RootCmd.PersistentFlags().StringVar(&globalFlags.certFile, "cert-file", "/etc/matchbox/client.crt", "Path to the client TLS certificate file")
 	RootCmd.PersistentFlags().StringVar(&globalFlags.keyFile, "key-file", "/etc/matchbox/client.key", "Path to the client TLS key file")
+	RootCmd.PersistentFlags().StringVar(&globalFlags.namespace, "namespace", "", "Namespace of the resources (default namespace if empty)")
 	cobra.EnablePrefixMatching = true
 }
 
@@ -101,3 +103,12 @@ func tlsInfoFromCmd(cmd *cobra.Command) *tlsutil.TLSInfo {
 		KeyFile:  keyFile,
 	}
 }
+
+// namespaceFromCmd returns the namespace argument.
+func namespaceFromCmd(cmd *cobra.Command) string {
+	namespace, err := cmd.Flags().GetString("namespace")
+	if err != nil {
+		exitWithError(ExitBadArgs, err)
+	}
+	return namespace
+}
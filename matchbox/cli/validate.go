@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// validateCmd reports the problems of the stored resources.
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Report problems of groups, profiles, and templates",
+	Long: `Report groups and profiles which cannot be parsed, groups whose profile
+does not exist, and profiles whose templates do not exist. Exits with a
+non-zero status if there are problems.`,
+	Run: runValidateCmd,
+}
+
+func init() {
+	RootCmd.AddCommand(validateCmd)
+}
+
+func runValidateCmd(cmd *cobra.Command, args []string) {
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Validate.Validate(context.TODO(), &pb.ValidateRequest{Namespace: namespaceFromCmd(cmd)})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	if len(resp.Problems) == 0 {
+		fmt.Println("No problems found")
+		return
+	}
+
+	tw := newTabWriter(os.Stdout)
+	// legend
+	fmt.Fprintf(tw, "KIND\tNAME\tPROBLEM\n")
+	for _, problem := range resp.Problems {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", problem.Kind, problem.Name, problem.Message)
+	}
+	tw.Flush()
+	os.Exit(ExitError)
+}
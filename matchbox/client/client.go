@@ -34,7 +34,11 @@ type Client struct {
 	Profiles rpcpb.ProfilesClient
 	Ignition rpcpb.IgnitionClient
 	Generic  rpcpb.GenericClient
+	Cloud    rpcpb.CloudClient
+	Partials rpcpb.PartialsClient
 	Select   rpcpb.SelectClient
+	History  rpcpb.HistoryClient
+	Validate rpcpb.ValidateClient
 	conn     *grpc.ClientConn
 }
 
@@ -67,7 +71,11 @@ func newClient(config *Config) (*Client, error) {
 		Profiles: rpcpb.NewProfilesClient(conn),
 		Ignition: rpcpb.NewIgnitionClient(conn),
 		Generic:  rpcpb.NewGenericClient(conn),
+		Cloud:    rpcpb.NewCloudClient(conn),
+		Partials: rpcpb.NewPartialsClient(conn),
 		Select:   rpcpb.NewSelectClient(conn),
+		History:  rpcpb.NewHistoryClient(conn),
+		Validate: rpcpb.NewValidateClient(conn),
 	}
 	return client, nil
 }
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/vincent-petithory/dataurl"
+	"gopkg.in/yaml.v2"
+)
+
+// butaneVariants maps Butane config variants and versions to the Ignition
+// config spec versions they translate to.
+var butaneVariants = map[string]map[string]string{
+	"fcos": {
+		"1.0.0": "3.0.0",
+		"1.1.0": "3.1.0",
+		"1.2.0": "3.2.0",
+		"1.3.0": "3.2.0",
+		"1.4.0": "3.3.0",
+		"1.5.0": "3.4.0",
+		"1.6.0": "3.5.0",
+	},
+	"flatcar": {
+		"1.0.0": "3.3.0",
+		"1.1.0": "3.4.0",
+	},
+}
+
+// butaneSugar maps the keys of Butane sugar, which needs local files or
+// expands into other fields, to what they are for. Sugar is not translated.
+var butaneSugar = map[string]string{
+	"boot_device":               "boot disk layouts",
+	"contents_local":            "local unit contents",
+	"grub":                      "GRUB configs",
+	"local":                     "local files",
+	"ssh_authorized_keys_local": "local SSH key files",
+	"trees":                     "local directory trees",
+	"with_mount_unit":           "generated mount units",
+}
+
+// isButane returns true if the file should be treated as a Butane config.
+func isButane(filename string) bool {
+	return strings.HasSuffix(filename, ".bu") || strings.HasSuffix(filename, ".butane")
+}
+
+// renderButane renders a Butane config template with data and translates
+// it into a raw Ignition config.
+func (s *Server) renderButane(r *ignitionRender, name, contents string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.renderTemplate(&buf, r.data, r.partials, contents); err != nil {
+		return nil, err
+	}
+	raw, err := translateButane(buf.Bytes())
+	if err != nil {
+		s.logger.Errorf("error translating Butane config %s: %v", name, err)
+		return nil, err
+	}
+	return raw, nil
+}
+
+// translateButane translates a Butane config into a raw Ignition config of
+// the spec version its variant and version translate to. Butane keys are the
+// snake_case forms of Ignition's, and file contents may be given inline.
+// Other Butane sugar (see butaneSugar) is rejected. Ignition validates the
+// result.
+func translateButane(contents []byte) ([]byte, error) {
+	var config map[interface{}]interface{}
+	if err := yaml.Unmarshal(contents, &config); err != nil {
+		return nil, err
+	}
+	variant, _ := config["variant"].(string)
+	version, _ := config["version"].(string)
+	specVersion, ok := butaneVariants[variant][version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported Butane config variant %q version %q", variant, version)
+	}
+	spec := getIgnitionSpec(*semver.New(specVersion))
+	if spec == nil {
+		return nil, fmt.Errorf("unsupported Ignition config version %s", specVersion)
+	}
+	delete(config, "variant")
+	delete(config, "version")
+
+	tree, err := butaneValue(config, spec.config, "")
+	if err != nil {
+		return nil, err
+	}
+	ignition, ok := tree.(map[string]interface{})["ignition"].(map[string]interface{})
+	if !ok {
+		ignition = make(map[string]interface{})
+		tree.(map[string]interface{})["ignition"] = ignition
+	}
+	ignition["version"] = specVersion
+	return json.Marshal(tree)
+}
+
+// butaneValue converts a decoded Butane value into the JSON value of the
+// Ignition type t. Values which do not match their type are left to
+// Ignition validation.
+func butaneValue(value interface{}, t reflect.Type, path string) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return value, nil
+		}
+		return butaneObject(m, t, path)
+	case reflect.Slice:
+		list, ok := value.([]interface{})
+		if !ok {
+			return value, nil
+		}
+		result := make([]interface{}, len(list))
+		for i, item := range list {
+			var err error
+			if result[i], err = butaneValue(item, t.Elem(), fmt.Sprintf("%s.%d", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+	if _, ok := value.(map[interface{}]interface{}); ok {
+		return nil, fmt.Errorf("unexpected object at %s", strings.TrimPrefix(path, "."))
+	}
+	return value, nil
+}
+
+// butaneObject converts a decoded Butane object into the JSON object of the
+// Ignition struct type t.
+func butaneObject(m map[interface{}]interface{}, t reflect.Type, path string) (map[string]interface{}, error) {
+	fields := make(map[string]reflect.StructField)
+	collectFields(t, fields)
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		key := fmt.Sprint(k)
+		keyPath := path + "." + key
+		if _, ok := fields["source"]; ok && t.Name() == "Resource" && key == "inline" {
+			if _, ok := m["source"]; ok {
+				return nil, fmt.Errorf("%s and source are both set", strings.TrimPrefix(keyPath, "."))
+			}
+			result["source"] = "data:," + dataurl.EscapeString(fmt.Sprint(v))
+			continue
+		}
+		field, ok := fields[key]
+		if !ok {
+			if sugar, ok := butaneSugar[key]; ok {
+				return nil, fmt.Errorf("unsupported Butane key %s: %s are not supported", strings.TrimPrefix(keyPath, "."), sugar)
+			}
+			return nil, fmt.Errorf("unknown key %s", strings.TrimPrefix(keyPath, "."))
+		}
+		value, err := butaneValue(v, field.Type, keyPath)
+		if err != nil {
+			return nil, err
+		}
+		result[jsonName(field)] = value
+	}
+	return result, nil
+}
+
+// collectFields adds the fields of a struct type, including those of
+// embedded structs, by their Butane names.
+func collectFields(t reflect.Type, fields map[string]reflect.StructField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectFields(field.Type, fields)
+			continue
+		}
+		fields[butaneName(jsonName(field))] = field
+	}
+}
+
+// butaneName returns the snake_case Butane name of an Ignition JSON name
+// (e.g. sshAuthorizedKeys is ssh_authorized_keys and sizeMiB is size_mib).
+func butaneName(name string) string {
+	name = strings.Replace(name, "MiB", "Mib", -1)
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// jsonName returns the JSON name of a struct field.
+func jsonName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
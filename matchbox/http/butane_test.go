@@ -0,0 +1,92 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateButane(t *testing.T) {
+	cases := []struct {
+		butane   string
+		expected string
+	}{
+		{"variant: fcos\nversion: 1.0.0\n", `{"ignition":{"version":"3.0.0"}}`},
+		{"variant: flatcar\nversion: 1.1.0\nignition:\n  timeouts:\n    http_total: 30\n", `{"ignition":{"timeouts":{"httpTotal":30},"version":"3.4.0"}}`},
+		{
+			`
+variant: fcos
+version: 1.4.0
+passwd:
+  users:
+    - name: core
+      ssh_authorized_keys:
+        - ssh-ed25519 AAAA
+storage:
+  disks:
+    - device: /dev/vda
+      partitions:
+        - label: data
+          size_mib: 1024
+  files:
+    - path: /etc/hostname
+      mode: 0644
+      contents:
+        inline: "node1 #1"
+systemd:
+  units:
+    - name: etcd.service
+      enabled: true
+kernel_arguments:
+  should_exist:
+    - quiet
+`,
+			`{"ignition":{"version":"3.3.0"},"kernelArguments":{"shouldExist":["quiet"]},"passwd":{"users":[{"name":"core","sshAuthorizedKeys":["ssh-ed25519 AAAA"]}]},"storage":{"disks":[{"device":"/dev/vda","partitions":[{"label":"data","sizeMiB":1024}]}],"files":[{"contents":{"source":"data:,node1%20%231"},"mode":420,"path":"/etc/hostname"}]},"systemd":{"units":[{"enabled":true,"name":"etcd.service"}]}}`,
+		},
+	}
+	for _, c := range cases {
+		raw, err := translateButane([]byte(c.butane))
+		if assert.Nil(t, err, c.butane) {
+			assert.Equal(t, c.expected, string(raw), c.butane)
+		}
+	}
+}
+
+func TestButaneName(t *testing.T) {
+	cases := map[string]string{
+		"source":            "source",
+		"httpTotal":         "http_total",
+		"sshAuthorizedKeys": "ssh_authorized_keys",
+		"sizeMiB":           "size_mib",
+		"startMiB":          "start_mib",
+		"typeGuid":          "type_guid",
+		"shouldNotExist":    "should_not_exist",
+	}
+	for name, expected := range cases {
+		assert.Equal(t, expected, butaneName(name))
+	}
+}
+
+func TestTranslateButane_Errors(t *testing.T) {
+	cases := []struct {
+		butane string
+		err    string
+	}{
+		{"version: 1.4.0\n", `unsupported Butane config variant "" version "1.4.0"`},
+		{"variant: fcos\nversion: 1.7.0\n", `unsupported Butane config variant "fcos" version "1.7.0"`},
+		{"variant: fcos\nversion: 1.4.0\nstorage:\n  trees:\n    - local: etc\n", "unsupported Butane key storage.trees: local directory trees are not supported"},
+		{"variant: fcos\nversion: 1.4.0\nboot_device:\n  mirror:\n    devices: [/dev/vda, /dev/vdb]\n", "unsupported Butane key boot_device: boot disk layouts are not supported"},
+		{"variant: fcos\nversion: 1.5.0\ngrub:\n  users: []\n", "unsupported Butane key grub: GRUB configs are not supported"},
+		{"variant: fcos\nversion: 1.4.0\nstorage:\n  filesystems:\n    - device: /dev/vdb\n      with_mount_unit: true\n", "unsupported Butane key storage.filesystems.0.with_mount_unit: generated mount units are not supported"},
+		{"variant: fcos\nversion: 1.4.0\npasswd:\n  users:\n    - name: core\n      sshAuthorizedKeys: []\n", "unknown key passwd.users.0.sshAuthorizedKeys"},
+		{"variant: fcos\nversion: 1.4.0\nstorage:\n  disks:\n    - device: /dev/vda\n      wipetable: true\n", "unknown key storage.disks.0.wipetable"},
+		{"variant: fcos\nversion: 1.0.0\nkernel_arguments: {}\n", "unknown key kernel_arguments"},
+		{"variant: fcos\nversion: 1.4.0\nstorage:\n  files:\n    - path: /a\n      contents:\n        local: a\n", "unsupported Butane key storage.files.0.contents.local: local files are not supported"},
+		{"variant: fcos\nversion: 1.4.0\nstorage:\n  files:\n    - path: /a\n      contents:\n        inline: a\n        source: data:,b\n", "storage.files.0.contents.inline and source are both set"},
+		{"variant: fcos\nversion: 1.4.0\nsystemd:\n  units:\n    - name:\n        a: b\n", "unexpected object at systemd.units.0.name"},
+	}
+	for _, c := range cases {
+		_, err := translateButane([]byte(c.butane))
+		assert.EqualError(t, err, c.err, c.butane)
+	}
+}
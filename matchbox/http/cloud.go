@@ -34,7 +34,7 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 			return
 		}
 
-		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile})
+		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile, Resolve: true})
 		if err != nil {
 			s.logger.WithFields(logrus.Fields{
 				"labels":     labelsFromRequest(nil, req),
@@ -45,7 +45,7 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 			return
 		}
 
-		contents, err := core.CloudGet(ctx, profile.CloudId)
+		contents, err := core.CloudGet(ctx, &pb.CloudGetRequest{Name: profile.CloudId})
 		if err != nil {
 			s.logger.WithFields(logrus.Fields{
 				"labels":     labelsFromRequest(nil, req),
@@ -68,7 +68,7 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 		s.logger.Warning("Cloud-Config support will be removed in the future")
 
 		// collect data for rendering
-		data, err := collectVariables(req, group)
+		data, err := collectVariables(req, group, profile, s.secretKey)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
 			http.NotFound(w, req)
@@ -76,8 +76,14 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 		}
 
 		// render the template of a cloud config with data
+		partials, err := s.partials(ctx, core, contents)
+		if err != nil {
+			s.logger.Errorf("error getting partial templates: %v", err)
+			http.NotFound(w, req)
+			return
+		}
 		var buf bytes.Buffer
-		err = s.renderTemplate(&buf, data, contents)
+		err = s.renderTemplate(&buf, data, partials, contents)
 		if err != nil {
 			http.NotFound(w, req)
 			return
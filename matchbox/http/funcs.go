@@ -0,0 +1,384 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// templateFuncs are the functions of Container Linux Config, generic, and
+// Cloud-Config templates. Functions which take the value to transform take
+// it last, so they can be used in pipelines, e.g. {{.pem | indent 4}}.
+var templateFuncs = template.FuncMap{
+	// strings
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"title":      strings.Title,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.Replace(s, old, new, -1) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"split":      split,
+	"join":       join,
+	"quote":      strconv.Quote,
+	"indent":     indent,
+	"nindent":    func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+	// encoding
+	"b64enc":    func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec":    b64dec,
+	"sha256sum": func(s string) string { return fmt.Sprintf("%x", sha256.Sum256([]byte(s))) },
+	// defaults
+	"default":  defaultValue,
+	"empty":    empty,
+	"coalesce": coalesce,
+	"required": required,
+	// lists and maps
+	"list":   func(values ...interface{}) []interface{} { return values },
+	"first":  first,
+	"last":   last,
+	"has":    has,
+	"dict":   dict,
+	"keys":   keys,
+	"hasKey": hasKey,
+	"get":    get,
+	// IP math
+	"cidrhost":    cidrhost,
+	"cidrnetmask": cidrnetmask,
+	"cidrsubnet":  cidrsubnet,
+	"ipadd":       ipadd,
+	// serialization
+	"toJson":       toJSON,
+	"toPrettyJson": toPrettyJSON,
+	"fromJson":     fromJSON,
+	"toYaml":       toYAML,
+}
+
+var errNotList = errors.New("template: value is not a list")
+
+// split returns the list of substrings of s separated by sep.
+func split(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// join returns the elements of a list, formatted as strings, joined by sep.
+func join(sep string, list interface{}) (string, error) {
+	values, err := toList(list)
+	if err != nil {
+		return "", err
+	}
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = fmt.Sprint(value)
+	}
+	return strings.Join(strs, sep), nil
+}
+
+// indent returns s with each line indented by the number of spaces.
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.Replace(s, "\n", "\n"+pad, -1)
+}
+
+// b64dec returns the string decoded from standard base64.
+func b64dec(s string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	return string(decoded), err
+}
+
+// defaultValue returns the value, or the default if the value is empty. Use
+// it with index or get for optional keys, e.g. {{index . "k" | default 1}}.
+func defaultValue(def interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || empty(value[0]) {
+		return def
+	}
+	return value[0]
+}
+
+// empty returns true if the value is nil, false, zero, or an empty string,
+// list, or map.
+func empty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	}
+	return false
+}
+
+// coalesce returns the first value which is not empty, or nil.
+func coalesce(values ...interface{}) interface{} {
+	for _, value := range values {
+		if !empty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+// required returns the value, or an error with the message if it is empty.
+func required(message string, value interface{}) (interface{}, error) {
+	if empty(value) {
+		return nil, errors.New(message)
+	}
+	return value, nil
+}
+
+// first returns the first element of a list, or nil if it is empty.
+func first(list interface{}) (interface{}, error) {
+	values, err := toList(list)
+	if err != nil || len(values) == 0 {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// last returns the last element of a list, or nil if it is empty.
+func last(list interface{}) (interface{}, error) {
+	values, err := toList(list)
+	if err != nil || len(values) == 0 {
+		return nil, err
+	}
+	return values[len(values)-1], nil
+}
+
+// has returns true if a list contains the value.
+func has(value interface{}, list interface{}) (bool, error) {
+	values, err := toList(list)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// dict returns a map of alternating keys and values.
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("template: dict requires pairs of keys and values")
+	}
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("template: dict key %v is not a string", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+// keys returns the sorted keys of a map.
+func keys(m map[string]interface{}) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// hasKey returns true if a map has the key.
+func hasKey(m map[string]interface{}, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+// get returns the value of a key of a map, or nil if the map does not have
+// the key.
+func get(m map[string]interface{}, key string) interface{} {
+	return m[key]
+}
+
+// cidrhost returns the IP address of the host with the given number within
+// a CIDR block, e.g. 10.3.0.10 for cidrhost "10.3.0.0/24" 10. Negative
+// numbers count back from the end of the block.
+func cidrhost(cidr string, num interface{}) (string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	n, err := toInt(num)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := network.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	offset := big.NewInt(int64(n))
+	if n < 0 {
+		offset.Add(offset, size)
+	}
+	if offset.Sign() < 0 || offset.Cmp(size) >= 0 {
+		return "", fmt.Errorf("template: host number %d is outside %s", n, cidr)
+	}
+	return addIP(network.IP, offset).String(), nil
+}
+
+// cidrnetmask returns the netmask of an IPv4 CIDR block in dotted decimal
+// notation, e.g. 255.255.255.0 for "10.3.0.0/24".
+func cidrnetmask(cidr string) (string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	if len(network.Mask) != net.IPv4len {
+		return "", fmt.Errorf("template: %s is not an IPv4 CIDR block", cidr)
+	}
+	return net.IP(network.Mask).String(), nil
+}
+
+// cidrsubnet returns the CIDR block of the subnet with the given number
+// within a CIDR block, which is newbits longer, e.g. 10.2.3.0/24 for
+// cidrsubnet "10.2.0.0/16" 8 3.
+func cidrsubnet(cidr string, newbits, num interface{}) (string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	extra, err := toInt(newbits)
+	if err != nil {
+		return "", err
+	}
+	n, err := toInt(num)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := network.Mask.Size()
+	if extra < 0 || ones+extra > bits {
+		return "", fmt.Errorf("template: cannot extend %s by %d bits", cidr, extra)
+	}
+	if n < 0 || big.NewInt(int64(n)).Cmp(new(big.Int).Lsh(big.NewInt(1), uint(extra))) >= 0 {
+		return "", fmt.Errorf("template: subnet number %d does not fit in %d bits", n, extra)
+	}
+	offset := new(big.Int).Lsh(big.NewInt(int64(n)), uint(bits-ones-extra))
+	subnet := &net.IPNet{
+		IP:   addIP(network.IP, offset),
+		Mask: net.CIDRMask(ones+extra, bits),
+	}
+	return subnet.String(), nil
+}
+
+// ipadd returns the IP address offset by a (possibly negative) number, e.g.
+// 10.0.0.12 for ipadd 2 "10.0.0.10".
+func ipadd(num interface{}, ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("template: invalid IP address %q", ip)
+	}
+	n, err := toInt(num)
+	if err != nil {
+		return "", err
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		sum := int64(binary.BigEndian.Uint32(v4)) + int64(n)
+		if sum < 0 || sum > 1<<32-1 {
+			return "", fmt.Errorf("template: %s offset by %d is not an IPv4 address", ip, n)
+		}
+		return addIP(net.IPv4zero.To4(), big.NewInt(sum)).String(), nil
+	}
+	sum := new(big.Int).Add(new(big.Int).SetBytes(parsed), big.NewInt(int64(n)))
+	if sum.Sign() < 0 || sum.BitLen() > 8*net.IPv6len {
+		return "", fmt.Errorf("template: %s offset by %d is not an IPv6 address", ip, n)
+	}
+	return addIP(net.IPv6zero, sum).String(), nil
+}
+
+// addIP returns the IP address offset by a non-negative number.
+func addIP(ip net.IP, offset *big.Int) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	sum := new(big.Int).Add(new(big.Int).SetBytes(ip), offset)
+	b := sum.Bytes()
+	result := make(net.IP, len(ip))
+	copy(result[len(result)-len(b):], b)
+	return result
+}
+
+// toJSON returns the JSON encoding of the value.
+func toJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// toPrettyJSON returns the indented JSON encoding of the value.
+func toPrettyJSON(value interface{}) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	return string(data), err
+}
+
+// fromJSON returns the value decoded from JSON.
+func fromJSON(s string) (interface{}, error) {
+	var value interface{}
+	err := json.Unmarshal([]byte(s), &value)
+	return value, err
+}
+
+// toYAML returns the YAML encoding of the value, without a trailing newline.
+func toYAML(value interface{}) (string, error) {
+	data, err := yaml.Marshal(value)
+	return strings.TrimSuffix(string(data), "\n"), err
+}
+
+// toList returns the elements of a slice or array.
+func toList(list interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, errNotList
+	}
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, nil
+}
+
+// toInt returns a number given as an integer, a whole JSON number, or a
+// decimal string.
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("template: %v is not an integer", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	}
+	return 0, fmt.Errorf("template: %v is not an integer", value)
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"testing"
+
+	logtest "github.com/Sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	// data as decoded from JSON metadata
+	data := map[string]interface{}{
+		"name":    "Node1",
+		"pem":     "-----BEGIN-----\nabc\n-----END-----",
+		"servers": []interface{}{"a.example.com", "b.example.com"},
+		"ports":   []interface{}{float64(80), float64(443)},
+		"network": map[string]interface{}{"cidr": "10.3.0.0/24", "offset": float64(10)},
+		"empty":   "",
+	}
+	cases := []struct {
+		tmpl     string
+		expected string
+	}{
+		// strings
+		{`{{upper .name}}`, "NODE1"},
+		{`{{lower .name}}`, "node1"},
+		{`{{title "worker node"}}`, "Worker Node"},
+		{`{{trim "  a b  "}}`, "a b"},
+		{`{{.name | trimPrefix "Node"}}`, "1"},
+		{`{{"node.example.com" | trimSuffix ".example.com"}}`, "node"},
+		{`{{"a-b-c" | replace "-" "."}}`, "a.b.c"},
+		{`{{contains "ode" .name}}`, "true"},
+		{`{{hasPrefix "Node" .name}}`, "true"},
+		{`{{hasSuffix "2" .name}}`, "false"},
+		{`{{index (split "," "a,b,c") 1}}`, "b"},
+		{`{{join ", " .servers}}`, "a.example.com, b.example.com"},
+		{`{{join ":" .ports}}`, "80:443"},
+		{`{{quote "a \"b\""}}`, `"a \"b\""`},
+		{`{{.pem | indent 2}}`, "  -----BEGIN-----\n  abc\n  -----END-----"},
+		{`key:{{.pem | nindent 2}}`, "key:\n  -----BEGIN-----\n  abc\n  -----END-----"},
+		// encoding
+		{`{{b64enc "hello"}}`, "aGVsbG8="},
+		{`{{b64dec "aGVsbG8="}}`, "hello"},
+		{`{{sha256sum "hello"}}`, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		// defaults
+		{`{{index . "missing" | default "fallback"}}`, "fallback"},
+		{`{{.empty | default "fallback"}}`, "fallback"},
+		{`{{.name | default "fallback"}}`, "Node1"},
+		{`{{empty .empty}} {{empty .ports}}`, "true false"},
+		{`{{coalesce .empty (index . "missing") .name}}`, "Node1"},
+		{`{{required "name is required" .name}}`, "Node1"},
+		// lists and maps
+		{`{{len (list 1 2 3)}}`, "3"},
+		{`{{first .servers}}`, "a.example.com"},
+		{`{{last .servers}}`, "b.example.com"},
+		{`{{has "b.example.com" .servers}} {{has "c.example.com" .servers}}`, "true false"},
+		{`{{$d := dict "a" 1 "b" 2}}{{$d.b}}`, "2"},
+		{`{{join "," (keys .network)}}`, "cidr,offset"},
+		{`{{hasKey .network "cidr"}} {{hasKey .network "gateway"}}`, "true false"},
+		{`{{get .network "gateway" | default "none"}}`, "none"},
+		// IP math
+		{`{{cidrhost .network.cidr .network.offset}}`, "10.3.0.10"},
+		{`{{cidrhost "10.3.0.0/24" -2}}`, "10.3.0.254"},
+		{`{{cidrhost "fd00::/64" 5}}`, "fd00::5"},
+		{`{{cidrnetmask "10.3.0.0/20"}}`, "255.255.240.0"},
+		{`{{cidrsubnet "10.2.0.0/16" 8 3}}`, "10.2.3.0/24"},
+		{`{{"10.0.0.255" | ipadd 2}}`, "10.0.1.1"},
+		{`{{"10.0.0.10" | ipadd -10}}`, "10.0.0.0"},
+		{`{{"fd00::ffff" | ipadd 1}}`, "fd00::1:0"},
+		// serialization
+		{`{{toJson .servers}}`, `["a.example.com","b.example.com"]`},
+		{`{{toPrettyJson .network}}`, "{\n  \"cidr\": \"10.3.0.0/24\",\n  \"offset\": 10\n}"},
+		{`{{(fromJson "{\"a\":[1,2]}").a | toJson}}`, "[1,2]"},
+		{`{{toYaml .network}}`, "cidr: 10.3.0.0/24\noffset: 10"},
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	for _, c := range cases {
+		var buf bytes.Buffer
+		err := srv.renderTemplate(&buf, data, nil, c.tmpl)
+		if assert.Nil(t, err, c.tmpl) {
+			assert.Equal(t, c.expected, buf.String(), c.tmpl)
+		}
+	}
+}
+
+func TestTemplateFuncs_Errors(t *testing.T) {
+	data := map[string]interface{}{
+		"name":  "node1",
+		"empty": "",
+	}
+	cases := []string{
+		`{{required "ssh key is required" .empty}}`,
+		`{{b64dec "not base64!"}}`,
+		`{{join "," .name}}`,
+		`{{first .name}}`,
+		`{{dict "a"}}`,
+		`{{dict 1 "a"}}`,
+		`{{cidrhost "10.3.0.0/24" 256}}`,
+		`{{cidrhost "10.3.0.0" 1}}`,
+		`{{cidrhost "10.3.0.0/24" 1.5}}`,
+		`{{cidrnetmask "fd00::/64"}}`,
+		`{{cidrsubnet "10.2.0.0/16" 20 1}}`,
+		`{{cidrsubnet "10.2.0.0/16" 8 256}}`,
+		`{{"255.255.255.255" | ipadd 1}}`,
+		`{{"not-an-ip" | ipadd 1}}`,
+		`{{fromJson "{"}}`,
+		// missing keys are still errors outside of index or get
+		`{{.missing | default "fallback"}}`,
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	for _, tmpl := range cases {
+		var buf bytes.Buffer
+		err := srv.renderTemplate(&buf, data, nil, tmpl)
+		assert.Error(t, err, tmpl)
+	}
+}
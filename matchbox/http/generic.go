@@ -25,7 +25,7 @@ func (s *Server) genericHandler(core server.Server) http.Handler {
 			http.NotFound(w, req)
 			return
 		}
-		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile})
+		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile, Resolve: true})
 		if err != nil {
 			s.logger.WithFields(logrus.Fields{
 				"labels":     labelsFromRequest(nil, req),
@@ -55,7 +55,7 @@ func (s *Server) genericHandler(core server.Server) http.Handler {
 		}).Debug("Matched a generic template")
 
 		// collect data for rendering
-		data, err := collectVariables(req, group)
+		data, err := collectVariables(req, group, profile, s.secretKey)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
 			http.NotFound(w, req)
@@ -63,8 +63,14 @@ func (s *Server) genericHandler(core server.Server) http.Handler {
 		}
 
 		// render the template of a generic config with data
+		partials, err := s.partials(ctx, core, contents)
+		if err != nil {
+			s.logger.Errorf("error getting partial templates: %v", err)
+			http.NotFound(w, req)
+			return
+		}
 		var buf bytes.Buffer
-		err = s.renderTemplate(&buf, data, contents)
+		err = s.renderTemplate(&buf, data, partials, contents)
 		if err != nil {
 			http.NotFound(w, req)
 			return
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -43,6 +44,87 @@ FOO=some-param
 	assert.Equal(t, expected, w.Body.String())
 }
 
+func TestGenericHandler_Partials(t *testing.T) {
+	content := `{{template "header.tmpl" .}}
+{{template "service" .}}
+`
+	expected := `# node a1b2c3d4
+SERVICE=etcd2
+`
+	store := &fake.FixedStore{
+		Profiles:       map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+		GenericConfigs: map[string]string{fake.Profile.GenericId: content},
+		Partials: map[string]string{
+			"header.tmpl":  `# node {{.uuid}}`,
+			"service.tmpl": `{{define "service"}}SERVICE={{.service_name}}{{end}}`,
+		},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.genericHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - Generic config includes partial templates and the templates they define
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, expected, w.Body.String())
+
+	// assert that templates which include missing partial templates are not served
+	store.GenericConfigs[fake.Profile.GenericId] = `{{template "missing.tmpl" .}}`
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// unlistablePartialsStore is a FixedStore whose partial templates cannot be
+// listed.
+type unlistablePartialsStore struct {
+	*fake.FixedStore
+}
+
+func (s unlistablePartialsStore) PartialList() ([]string, error) {
+	return nil, errors.New("unlistable")
+}
+
+func TestGenericHandler_PartialsLookedUp(t *testing.T) {
+	store := &fake.FixedStore{
+		Profiles:       map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+		GenericConfigs: map[string]string{fake.Profile.GenericId: `SERVICE={{.service_name}}`},
+		Partials: map[string]string{
+			"header.tmpl":  `# node {{.uuid}}{{template "footer.tmpl"}}`,
+			"footer.tmpl":  ` #`,
+			"service.tmpl": `{{define "service"}}SERVICE={{.service_name}}{{end}}`,
+		},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: unlistablePartialsStore{store}})
+	h := srv.genericHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	req, _ := http.NewRequest("GET", "/", nil)
+	// assert that templates which include no partial templates do not load them
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "SERVICE=etcd2", w.Body.String())
+
+	// assert that partial templates are looked up by the included names
+	store.GenericConfigs[fake.Profile.GenericId] = `{{template "header.tmpl" .}}`
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "# node a1b2c3d4 #", w.Body.String())
+
+	// assert that templates defined by partial templates need the list
+	store.GenericConfigs[fake.Profile.GenericId] = `{{template "service" .}}`
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestGenericHandler_MissingCtxProfile(t *testing.T) {
 	logger, _ := logtest.NewNullLogger()
 	srv := NewServer(&Config{Logger: logger})
@@ -2,7 +2,9 @@ package http
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/coreos/matchbox/matchbox/server"
 	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
@@ -30,6 +32,35 @@ func (s *Server) logRequest(next http.Handler) http.Handler {
 	return http.HandlerFunc(fn)
 }
 
+// selectNamespace scopes requests to the Server's namespace, else to the
+// namespace of the request's host name, else to the namespace named by the
+// "namespace" query parameter if enabled, and calls the next handler.
+// Requests without a namespace are scoped to the default namespace.
+func (s *Server) selectNamespace(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		namespace := s.namespace
+		if namespace == "" {
+			namespace = s.namespaceHosts[hostname(req.Host)]
+		}
+		if namespace == "" && s.namespaceQuery {
+			namespace = req.URL.Query().Get("namespace")
+		}
+		if namespace != "" {
+			req = req.WithContext(server.WithNamespace(req.Context(), namespace))
+		}
+		next.ServeHTTP(w, req)
+	}
+	return http.HandlerFunc(fn)
+}
+
+// hostname returns the lowercase host name of a host and optional port.
+func hostname(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	return strings.ToLower(host)
+}
+
 // selectGroup selects the Group whose selectors match the query parameters,
 // adds the Group to the ctx, and calls the next handler. The next handler
 // should handle a missing Group.
@@ -1,15 +1,20 @@
 package http
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	logtest "github.com/Sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage"
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
 	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
 )
@@ -64,3 +69,44 @@ func TestSelectProfile(t *testing.T) {
 	h.ServeHTTP(w, req)
 	assert.Equal(t, "next handler called", w.Body.String())
 }
+
+func TestSelectNamespace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	c := server.NewServer(&server.Config{Store: storage.NewFileStore(&storage.Config{Root: dir})})
+	for _, namespace := range []string{"team-a", "team-b"} {
+		_, err := c.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: &storagepb.Profile{Id: "p"}, Namespace: namespace})
+		assert.Nil(t, err)
+		group := &storagepb.Group{Id: namespace, Profile: "p", Selector: map[string]string{"uuid": "a1b2c3d4"}}
+		_, err = c.GroupPut(context.Background(), &pb.GroupPutRequest{Group: group, Namespace: namespace})
+		assert.Nil(t, err)
+	}
+	logger, _ := logtest.NewNullLogger()
+	cases := []struct {
+		config    *Config
+		url       string
+		namespace string
+	}{
+		{&Config{NamespaceQuery: true}, "http://matchbox/?uuid=a1b2c3d4&namespace=team-a", "team-a"},
+		{&Config{}, "http://matchbox/?uuid=a1b2c3d4&namespace=team-a", ""},
+		{&Config{NamespaceHosts: map[string]string{"team-b.matchbox": "team-b"}, NamespaceQuery: true}, "http://Team-B.matchbox:8080/?uuid=a1b2c3d4&namespace=team-a", "team-b"},
+		{&Config{Namespace: "team-a", NamespaceHosts: map[string]string{"team-b.matchbox": "team-b"}}, "http://team-b.matchbox/?uuid=a1b2c3d4", "team-a"},
+		{&Config{}, "http://matchbox/?uuid=a1b2c3d4", ""},
+	}
+	for _, tc := range cases {
+		tc.config.Logger = logger
+		srv := NewServer(tc.config)
+		var selected string
+		next := func(w http.ResponseWriter, req *http.Request) {
+			if group, err := groupFromContext(req.Context()); err == nil {
+				selected = group.Namespace
+			}
+		}
+		// assert that the Group is selected from the expected namespace
+		h := srv.selectNamespace(srv.selectGroup(c, http.HandlerFunc(next)))
+		req, _ := http.NewRequest("GET", tc.url, nil)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, tc.namespace, selected)
+	}
+}
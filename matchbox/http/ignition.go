@@ -2,22 +2,32 @@ package http
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
 	ct "github.com/coreos/container-linux-config-transpiler/config"
+	"github.com/coreos/go-semver/semver"
 	ignition "github.com/coreos/ignition/config"
+	ignitionV2_1 "github.com/coreos/ignition/config/v2_1"
+	ignitionTypes "github.com/coreos/ignition/config/v2_1/types"
+	"github.com/coreos/ignition/config/validate/report"
+	"github.com/coreos/ignition/v2/config/util"
 
 	"github.com/coreos/matchbox/matchbox/server"
 	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
 )
 
 // ignitionHandler returns a handler that responds with the Ignition config
-// matching the request. The Ignition file referenced in the Profile is parsed
-// as raw Ignition (for .ign/.ignition) or rendered from a Container Linux
-// Config (YAML) and converted to Ignition. Ignition configs are served as HTTP
-// JSON responses.
+// matching the request. Each Ignition file referenced in the Profile is parsed
+// as raw Ignition (for .ign/.ignition), or rendered from a Butane config (for
+// .bu/.butane or the butane Profile format) or a Container Linux Config (YAML)
+// and converted to Ignition, and the configs are merged in order. Ignition
+// configs are served as HTTP JSON responses.
 func (s *Server) ignitionHandler(core server.Server) http.Handler {
 	fn := func(w http.ResponseWriter, req *http.Request) {
 		ctx := req.Context()
@@ -30,7 +40,7 @@ func (s *Server) ignitionHandler(core server.Server) http.Handler {
 			return
 		}
 
-		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile})
+		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile, Resolve: true})
 		if err != nil {
 			s.logger.WithFields(logrus.Fields{
 				"labels":     labelsFromRequest(nil, req),
@@ -41,17 +51,31 @@ func (s *Server) ignitionHandler(core server.Server) http.Handler {
 			return
 		}
 
-		contents, err := core.IgnitionGet(ctx, &pb.IgnitionGetRequest{Name: profile.IgnitionId})
-		if err != nil {
+		names := profile.IgnitionTemplates()
+		if len(names) == 0 {
 			s.logger.WithFields(logrus.Fields{
 				"labels":     labelsFromRequest(nil, req),
 				"group":      group.Id,
 				"group_name": group.Name,
 				"profile":    group.Profile,
-			}).Infof("No Ignition or Container Linux Config template named: %s", profile.IgnitionId)
+			}).Infof("No Ignition or Container Linux Config template in profile: %s", group.Profile)
 			http.NotFound(w, req)
 			return
 		}
+		templates := make([]string, len(names))
+		for i, name := range names {
+			templates[i], err = core.IgnitionGet(ctx, &pb.IgnitionGetRequest{Name: name})
+			if err != nil {
+				s.logger.WithFields(logrus.Fields{
+					"labels":     labelsFromRequest(nil, req),
+					"group":      group.Id,
+					"group_name": group.Name,
+					"profile":    group.Profile,
+				}).Infof("No Ignition or Container Linux Config template named: %s", name)
+				http.NotFound(w, req)
+				return
+			}
+		}
 
 		// match was successful
 		s.logger.WithFields(logrus.Fields{
@@ -60,56 +84,240 @@ func (s *Server) ignitionHandler(core server.Server) http.Handler {
 			"profile": profile.Id,
 		}).Debug("Matched an Ignition or Container Linux Config template")
 
-		// Skip rendering if raw Ignition JSON is provided
-		if isIgnition(profile.IgnitionId) {
-			_, report, err := ignition.Parse([]byte(contents))
-			if err != nil {
-				s.logger.Warningf("warning parsing Ignition JSON: %s", report.String())
-			}
-			s.writeJSON(w, []byte(contents))
+		// Skip rendering if a single raw Ignition JSON of the requested
+		// version is provided
+		if len(names) == 1 && isIgnition(names[0]) && declaresVersion([]byte(templates[0]), profile.IgnitionVersion) {
+			r := &ignitionRender{}
+			r.warn(names[0], validateIgnition([]byte(templates[0])))
+			s.writeWarnings(w, r.warnings)
+			s.writeJSON(w, []byte(templates[0]))
 			return
 		}
 
-		// Container Linux Config template
-
 		// collect data for rendering
-		data, err := collectVariables(req, group)
+		data, err := collectVariables(req, group, profile, s.secretKey)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
 			http.NotFound(w, req)
 			return
 		}
-
-		// render the template for an Ignition config with data
-		var buf bytes.Buffer
-		err = s.renderTemplate(&buf, data, contents)
+		partials, err := s.partials(ctx, core, templates...)
 		if err != nil {
+			s.logger.Errorf("error getting partial templates: %v", err)
 			http.NotFound(w, req)
 			return
 		}
+		r := &ignitionRender{
+			data:     data,
+			partials: partials,
+			platform: profile.IgnitionPlatform,
+		}
 
-		// Parse bytes into a Container Linux Config
-		config, ast, report := ct.Parse(buf.Bytes())
-		if report.IsFatal() {
-			s.logger.Errorf("error parsing Container Linux config: %s", report.String())
+		// render each template as an Ignition spec 2.x config, or a spec 3.x
+		// config for Butane and raw spec 3.x templates
+		var configs []ignitionTypes.Config
+		var namesV3 []string
+		var configsV3 [][]byte
+		for i, name := range names {
+			switch {
+			case isIgnition(name) && isIgnitionV3([]byte(templates[i])):
+				namesV3 = append(namesV3, name)
+				configsV3 = append(configsV3, []byte(templates[i]))
+			case isButane(name) || (!isIgnition(name) && profile.IgnitionFormat == storagepb.IgnitionFormatButane):
+				raw, err := s.renderButane(r, name, templates[i])
+				if err != nil {
+					http.NotFound(w, req)
+					return
+				}
+				namesV3 = append(namesV3, name)
+				configsV3 = append(configsV3, raw)
+			default:
+				config, err := s.ignitionConfig(r, name, templates[i])
+				if err != nil {
+					http.NotFound(w, req)
+					return
+				}
+				configs = append(configs, config)
+			}
+		}
+		if len(configs) > 0 && len(configsV3) > 0 {
+			s.logger.Errorf("error merging Ignition configs of profile %s: spec 2.x and 3.x configs cannot be merged", profile.Id)
 			http.NotFound(w, req)
 			return
 		}
 
-		// Convert Container Linux Config into an Ignition Config
-		ign, report := ct.ConvertAs2_0(config, "", ast)
-		if report.IsFatal() {
-			s.logger.Errorf("error converting Container Linux config: %s", report.String())
+		// merge the Ignition configs in order
+		if len(configsV3) > 0 {
+			merged, err := s.mergeIgnitionV3(r, namesV3, configsV3, profile.IgnitionVersion)
+			if err != nil {
+				http.NotFound(w, req)
+				return
+			}
+			s.writeWarnings(w, r.warnings)
+			s.renderJSON(w, merged)
+			return
+		}
+		if !isVersion(profile.IgnitionVersion, ignitionTypes.MaxVersion) {
+			s.logger.Errorf("error converting Ignition configs of profile %s: spec 2.x configs are served as spec version %s, not %s", profile.Id, ignitionTypes.MaxVersion, profile.IgnitionVersion)
 			http.NotFound(w, req)
 			return
 		}
+		merged := configs[0]
+		for _, config := range configs[1:] {
+			merged = appendIgnition(merged, config)
+		}
+		merged.Ignition.Version = ignitionTypes.MaxVersion.String()
 
-		s.renderJSON(w, ign)
+		s.writeWarnings(w, r.warnings)
+		s.renderJSON(w, merged)
 		return
 	}
 	return http.HandlerFunc(fn)
 }
 
+// ignitionRender holds the data, partial templates, and Container Linux
+// Config platform the Ignition templates of a Profile are rendered with, and
+// the warnings reported while rendering them.
+type ignitionRender struct {
+	data     interface{}
+	partials map[string]string
+	platform string
+	warnings []string
+}
+
+// warn records warnings about a template.
+func (r *ignitionRender) warn(name string, messages []string) {
+	for _, message := range messages {
+		r.warnings = append(r.warnings, fmt.Sprintf("%s: %s", name, message))
+	}
+}
+
+// writeWarnings logs warnings and adds them to the response as Warning
+// headers, so clients such as curl see them too.
+func (s *Server) writeWarnings(w http.ResponseWriter, warnings []string) {
+	for _, warning := range warnings {
+		s.logger.Warningf("warning rendering Ignition config %s", warning)
+		w.Header().Add("Warning", fmt.Sprintf("199 matchbox %q", warning))
+	}
+}
+
+// ignitionConfig returns the Ignition config of a template, parsed as raw
+// Ignition or rendered from a Container Linux Config and converted for the
+// platform.
+func (s *Server) ignitionConfig(r *ignitionRender, name, contents string) (ignitionTypes.Config, error) {
+	if isIgnition(name) {
+		config, report, err := ignitionV2_1.Parse([]byte(contents))
+		if err != nil {
+			s.logger.Errorf("error parsing Ignition JSON %s: %v %s", name, err, report.String())
+			return config, err
+		}
+		r.warn(name, reportMessages(report))
+		return config, nil
+	}
+
+	// render the template for an Ignition config with data
+	var buf bytes.Buffer
+	if err := s.renderTemplate(&buf, r.data, r.partials, contents); err != nil {
+		return ignitionTypes.Config{}, err
+	}
+
+	// Parse bytes into a Container Linux Config
+	config, ast, report := ct.Parse(buf.Bytes())
+	if report.IsFatal() {
+		s.logger.Errorf("error parsing Container Linux config %s: %s", name, report.String())
+		return ignitionTypes.Config{}, errors.New(report.String())
+	}
+	r.warn(name, reportMessages(report))
+
+	// Convert Container Linux Config into an Ignition Config
+	ign, report := ct.ConvertAs2_0(config, r.platform, ast)
+	if report.IsFatal() {
+		s.logger.Errorf("error converting Container Linux config %s: %s", name, report.String())
+		return ignitionTypes.Config{}, errors.New(report.String())
+	}
+	r.warn(name, reportMessages(report))
+	return ign, nil
+}
+
+// appendIgnition appends an Ignition config to another with Ignition's
+// append semantics: lists such as systemd units, files, and users are
+// appended, nested structs are appended field by field, and other values are
+// replaced by those set in the appended config. The version of the first
+// config is kept.
+func appendIgnition(config, appended ignitionTypes.Config) ignitionTypes.Config {
+	return appendValue(reflect.ValueOf(config), reflect.ValueOf(appended)).Interface().(ignitionTypes.Config)
+}
+
+// appendValue returns the value appended to another value of the same type.
+func appendValue(old, appended reflect.Value) reflect.Value {
+	switch old.Kind() {
+	case reflect.Struct:
+		result := reflect.New(old.Type()).Elem()
+		for i := 0; i < old.NumField(); i++ {
+			if old.Type().Field(i).Name == "Version" {
+				result.Field(i).Set(old.Field(i))
+				continue
+			}
+			result.Field(i).Set(appendValue(old.Field(i), appended.Field(i)))
+		}
+		return result
+	case reflect.Slice:
+		result := reflect.MakeSlice(old.Type(), 0, old.Len()+appended.Len())
+		return reflect.AppendSlice(reflect.AppendSlice(result, old), appended)
+	}
+	if appended.IsZero() {
+		return old
+	}
+	return appended
+}
+
+// validateIgnition validates a raw Ignition config against the spec version
+// it declares and returns its problems.
+func validateIgnition(raw []byte) []string {
+	if isIgnitionV3(raw) {
+		report, err := validateIgnitionV3(raw)
+		if err != nil && len(report.Entries) == 0 {
+			return []string{err.Error()}
+		}
+		return reportMessagesV3(report)
+	}
+	_, report, err := ignition.Parse(raw)
+	if err != nil && len(report.Entries) == 0 {
+		return []string{err.Error()}
+	}
+	return reportMessages(report)
+}
+
+// reportMessages returns a message for each entry of a Container Linux
+// Config or Ignition spec 2.x report.
+func reportMessages(r report.Report) []string {
+	var messages []string
+	for _, entry := range r.Entries {
+		if entry.Line != 0 {
+			messages = append(messages, fmt.Sprintf("%s at line %d, column %d: %v", entry.Kind, entry.Line, entry.Column, entry.Message))
+		} else {
+			messages = append(messages, fmt.Sprintf("%s: %v", entry.Kind, entry.Message))
+		}
+	}
+	return messages
+}
+
+// declaresVersion returns true if version is empty or a raw Ignition config
+// declares it as its spec version.
+func declaresVersion(raw []byte, version string) bool {
+	declared, _, err := util.GetConfigVersion(raw)
+	return version == "" || (err == nil && isVersion(version, declared))
+}
+
+// isVersion returns true if version is empty or the semantic version v.
+func isVersion(version string, v semver.Version) bool {
+	if version == "" {
+		return true
+	}
+	parsed, err := semver.NewVersion(version)
+	return err == nil && *parsed == v
+}
+
 // isIgnition returns true if the file should be treated as plain Ignition.
 func isIgnition(filename string) bool {
 	return strings.HasSuffix(filename, ".ign") || strings.HasSuffix(filename, ".ignition")
@@ -7,6 +7,7 @@ import (
 
 	"context"
 	logtest "github.com/Sirupsen/logrus/hooks/test"
+	ignitionTypes "github.com/coreos/ignition/config/v2_1/types"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/coreos/matchbox/matchbox/server"
@@ -73,6 +74,213 @@ systemd:
 	assert.Equal(t, expectedIgnitionV2, w.Body.String())
 }
 
+func TestIgnitionHandler_Fragments(t *testing.T) {
+	base := `
+systemd:
+  units:
+    - name: etcd2.service
+      enable: true
+passwd:
+  users:
+    - name: core
+`
+	role := `
+systemd:
+  units:
+    - name: {{.uuid}}.service
+      enable: true
+storage:
+  files:
+    - path: /etc/hostname
+      filesystem: root
+      contents:
+        inline: {{.service_name}}
+`
+	site := `{"ignition":{"version":"2.1.0"},"passwd":{"users":[{"name":"admin"}]}}`
+	profile := &storagepb.Profile{
+		Id:          fake.Group.Profile,
+		IgnitionId:  "base.yaml",
+		IgnitionIds: []string{"role.yaml", "site.ign"},
+	}
+	store := &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Group.Profile: profile},
+		IgnitionConfigs: map[string]string{"base.yaml": base, "role.yaml": role, "site.ign": site},
+	}
+	expectedIgnitionV2 := `{"ignition":{"config":{},"timeouts":{},"version":"2.1.0"},"networkd":{},"passwd":{"users":[{"name":"core"},{"name":"admin"}]},"storage":{"files":[{"filesystem":"root","group":{},"path":"/etc/hostname","user":{},"contents":{"source":"data:,etcd2","verification":{}}}]},"systemd":{"units":[{"enable":true,"name":"etcd2.service"},{"enable":true,"name":"a1b2c3d4.service"}]}}`
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - each template is rendered or parsed as raw Ignition
+	// - systemd units, files, and users of all configs are merged in order
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	assert.Equal(t, expectedIgnitionV2, w.Body.String())
+
+	// assert that a missing template is not found
+	profile.IgnitionIds = append(profile.IgnitionIds, "missing.yaml")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestIgnitionHandler_Butane(t *testing.T) {
+	base := `
+variant: fcos
+version: 1.1.0
+systemd:
+  units:
+    - name: {{.service_name}}.service
+      enabled: true
+`
+	role := `
+variant: flatcar
+version: 1.0.0
+systemd:
+  units:
+    - name: {{.service_name}}.service
+      dropins:
+        - name: role.conf
+          contents: "[Service]"
+storage:
+  files:
+    - path: /etc/hostname
+      contents:
+        inline: {{.uuid}}
+`
+	site := `{"ignition":{"version":"3.2.0"},"passwd":{"users":[{"name":"admin"}]}}`
+	profile := &storagepb.Profile{
+		Id:             fake.Group.Profile,
+		IgnitionId:     "base.bu",
+		IgnitionIds:    []string{"role.yaml", "site.ign"},
+		IgnitionFormat: storagepb.IgnitionFormatButane,
+	}
+	store := &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Group.Profile: profile},
+		IgnitionConfigs: map[string]string{"base.bu": base, "role.yaml": role, "site.ign": site},
+	}
+	expectedIgnitionV3 := `{"ignition":{"config":{"replace":{"verification":{}}},"proxy":{},"security":{"tls":{}},"timeouts":{},"version":"3.3.0"},"kernelArguments":{},"passwd":{"users":[{"name":"admin"}]},"storage":{"files":[{"group":{},"path":"/etc/hostname","user":{},"contents":{"source":"data:,a1b2c3d4","verification":{}}}]},"systemd":{"units":[{"dropins":[{"contents":"[Service]","name":"role.conf"}],"enabled":true,"name":"etcd2.service"}]}}`
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - Butane templates are rendered and translated to Ignition spec 3.x
+	// - configs are translated to the latest spec version and merged by key
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	assert.Equal(t, expectedIgnitionV3, w.Body.String())
+
+	// assert that spec 2.x and 3.x configs are not merged
+	profile.IgnitionFormat = ""
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// assert that configs are translated to the requested spec version, but
+	// not to a version older than one they declare
+	profile.IgnitionFormat = storagepb.IgnitionFormatButane
+	profile.IgnitionVersion = "3.4.0"
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"version":"3.4.0"`)
+	profile.IgnitionVersion = "3.2.0"
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestIgnitionHandler_Platform(t *testing.T) {
+	content := `
+etcd:
+  advertise_client_urls: http://{PRIVATE_IPV4}:2379
+`
+	profile := &storagepb.Profile{
+		Id:               fake.Group.Profile,
+		IgnitionId:       "etcd.yaml",
+		IgnitionPlatform: "ec2",
+	}
+	store := &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Group.Profile: profile},
+		IgnitionConfigs: map[string]string{"etcd.yaml": content},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - dynamic data is converted for the Profile's platform
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "COREOS_EC2_IPV4_LOCAL")
+
+	// assert that dynamic data requires a platform
+	profile.IgnitionPlatform = ""
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// assert that spec 2.x configs are only served as spec version 2.1.0
+	profile.IgnitionPlatform = "ec2"
+	profile.IgnitionVersion = "3.0.0"
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestIgnitionHandler_V3JSON(t *testing.T) {
+	content := `{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"relative"}]}}`
+	profile := &storagepb.Profile{
+		Id:         fake.Group.Profile,
+		IgnitionId: "file.ign",
+	}
+	store := &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Group.Profile: profile},
+		IgnitionConfigs: map[string]string{"file.ign": content},
+	}
+	logger, hook := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - raw Ignition config served directly
+	// - validated against its declared spec version
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, content, w.Body.String())
+	if assert.NotNil(t, hook.LastEntry()) {
+		assert.Contains(t, hook.LastEntry().Message, "path not absolute")
+	}
+	// - validation problems are returned as Warning headers
+	assert.Contains(t, w.HeaderMap.Get("Warning"), "199 matchbox \"file.ign: ")
+	assert.Contains(t, w.HeaderMap.Get("Warning"), "path not absolute")
+}
+
+func TestIgnitionVersions(t *testing.T) {
+	// assert that Profiles accept exactly the spec versions which are served
+	served := []string{ignitionTypes.MaxVersion.String()}
+	for _, spec := range ignitionSpecs {
+		served = append(served, spec.version.String())
+	}
+	assert.Equal(t, served, storagepb.IgnitionVersions)
+}
+
 func TestIgnitionHandler_MissingCtxProfile(t *testing.T) {
 	logger, _ := logtest.NewNullLogger()
 	srv := NewServer(&Config{Logger: logger})
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/coreos/ignition/v2/config/merge"
+	"github.com/coreos/ignition/v2/config/util"
+	v3_0 "github.com/coreos/ignition/v2/config/v3_0"
+	v3_0types "github.com/coreos/ignition/v2/config/v3_0/types"
+	v3_1 "github.com/coreos/ignition/v2/config/v3_1"
+	v3_1types "github.com/coreos/ignition/v2/config/v3_1/types"
+	v3_2 "github.com/coreos/ignition/v2/config/v3_2"
+	v3_2types "github.com/coreos/ignition/v2/config/v3_2/types"
+	v3_3 "github.com/coreos/ignition/v2/config/v3_3"
+	v3_3types "github.com/coreos/ignition/v2/config/v3_3/types"
+	v3_4 "github.com/coreos/ignition/v2/config/v3_4"
+	v3_4types "github.com/coreos/ignition/v2/config/v3_4/types"
+	v3_5 "github.com/coreos/ignition/v2/config/v3_5"
+	v3_5types "github.com/coreos/ignition/v2/config/v3_5/types"
+	"github.com/coreos/vcontext/report"
+)
+
+// ignitionSpec is an Ignition config spec 3.x version.
+type ignitionSpec struct {
+	version semver.Version
+	// config is the type of configs of the version
+	config reflect.Type
+	// parse parses and validates a config of the version
+	parse func([]byte) (interface{}, report.Report, error)
+	// parseCompatible parses and validates a config of the version or an
+	// earlier 3.x version, translated to the version
+	parseCompatible func([]byte) (interface{}, report.Report, error)
+}
+
+// ignitionSpecs are the supported Ignition config spec 3.x versions.
+var ignitionSpecs = []ignitionSpec{
+	{
+		version:         v3_0types.MaxVersion,
+		config:          reflect.TypeOf(v3_0types.Config{}),
+		parse:           func(raw []byte) (interface{}, report.Report, error) { return v3_0.Parse(raw) },
+		parseCompatible: func(raw []byte) (interface{}, report.Report, error) { return v3_0.ParseCompatibleVersion(raw) },
+	},
+	{
+		version:         v3_1types.MaxVersion,
+		config:          reflect.TypeOf(v3_1types.Config{}),
+		parse:           func(raw []byte) (interface{}, report.Report, error) { return v3_1.Parse(raw) },
+		parseCompatible: func(raw []byte) (interface{}, report.Report, error) { return v3_1.ParseCompatibleVersion(raw) },
+	},
+	{
+		version:         v3_2types.MaxVersion,
+		config:          reflect.TypeOf(v3_2types.Config{}),
+		parse:           func(raw []byte) (interface{}, report.Report, error) { return v3_2.Parse(raw) },
+		parseCompatible: func(raw []byte) (interface{}, report.Report, error) { return v3_2.ParseCompatibleVersion(raw) },
+	},
+	{
+		version:         v3_3types.MaxVersion,
+		config:          reflect.TypeOf(v3_3types.Config{}),
+		parse:           func(raw []byte) (interface{}, report.Report, error) { return v3_3.Parse(raw) },
+		parseCompatible: func(raw []byte) (interface{}, report.Report, error) { return v3_3.ParseCompatibleVersion(raw) },
+	},
+	{
+		version:         v3_4types.MaxVersion,
+		config:          reflect.TypeOf(v3_4types.Config{}),
+		parse:           func(raw []byte) (interface{}, report.Report, error) { return v3_4.Parse(raw) },
+		parseCompatible: func(raw []byte) (interface{}, report.Report, error) { return v3_4.ParseCompatibleVersion(raw) },
+	},
+	{
+		version:         v3_5types.MaxVersion,
+		config:          reflect.TypeOf(v3_5types.Config{}),
+		parse:           func(raw []byte) (interface{}, report.Report, error) { return v3_5.Parse(raw) },
+		parseCompatible: func(raw []byte) (interface{}, report.Report, error) { return v3_5.ParseCompatibleVersion(raw) },
+	},
+}
+
+// getIgnitionSpec returns the Ignition config spec 3.x version, or nil if
+// the version is not supported.
+func getIgnitionSpec(version semver.Version) *ignitionSpec {
+	for i := range ignitionSpecs {
+		if ignitionSpecs[i].version == version {
+			return &ignitionSpecs[i]
+		}
+	}
+	return nil
+}
+
+// declaredIgnitionSpec returns the Ignition config spec 3.x version a raw
+// Ignition config declares.
+func declaredIgnitionSpec(raw []byte) (*ignitionSpec, error) {
+	version, _, err := util.GetConfigVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+	spec := getIgnitionSpec(version)
+	if spec == nil {
+		return nil, fmt.Errorf("unsupported Ignition config version %s", version)
+	}
+	return spec, nil
+}
+
+// isIgnitionV3 returns true if a raw Ignition config declares a spec 3.x
+// version.
+func isIgnitionV3(raw []byte) bool {
+	version, _, err := util.GetConfigVersion(raw)
+	return err == nil && version.Major == 3
+}
+
+// validateIgnitionV3 validates a raw Ignition config against the spec 3.x
+// version it declares.
+func validateIgnitionV3(raw []byte) (report.Report, error) {
+	spec, err := declaredIgnitionSpec(raw)
+	if err != nil {
+		return report.Report{}, err
+	}
+	_, rpt, err := spec.parse(raw)
+	return rpt, err
+}
+
+// mergeIgnitionV3 parses raw Ignition spec 3.x configs, translated to the
+// requested spec version or else the latest spec version they declare, and
+// merges them in order with Ignition's merge semantics: list entries such as
+// systemd units, files, and users are keyed by name or path, and entries of
+// later configs extend or replace those of earlier configs.
+func (s *Server) mergeIgnitionV3(r *ignitionRender, names []string, configs [][]byte, version string) (interface{}, error) {
+	var target *ignitionSpec
+	if version != "" {
+		if v, err := semver.NewVersion(version); err == nil {
+			target = getIgnitionSpec(*v)
+		}
+		if target == nil {
+			err := fmt.Errorf("unsupported Ignition config version %s", version)
+			s.logger.Errorf("error converting Ignition configs: %v", err)
+			return nil, err
+		}
+	}
+	var latest *ignitionSpec
+	for i, raw := range configs {
+		spec, err := declaredIgnitionSpec(raw)
+		if err != nil {
+			s.logger.Errorf("error parsing Ignition config %s: %v", names[i], err)
+			return nil, err
+		}
+		if target != nil && target.version.LessThan(spec.version) {
+			err = fmt.Errorf("spec version %s cannot be served as spec version %s", spec.version, target.version)
+			s.logger.Errorf("error converting Ignition config %s: %v", names[i], err)
+			return nil, err
+		}
+		if latest == nil || latest.version.LessThan(spec.version) {
+			latest = spec
+		}
+	}
+	if target == nil {
+		target = latest
+	}
+
+	var merged interface{}
+	for i, raw := range configs {
+		config, rpt, err := target.parseCompatible(raw)
+		if err != nil {
+			s.logger.Errorf("error parsing Ignition config %s: %v %s", names[i], err, rpt.String())
+			return nil, err
+		}
+		r.warn(names[i], reportMessagesV3(rpt))
+		if i == 0 {
+			merged = config
+		} else {
+			merged, _ = merge.MergeStructTranscribe(merged, config)
+		}
+	}
+	return merged, nil
+}
+
+// reportMessagesV3 returns a message for each entry of an Ignition spec 3.x
+// report.
+func reportMessagesV3(r report.Report) []string {
+	var messages []string
+	for _, entry := range r.Entries {
+		messages = append(messages, entry.String())
+	}
+	return messages
+}
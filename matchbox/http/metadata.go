@@ -7,13 +7,17 @@ import (
 	"strings"
 
 	"github.com/Sirupsen/logrus"
+
+	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
 )
 
 const plainContentType = "plain/text"
 
-// genericHandler returns a handler that responds with the metadata env file
-// matching the request.
-func (s *Server) metadataHandler() http.Handler {
+// metadataHandler returns a handler that responds with the metadata env file
+// matching the request, including the default metadata of the group's
+// profile if it exists.
+func (s *Server) metadataHandler(core server.Server) http.Handler {
 	fn := func(w http.ResponseWriter, req *http.Request) {
 		ctx := req.Context()
 		group, err := groupFromContext(ctx)
@@ -31,8 +35,16 @@ func (s *Server) metadataHandler() http.Handler {
 			"group":  group.Id,
 		}).Debug("Matched group metadata")
 
+		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile, Resolve: true})
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"group":   group.Id,
+				"profile": group.Profile,
+			}).Warningf("Rendering metadata without profile metadata: %v", err)
+		}
+
 		// collect data for rendering
-		data, err := collectVariables(req, group)
+		data, err := collectVariables(req, group, profile, s.secretKey)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
 			http.NotFound(w, req)
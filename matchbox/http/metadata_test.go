@@ -11,24 +11,36 @@ import (
 	logtest "github.com/Sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/coreos/matchbox/matchbox/server"
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
 )
 
 func TestMetadataHandler(t *testing.T) {
 	group := &storagepb.Group{
 		Id:       "test-group",
+		Profile:  "etcd",
 		Selector: map[string]string{"mac": "52:54:00:a1:9c:ae"},
 		Metadata: []byte(`{"meta":"data", "etcd":{"name":"node1"},"some":{"nested":{"data":"some-value"}}}`),
 	}
+	profile := &storagepb.Profile{
+		Id:       "etcd",
+		Metadata: []byte(`{"meta":"default","etcd":{"version":"3.3"},"dns_service_ip":"10.3.0.10"}`),
+	}
+	store := &fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{profile.Id: profile},
+	}
 	logger, _ := logtest.NewNullLogger()
 	srv := NewServer(&Config{Logger: logger})
-	h := srv.metadataHandler()
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.metadataHandler(c)
 	ctx := withGroup(context.Background(), group)
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/?mac=52-54-00-a1-9c-ae&foo=bar&count=3&gate=true", nil)
 	h.ServeHTTP(w, req.WithContext(ctx))
 	// assert that:
 	// - Group selectors, metadata, and query variables are formatted
+	// - Group metadata is merged over Profile metadata
 	// - nested metadata are namespaced
 	// - key names are upper case
 	// - key/value pairs are newline separated
@@ -37,6 +49,9 @@ func TestMetadataHandler(t *testing.T) {
 		"META":             "data",
 		"ETCD_NAME":        "node1",
 		"SOME_NESTED_DATA": "some-value",
+		// profile metadata
+		"ETCD_VERSION":   "3.3",
+		"DNS_SERVICE_IP": "10.3.0.10",
 		// group selector
 		"MAC": "52:54:00:a1:9c:ae",
 		// request
@@ -55,7 +70,8 @@ func TestMetadataHandler(t *testing.T) {
 func TestMetadataHandler_MetadataEdgeCases(t *testing.T) {
 	logger, _ := logtest.NewNullLogger()
 	srv := NewServer(&Config{Logger: logger})
-	h := srv.metadataHandler()
+	c := server.NewServer(&server.Config{Store: &fake.EmptyStore{}})
+	h := srv.metadataHandler(c)
 	// groups with different metadata
 	cases := []struct {
 		group    *storagepb.Group
@@ -82,7 +98,8 @@ func TestMetadataHandler_MetadataEdgeCases(t *testing.T) {
 func TestMetadataHandler_MissingCtxGroup(t *testing.T) {
 	logger, _ := logtest.NewNullLogger()
 	srv := NewServer(&Config{Logger: logger})
-	h := srv.metadataHandler()
+	c := server.NewServer(&server.Config{Store: &fake.EmptyStore{}})
+	h := srv.metadataHandler(c)
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/", nil)
 	h.ServeHTTP(w, req)
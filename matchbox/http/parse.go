@@ -8,20 +8,31 @@ import (
 
 	"github.com/Sirupsen/logrus"
 
+	"github.com/coreos/matchbox/matchbox/secret"
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
 )
 
-// collectVariables collects group selectors, metadata, and request-scoped
-// query parameters into a single structured map suitable for rendering
-// templates.
-func collectVariables(req *http.Request, group *storagepb.Group) (map[string]interface{}, error) {
+// collectVariables collects group selectors, metadata merged over the
+// profile's metadata, and request-scoped query parameters into a single
+// structured map suitable for rendering templates. The profile is optional.
+// Secret values of the metadata are decrypted with the key.
+func collectVariables(req *http.Request, group *storagepb.Group, profile *storagepb.Profile, key *secret.Key) (map[string]interface{}, error) {
 	data := make(map[string]interface{})
 	data["request"] = make(map[string]interface{})
-	if group.Metadata != nil {
-		err := json.Unmarshal(group.Metadata, &data)
+	metadata, err := storagepb.MergeMetadata(profile.GetMetadata(), group.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if metadata != nil {
+		err := json.Unmarshal(metadata, &data)
 		if err != nil {
 			return nil, err
 		}
+		for name, value := range data {
+			if data[name], err = secret.Decrypt(key, "/"+name, value); err != nil {
+				return nil, err
+			}
+		}
 	}
 	for key, value := range group.Selector {
 		data[strings.ToLower(key)] = value
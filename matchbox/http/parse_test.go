@@ -6,6 +6,9 @@ import (
 
 	logtest "github.com/Sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/secret"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
 )
 
 func TestLabelsFromRequest(t *testing.T) {
@@ -32,3 +35,33 @@ func TestLabelsFromRequest(t *testing.T) {
 		assert.Equal(t, c.labels, labelsFromRequest(logger, req))
 	}
 }
+
+func TestCollectVariables_Secrets(t *testing.T) {
+	key, err := secret.NewKey([]byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, err)
+	ciphertext, err := key.Encrypt("s3cr3t")
+	assert.Nil(t, err)
+	group := &storagepb.Group{
+		Metadata: []byte(`{"user":"core","token":{"$encrypted":"` + ciphertext + `"},"tls":{"keys":[{"$encrypted":"` + ciphertext + `"}]}}`),
+	}
+	req, err := http.NewRequest("GET", "http://a.io", nil)
+	assert.Nil(t, err)
+
+	// assert that secret values are decrypted for rendering
+	data, err := collectVariables(req, group, nil, key)
+	assert.Nil(t, err)
+	assert.Equal(t, "core", data["user"])
+	assert.Equal(t, "s3cr3t", data["token"])
+	assert.Equal(t, map[string]interface{}{"keys": []interface{}{"s3cr3t"}}, data["tls"])
+
+	// assert that they cannot be rendered without the key
+	_, err = collectVariables(req, group, nil, nil)
+	assert.Equal(t, secret.ErrNoKey, err)
+
+	// assert that decrypt errors name the secret value
+	group.Metadata = []byte(`{"tls":{"keys":[{"$encrypted":"AAAA"}]}}`)
+	_, err = collectVariables(req, group, nil, key)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "secret: cannot decrypt /tls/keys/0: ")
+	}
+}
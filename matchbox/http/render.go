@@ -1,10 +1,15 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
+	"os"
 	"text/template"
+
+	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
 )
 
 const (
@@ -34,8 +39,17 @@ func (s *Server) writeJSON(w http.ResponseWriter, data []byte) {
 	}
 }
 
-func (s *Server) renderTemplate(w io.Writer, data interface{}, contents ...string) (err error) {
-	tmpl := template.New("").Option("missingkey=error")
+// renderTemplate renders the template contents with data and the
+// templateFuncs function library. The contents may include the partial
+// templates by name, and the templates they define.
+func (s *Server) renderTemplate(w io.Writer, data interface{}, partials map[string]string, contents ...string) (err error) {
+	tmpl := template.New("").Funcs(templateFuncs).Option("missingkey=error")
+	for name, partial := range partials {
+		if _, err = tmpl.New(name).Parse(partial); err != nil {
+			s.logger.Errorf("error parsing partial template %s: %v", name, err)
+			return err
+		}
+	}
 	for _, content := range contents {
 		tmpl, err = tmpl.Parse(content)
 		if err != nil {
@@ -50,3 +64,49 @@ func (s *Server) renderTemplate(w io.Writer, data interface{}, contents ...strin
 	}
 	return nil
 }
+
+// partials returns the partial templates which templates include, directly or
+// through other partial templates, by name. Partial templates are looked up by
+// the included names. Only if a name is not that of a partial template, e.g.
+// because a partial template defines it, are all partial templates of the
+// namespace loaded. Templates which include none need no partial templates.
+func (s *Server) partials(ctx context.Context, core server.Server, templates ...string) (map[string]string, error) {
+	var pending []string
+	for _, contents := range templates {
+		pending = append(pending, server.TemplateIncludes("", contents)...)
+	}
+	partials := make(map[string]string)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, ok := partials[name]; ok {
+			continue
+		}
+		contents, err := core.PartialGet(ctx, &pb.PartialGetRequest{Name: name})
+		if os.IsNotExist(err) {
+			return s.allPartials(ctx, core)
+		}
+		if err != nil {
+			return nil, err
+		}
+		partials[name] = contents
+		pending = append(pending, server.TemplateIncludes(name, contents)...)
+	}
+	return partials, nil
+}
+
+// allPartials returns the partial templates of the namespace of a request by
+// name.
+func (s *Server) allPartials(ctx context.Context, core server.Server) (map[string]string, error) {
+	names, err := core.PartialList(ctx, &pb.PartialListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	partials := make(map[string]string, len(names))
+	for _, name := range names {
+		if partials[name], err = core.PartialGet(ctx, &pb.PartialGetRequest{Name: name}); err != nil {
+			return nil, err
+		}
+	}
+	return partials, nil
+}
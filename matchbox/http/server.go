@@ -5,6 +5,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 
+	"github.com/coreos/matchbox/matchbox/secret"
 	"github.com/coreos/matchbox/matchbox/server"
 	"github.com/coreos/matchbox/matchbox/sign"
 )
@@ -18,25 +19,41 @@ type Config struct {
 	// config signers (.sig and .asc)
 	Signer        sign.Signer
 	ArmoredSigner sign.Signer
+	// key of the secret values of Group metadata (optional)
+	SecretKey *secret.Key
+	// Namespace of all requests (optional)
+	Namespace string
+	// namespaces of requests by their host name (optional)
+	NamespaceHosts map[string]string
+	// select the namespace of requests by their "namespace" query parameter
+	NamespaceQuery bool
 }
 
 // Server serves boot and provisioning configs to machines via HTTP.
 type Server struct {
-	core          server.Server
-	logger        *logrus.Logger
-	assetsPath    string
-	signer        sign.Signer
-	armoredSigner sign.Signer
+	core           server.Server
+	logger         *logrus.Logger
+	assetsPath     string
+	signer         sign.Signer
+	armoredSigner  sign.Signer
+	secretKey      *secret.Key
+	namespace      string
+	namespaceHosts map[string]string
+	namespaceQuery bool
 }
 
 // NewServer returns a new Server.
 func NewServer(config *Config) *Server {
 	return &Server{
-		core:          config.Core,
-		logger:        config.Logger,
-		assetsPath:    config.AssetsPath,
-		signer:        config.Signer,
-		armoredSigner: config.ArmoredSigner,
+		core:           config.Core,
+		logger:         config.Logger,
+		assetsPath:     config.AssetsPath,
+		signer:         config.Signer,
+		armoredSigner:  config.ArmoredSigner,
+		secretKey:      config.SecretKey,
+		namespace:      config.Namespace,
+		namespaceHosts: config.NamespaceHosts,
+		namespaceQuery: config.NamespaceQuery,
 	}
 }
 
@@ -45,7 +62,7 @@ func (s *Server) HTTPHandler() http.Handler {
 	mux := http.NewServeMux()
 
 	chain := func(next http.Handler) http.Handler {
-		return s.logRequest(next)
+		return s.logRequest(s.selectNamespace(next))
 	}
 	// matchbox version
 	mux.Handle("/", s.logRequest(homeHandler()))
@@ -62,12 +79,12 @@ func (s *Server) HTTPHandler() http.Handler {
 	// Generic template
 	mux.Handle("/generic", chain(s.selectGroup(s.core, s.genericHandler(s.core))))
 	// Metadata
-	mux.Handle("/metadata", chain(s.selectGroup(s.core, s.metadataHandler())))
+	mux.Handle("/metadata", chain(s.selectGroup(s.core, s.metadataHandler(s.core))))
 
 	// Signatures
 	if s.signer != nil {
 		signerChain := func(next http.Handler) http.Handler {
-			return s.logRequest(sign.SignatureHandler(s.signer, next))
+			return s.logRequest(s.selectNamespace(sign.SignatureHandler(s.signer, next)))
 		}
 		mux.Handle("/grub.sig", signerChain(s.selectProfile(s.core, s.grubHandler())))
 		mux.Handle("/boot.ipxe.sig", signerChain(ipxeInspect()))
@@ -76,11 +93,11 @@ func (s *Server) HTTPHandler() http.Handler {
 		mux.Handle("/ignition.sig", signerChain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
 		mux.Handle("/cloud.sig", signerChain(s.selectGroup(s.core, s.cloudHandler(s.core))))
 		mux.Handle("/generic.sig", signerChain(s.selectGroup(s.core, s.genericHandler(s.core))))
-		mux.Handle("/metadata.sig", signerChain(s.selectGroup(s.core, s.metadataHandler())))
+		mux.Handle("/metadata.sig", signerChain(s.selectGroup(s.core, s.metadataHandler(s.core))))
 	}
 	if s.armoredSigner != nil {
 		signerChain := func(next http.Handler) http.Handler {
-			return s.logRequest(sign.SignatureHandler(s.armoredSigner, next))
+			return s.logRequest(s.selectNamespace(sign.SignatureHandler(s.armoredSigner, next)))
 		}
 		mux.Handle("/grub.asc", signerChain(s.selectProfile(s.core, s.grubHandler())))
 		mux.Handle("/boot.ipxe.asc", signerChain(ipxeInspect()))
@@ -89,7 +106,7 @@ func (s *Server) HTTPHandler() http.Handler {
 		mux.Handle("/ignition.asc", signerChain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
 		mux.Handle("/cloud.asc", signerChain(s.selectGroup(s.core, s.cloudHandler(s.core))))
 		mux.Handle("/generic.asc", signerChain(s.selectGroup(s.core, s.genericHandler(s.core))))
-		mux.Handle("/metadata.asc", signerChain(s.selectGroup(s.core, s.metadataHandler())))
+		mux.Handle("/metadata.asc", signerChain(s.selectGroup(s.core, s.metadataHandler(s.core))))
 	}
 
 	// kernel, initrd, and TLS assets
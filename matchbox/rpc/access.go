@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/coreos/matchbox/matchbox/server"
+)
+
+// allNamespaces grants access to every namespace.
+const allNamespaces = "*"
+
+// NamespaceAccess maps the common names of client certificate subjects to
+// the namespaces their requests may access. The namespace "*" grants access
+// to all namespaces and "default" to the default namespace. A nil
+// NamespaceAccess grants every client access to all namespaces.
+type NamespaceAccess map[string][]string
+
+// ParseNamespaceAccess parses a comma separated list of name=namespace
+// grants, such as "team-a-admin=team-a,ops=*", into a NamespaceAccess.
+func ParseNamespaceAccess(grants string) (NamespaceAccess, error) {
+	access := make(NamespaceAccess)
+	for _, grant := range strings.Split(grants, ",") {
+		grant = strings.TrimSpace(grant)
+		if grant == "" {
+			continue
+		}
+		parts := strings.SplitN(grant, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("rpc: invalid namespace grant %q, expected name=namespace", grant)
+		}
+		access[parts[0]] = append(access[parts[0]], parts[1])
+	}
+	return access, nil
+}
+
+// Allowed returns true if the client with the given common name may access
+// the named namespace.
+func (a NamespaceAccess) Allowed(name, namespace string) bool {
+	if a == nil {
+		return true
+	}
+	if namespace == "" {
+		namespace = server.DefaultNamespace
+	}
+	for _, granted := range a[name] {
+		if granted == allNamespaces || granted == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaced is a request scoped to a namespace.
+type namespaced interface {
+	GetNamespace() string
+}
+
+// check returns errNamespaceDenied if the client of a request may not access
+// the request's namespace.
+func (a NamespaceAccess) check(ctx context.Context, req interface{}) error {
+	var namespace string
+	if req, ok := req.(namespaced); ok {
+		namespace = req.GetNamespace()
+	}
+	if !a.Allowed(peerCommonName(ctx), namespace) {
+		return errNamespaceDenied
+	}
+	return nil
+}
+
+// unaryInterceptor denies requests for namespaces the client may not access
+// and attributes the writes of other requests to the client.
+func (a NamespaceAccess) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.check(ctx, req); err != nil {
+		return nil, err
+	}
+	return authorInterceptor(ctx, req, info, handler)
+}
+
+// streamInterceptor denies streams whose requests are for namespaces the
+// client may not access.
+func (a NamespaceAccess) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if a == nil {
+		return handler(srv, ss)
+	}
+	return handler(srv, &accessStream{ServerStream: ss, access: a})
+}
+
+// accessStream checks the namespace access of each request received on a
+// stream.
+type accessStream struct {
+	grpc.ServerStream
+	access NamespaceAccess
+}
+
+func (s *accessStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.access.check(s.Context(), m)
+}
@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+func TestParseNamespaceAccess(t *testing.T) {
+	access, err := ParseNamespaceAccess("team-a-admin=team-a, ops=*,ops=default")
+	assert.Nil(t, err)
+	expected := NamespaceAccess{
+		"team-a-admin": {"team-a"},
+		"ops":          {"*", "default"},
+	}
+	assert.Equal(t, expected, access)
+
+	for _, grants := range []string{"team-a", "=team-a", "ops="} {
+		_, err := ParseNamespaceAccess(grants)
+		assert.Error(t, err, grants)
+	}
+}
+
+func TestNamespaceAccess(t *testing.T) {
+	access := NamespaceAccess{
+		"team-a-admin": {"team-a", "default"},
+		"ops":          {"*"},
+	}
+	cases := []struct {
+		name      string
+		namespace string
+		allowed   bool
+	}{
+		{"team-a-admin", "team-a", true},
+		{"team-a-admin", "", true},
+		{"team-a-admin", "default", true},
+		{"team-a-admin", "team-b", false},
+		{"ops", "team-b", true},
+		{"", "team-a", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.allowed, access.Allowed(c.name, c.namespace), "%s %s", c.name, c.namespace)
+	}
+	// assert that a nil NamespaceAccess allows all namespaces
+	assert.True(t, NamespaceAccess(nil).Allowed("", "team-b"))
+}
+
+func TestNamespaceAccess_Check(t *testing.T) {
+	access := NamespaceAccess{"team-a-admin": {"team-a"}}
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "team-a-admin"}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+	// assert that requests are checked by the common name of the client
+	assert.Nil(t, access.check(ctx, &pb.GroupListRequest{Namespace: "team-a"}))
+	assert.Equal(t, errNamespaceDenied, access.check(ctx, &pb.GroupListRequest{Namespace: "team-b"}))
+	assert.Equal(t, errNamespaceDenied, access.check(ctx, &pb.GroupListRequest{}))
+	assert.Equal(t, errNamespaceDenied, access.check(context.Background(), &pb.GroupListRequest{Namespace: "team-a"}))
+}
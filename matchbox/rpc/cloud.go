@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/coreos/matchbox/matchbox/rpc/rpcpb"
+	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// cloudServer takes a matchbox Server and implements a gRPC CloudServer.
+type cloudServer struct {
+	srv server.Server
+}
+
+func newCloudServer(s server.Server) rpcpb.CloudServer {
+	return &cloudServer{
+		srv: s,
+	}
+}
+
+func (s *cloudServer) CloudPut(ctx context.Context, req *pb.CloudPutRequest) (*pb.CloudPutResponse, error) {
+	version, err := s.srv.CloudPut(ctx, req)
+	return &pb.CloudPutResponse{ResourceVersion: version}, grpcError(err)
+}
+
+func (s *cloudServer) CloudGet(ctx context.Context, req *pb.CloudGetRequest) (*pb.CloudGetResponse, error) {
+	// read the version first, so a concurrent write makes a conditional put
+	// based on this response fail rather than overwrite the newer template
+	version, err := s.srv.CloudVersion(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	template, err := s.srv.CloudGet(ctx, req)
+	return &pb.CloudGetResponse{Config: []byte(template), ResourceVersion: version}, grpcError(err)
+}
+
+func (s *cloudServer) CloudDelete(ctx context.Context, req *pb.CloudDeleteRequest) (*pb.CloudDeleteResponse, error) {
+	err := s.srv.CloudDelete(ctx, req)
+	return &pb.CloudDeleteResponse{}, grpcError(err)
+}
+
+func (s *cloudServer) CloudList(ctx context.Context, req *pb.CloudListRequest) (*pb.CloudListResponse, error) {
+	names, err := s.srv.CloudList(ctx, req)
+	return &pb.CloudListResponse{Names: names}, grpcError(err)
+}
+
+func (s *cloudServer) CloudWatch(req *pb.CloudWatchRequest, stream rpcpb.Cloud_CloudWatchServer) error {
+	events, err := s.srv.CloudWatch(stream.Context(), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	for event := range events {
+		if err := stream.Send(&pb.CloudWatchResponse{Event: event}); err != nil {
+			return err
+		}
+	}
+	if stream.Context().Err() == nil {
+		return errWatchClosed
+	}
+	return nil
+}
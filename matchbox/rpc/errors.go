@@ -5,6 +5,7 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/coreos/matchbox/matchbox/server"
+	"github.com/coreos/matchbox/matchbox/storage"
 )
 
 var (
@@ -12,6 +13,16 @@ var (
 	grpcErrorf           = grpc.Errorf
 	errNoMatchingGroup   = grpcErrorf(codes.NotFound, "matchbox: No matching Group")
 	errNoMatchingProfile = grpcErrorf(codes.NotFound, "matchbox: No matching Profile")
+	errWatchClosed       = grpcErrorf(codes.Unavailable, "matchbox: Watch closed, list and watch again")
+	errVersionConflict   = grpcErrorf(codes.Aborted, "matchbox: Resource version conflict, get and retry")
+	errNoHistory         = grpcErrorf(codes.Unimplemented, "matchbox: Store does not keep history")
+	errInvalidKind       = grpcErrorf(codes.InvalidArgument, "matchbox: Invalid resource kind")
+	errNoRevision        = grpcErrorf(codes.NotFound, "matchbox: No matching Revision")
+	errNoNamespaces      = grpcErrorf(codes.Unimplemented, "matchbox: Store does not support namespaces")
+	errInvalidNamespace  = grpcErrorf(codes.InvalidArgument, "matchbox: Invalid namespace name")
+	errNamespaceDenied   = grpcErrorf(codes.PermissionDenied, "matchbox: Namespace access denied")
+	errNoNamespace       = grpcErrorf(codes.NotFound, "matchbox: Namespace not found")
+	errReadOnly          = grpcErrorf(codes.FailedPrecondition, "matchbox: Store is read-only")
 )
 
 // grpcError transforms an error into a gRPC errors with canonical error codes.
@@ -24,6 +35,26 @@ func grpcError(err error) error {
 		return errNoMatchingGroup
 	case server.ErrNoMatchingProfile:
 		return errNoMatchingProfile
+	case server.ErrHistoryUnsupported:
+		return errNoHistory
+	case server.ErrInvalidKind:
+		return errInvalidKind
+	case server.ErrNoRevision:
+		return errNoRevision
+	case server.ErrNamespacesUnsupported:
+		return errNoNamespaces
+	case server.ErrInvalidNamespace:
+		return errInvalidNamespace
+	case server.ErrNamespaceNotFound:
+		return errNoNamespace
+	case storage.ErrVersionConflict:
+		return errVersionConflict
+	case storage.ErrReadOnly:
+		return errReadOnly
+	}
+	switch err.(type) {
+	case *server.MissingReferenceError, *server.DependentsError, *server.AmbiguousGroupError, *server.ProfileCycleError:
+		return grpcErrorf(codes.FailedPrecondition, err.Error())
 	default:
 		return grpcErrorf(codes.Unknown, err.Error())
 	}
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/coreos/matchbox/matchbox/server"
+	"github.com/coreos/matchbox/matchbox/storage"
 )
 
 func TestGRPCError(t *testing.T) {
@@ -18,6 +19,18 @@ func TestGRPCError(t *testing.T) {
 		{nil, nil},
 		{server.ErrNoMatchingGroup, errNoMatchingGroup},
 		{server.ErrNoMatchingProfile, errNoMatchingProfile},
+		{storage.ErrVersionConflict, errVersionConflict},
+		{storage.ErrReadOnly, errReadOnly},
+		{server.ErrHistoryUnsupported, errNoHistory},
+		{server.ErrInvalidKind, errInvalidKind},
+		{server.ErrNoRevision, errNoRevision},
+		{server.ErrNamespacesUnsupported, errNoNamespaces},
+		{server.ErrInvalidNamespace, errInvalidNamespace},
+		{server.ErrNamespaceNotFound, errNoNamespace},
+		{&server.MissingReferenceError{Kind: "profiles", Name: "etcd"}, grpcErrorf(codes.FailedPrecondition, `matchbox: Profile "etcd" does not exist`)},
+		{&server.DependentsError{Kind: "ignition", Name: "etcd.yaml", Dependents: []string{"profiles/etcd", "profiles/etcd-proxy"}}, grpcErrorf(codes.FailedPrecondition, `matchbox: Ignition template "etcd.yaml" is referenced by profiles/etcd, profiles/etcd-proxy, delete them first or force the delete`)},
+		{&server.AmbiguousGroupError{Id: "os", Groups: []string{"default"}}, grpcErrorf(codes.FailedPrecondition, `matchbox: Group "os" may match the same machines as Groups default with the same rank, set a priority to order them`)},
+		{&server.ProfileCycleError{Profiles: []string{"a", "b", "a"}}, grpcErrorf(codes.FailedPrecondition, "matchbox: Profiles inherit from each other in a cycle a -> b -> a")},
 		{errors.New("other error"), grpcErrorf(codes.Unknown, "other error")},
 	}
 	for _, c := range cases {
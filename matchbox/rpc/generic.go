@@ -20,16 +20,43 @@ func newGenericServer(s server.Server) rpcpb.GenericServer {
 }
 
 func (s *genericServer) GenericPut(ctx context.Context, req *pb.GenericPutRequest) (*pb.GenericPutResponse, error) {
-	_, err := s.srv.GenericPut(ctx, req)
-	return &pb.GenericPutResponse{}, grpcError(err)
+	version, err := s.srv.GenericPut(ctx, req)
+	return &pb.GenericPutResponse{ResourceVersion: version}, grpcError(err)
 }
 
 func (s *genericServer) GenericGet(ctx context.Context, req *pb.GenericGetRequest) (*pb.GenericGetResponse, error) {
+	// read the version first, so a concurrent write makes a conditional put
+	// based on this response fail rather than overwrite the newer template
+	version, err := s.srv.GenericVersion(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
 	template, err := s.srv.GenericGet(ctx, req)
-	return &pb.GenericGetResponse{Config: []byte(template)}, grpcError(err)
+	return &pb.GenericGetResponse{Config: []byte(template), ResourceVersion: version}, grpcError(err)
 }
 
 func (s *genericServer) GenericDelete(ctx context.Context, req *pb.GenericDeleteRequest) (*pb.GenericDeleteResponse, error) {
 	err := s.srv.GenericDelete(ctx, req)
 	return &pb.GenericDeleteResponse{}, grpcError(err)
 }
+
+func (s *genericServer) GenericList(ctx context.Context, req *pb.GenericListRequest) (*pb.GenericListResponse, error) {
+	names, err := s.srv.GenericList(ctx, req)
+	return &pb.GenericListResponse{Names: names}, grpcError(err)
+}
+
+func (s *genericServer) GenericWatch(req *pb.GenericWatchRequest, stream rpcpb.Generic_GenericWatchServer) error {
+	events, err := s.srv.GenericWatch(stream.Context(), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	for event := range events {
+		if err := stream.Send(&pb.GenericWatchResponse{Event: event}); err != nil {
+			return err
+		}
+	}
+	if stream.Context().Err() == nil {
+		return errWatchClosed
+	}
+	return nil
+}
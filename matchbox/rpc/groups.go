@@ -20,12 +20,18 @@ func newGroupServer(s server.Server) rpcpb.GroupsServer {
 }
 
 func (s *groupServer) GroupPut(ctx context.Context, req *pb.GroupPutRequest) (*pb.GroupPutResponse, error) {
-	_, err := s.srv.GroupPut(ctx, req)
-	return &pb.GroupPutResponse{}, grpcError(err)
+	group, err := s.srv.GroupPut(ctx, req)
+	if err == nil {
+		group, err = redactGroup(group)
+	}
+	return &pb.GroupPutResponse{Group: group}, grpcError(err)
 }
 
 func (s *groupServer) GroupGet(ctx context.Context, req *pb.GroupGetRequest) (*pb.GroupGetResponse, error) {
 	group, err := s.srv.GroupGet(ctx, req)
+	if err == nil {
+		group, err = redactGroup(group)
+	}
 	return &pb.GroupGetResponse{Group: group}, grpcError(err)
 }
 
@@ -36,5 +42,28 @@ func (s *groupServer) GroupDelete(ctx context.Context, req *pb.GroupDeleteReques
 
 func (s *groupServer) GroupList(ctx context.Context, req *pb.GroupListRequest) (*pb.GroupListResponse, error) {
 	groups, err := s.srv.GroupList(ctx, req)
+	if err == nil {
+		groups, err = redactGroups(groups)
+	}
 	return &pb.GroupListResponse{Groups: groups}, grpcError(err)
 }
+
+func (s *groupServer) GroupWatch(req *pb.GroupWatchRequest, stream rpcpb.Groups_GroupWatchServer) error {
+	events, err := s.srv.GroupWatch(stream.Context(), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	for event := range events {
+		event, err := redactEvent(event)
+		if err != nil {
+			return grpcError(err)
+		}
+		if err := stream.Send(&pb.GroupWatchResponse{Event: event}); err != nil {
+			return err
+		}
+	}
+	if stream.Context().Err() == nil {
+		return errWatchClosed
+	}
+	return nil
+}
@@ -3,26 +3,72 @@ package rpc
 import (
 	"crypto/tls"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 
 	"github.com/coreos/matchbox/matchbox/rpc/rpcpb"
 	"github.com/coreos/matchbox/matchbox/server"
 )
 
-// NewServer wraps the matchbox Server to return a new gRPC Server.
-func NewServer(s server.Server, tls *tls.Config) *grpc.Server {
+// NewServer wraps the matchbox Server to return a new gRPC Server. Clients
+// may only access the namespaces granted to them by the NamespaceAccess,
+// unless it is nil.
+func NewServer(s server.Server, tls *tls.Config, access NamespaceAccess) *grpc.Server {
 	var opts []grpc.ServerOption
 	if tls != nil {
 		// Add TLS Credentials as a ServerOption for server connections.
 		opts = append(opts, grpc.Creds(credentials.NewTLS(tls)))
 	}
 
+	opts = append(opts,
+		grpc.UnaryInterceptor(access.unaryInterceptor),
+		grpc.StreamInterceptor(access.streamInterceptor),
+	)
+
 	grpcServer := grpc.NewServer(opts...)
 	rpcpb.RegisterGroupsServer(grpcServer, newGroupServer(s))
 	rpcpb.RegisterProfilesServer(grpcServer, newProfileServer(s))
 	rpcpb.RegisterSelectServer(grpcServer, newSelectServer(s))
 	rpcpb.RegisterIgnitionServer(grpcServer, newIgnitionServer(s))
 	rpcpb.RegisterGenericServer(grpcServer, newGenericServer(s))
+	rpcpb.RegisterCloudServer(grpcServer, newCloudServer(s))
+	rpcpb.RegisterPartialsServer(grpcServer, newPartialsServer(s))
+	rpcpb.RegisterHistoryServer(grpcServer, newHistoryServer(s))
+	rpcpb.RegisterValidateServer(grpcServer, newValidateServer(s))
 	return grpcServer
 }
+
+// authorInterceptor attributes the writes of a request to the client, by the
+// common name of its TLS certificate or else its address.
+func authorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if author := peerAuthor(ctx); author != "" {
+		ctx = server.WithAuthor(ctx, author)
+	}
+	return handler(ctx, req)
+}
+
+// peerAuthor returns the author name of the client of a request.
+func peerAuthor(ctx context.Context) string {
+	if name := peerCommonName(ctx); name != "" {
+		return name
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// peerCommonName returns the common name of the TLS certificate subject of
+// the client of a request, if any.
+func peerCommonName(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.PeerCertificates) > 0 {
+		return info.State.PeerCertificates[0].Subject.CommonName
+	}
+	return ""
+}
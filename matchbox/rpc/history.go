@@ -0,0 +1,33 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/coreos/matchbox/matchbox/rpc/rpcpb"
+	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// historyServer takes a matchbox Server and implements a gRPC HistoryServer.
+type historyServer struct {
+	srv server.Server
+}
+
+func newHistoryServer(s server.Server) rpcpb.HistoryServer {
+	return &historyServer{
+		srv: s,
+	}
+}
+
+func (s *historyServer) History(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	revisions, err := s.srv.History(ctx, req)
+	if err == nil {
+		revisions, err = redactRevisions(revisions)
+	}
+	return &pb.HistoryResponse{Revisions: revisions}, grpcError(err)
+}
+
+func (s *historyServer) Rollback(ctx context.Context, req *pb.RollbackRequest) (*pb.RollbackResponse, error) {
+	version, err := s.srv.Rollback(ctx, req)
+	return &pb.RollbackResponse{ResourceVersion: version}, grpcError(err)
+}
@@ -20,16 +20,43 @@ func newIgnitionServer(s server.Server) rpcpb.IgnitionServer {
 }
 
 func (s *ignitionServer) IgnitionPut(ctx context.Context, req *pb.IgnitionPutRequest) (*pb.IgnitionPutResponse, error) {
-	_, err := s.srv.IgnitionPut(ctx, req)
-	return &pb.IgnitionPutResponse{}, grpcError(err)
+	version, err := s.srv.IgnitionPut(ctx, req)
+	return &pb.IgnitionPutResponse{ResourceVersion: version}, grpcError(err)
 }
 
 func (s *ignitionServer) IgnitionGet(ctx context.Context, req *pb.IgnitionGetRequest) (*pb.IgnitionGetResponse, error) {
+	// read the version first, so a concurrent write makes a conditional put
+	// based on this response fail rather than overwrite the newer template
+	version, err := s.srv.IgnitionVersion(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
 	template, err := s.srv.IgnitionGet(ctx, req)
-	return &pb.IgnitionGetResponse{Config: []byte(template)}, grpcError(err)
+	return &pb.IgnitionGetResponse{Config: []byte(template), ResourceVersion: version}, grpcError(err)
 }
 
 func (s *ignitionServer) IgnitionDelete(ctx context.Context, req *pb.IgnitionDeleteRequest) (*pb.IgnitionDeleteResponse, error) {
 	err := s.srv.IgnitionDelete(ctx, req)
 	return &pb.IgnitionDeleteResponse{}, grpcError(err)
 }
+
+func (s *ignitionServer) IgnitionList(ctx context.Context, req *pb.IgnitionListRequest) (*pb.IgnitionListResponse, error) {
+	names, err := s.srv.IgnitionList(ctx, req)
+	return &pb.IgnitionListResponse{Names: names}, grpcError(err)
+}
+
+func (s *ignitionServer) IgnitionWatch(req *pb.IgnitionWatchRequest, stream rpcpb.Ignition_IgnitionWatchServer) error {
+	events, err := s.srv.IgnitionWatch(stream.Context(), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	for event := range events {
+		if err := stream.Send(&pb.IgnitionWatchResponse{Event: event}); err != nil {
+			return err
+		}
+	}
+	if stream.Context().Err() == nil {
+		return errWatchClosed
+	}
+	return nil
+}
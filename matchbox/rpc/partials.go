@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/coreos/matchbox/matchbox/rpc/rpcpb"
+	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// partialsServer takes a matchbox Server and implements a gRPC PartialsServer.
+type partialsServer struct {
+	srv server.Server
+}
+
+func newPartialsServer(s server.Server) rpcpb.PartialsServer {
+	return &partialsServer{
+		srv: s,
+	}
+}
+
+func (s *partialsServer) PartialPut(ctx context.Context, req *pb.PartialPutRequest) (*pb.PartialPutResponse, error) {
+	version, err := s.srv.PartialPut(ctx, req)
+	return &pb.PartialPutResponse{ResourceVersion: version}, grpcError(err)
+}
+
+func (s *partialsServer) PartialGet(ctx context.Context, req *pb.PartialGetRequest) (*pb.PartialGetResponse, error) {
+	// read the version first, so a concurrent write makes a conditional put
+	// based on this response fail rather than overwrite the newer template
+	version, err := s.srv.PartialVersion(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	template, err := s.srv.PartialGet(ctx, req)
+	return &pb.PartialGetResponse{Config: []byte(template), ResourceVersion: version}, grpcError(err)
+}
+
+func (s *partialsServer) PartialDelete(ctx context.Context, req *pb.PartialDeleteRequest) (*pb.PartialDeleteResponse, error) {
+	err := s.srv.PartialDelete(ctx, req)
+	return &pb.PartialDeleteResponse{}, grpcError(err)
+}
+
+func (s *partialsServer) PartialList(ctx context.Context, req *pb.PartialListRequest) (*pb.PartialListResponse, error) {
+	names, err := s.srv.PartialList(ctx, req)
+	return &pb.PartialListResponse{Names: names}, grpcError(err)
+}
@@ -20,13 +20,18 @@ func newProfileServer(s server.Server) rpcpb.ProfilesServer {
 }
 
 func (s *profileServer) ProfilePut(ctx context.Context, req *pb.ProfilePutRequest) (*pb.ProfilePutResponse, error) {
-	_, err := s.srv.ProfilePut(ctx, req)
-	// TODO(dghubble): Decide on create/put and response(s).
-	return &pb.ProfilePutResponse{}, grpcError(err)
+	profile, err := s.srv.ProfilePut(ctx, req)
+	if err == nil {
+		profile, err = redactProfile(profile)
+	}
+	return &pb.ProfilePutResponse{Profile: profile}, grpcError(err)
 }
 
 func (s *profileServer) ProfileGet(ctx context.Context, req *pb.ProfileGetRequest) (*pb.ProfileGetResponse, error) {
 	profile, err := s.srv.ProfileGet(ctx, req)
+	if err == nil {
+		profile, err = redactProfile(profile)
+	}
 	return &pb.ProfileGetResponse{Profile: profile}, grpcError(err)
 }
 
@@ -37,5 +42,28 @@ func (s *profileServer) ProfileDelete(ctx context.Context, req *pb.ProfileDelete
 
 func (s *profileServer) ProfileList(ctx context.Context, req *pb.ProfileListRequest) (*pb.ProfileListResponse, error) {
 	profiles, err := s.srv.ProfileList(ctx, req)
+	if err == nil {
+		profiles, err = redactProfiles(profiles)
+	}
 	return &pb.ProfileListResponse{Profiles: profiles}, grpcError(err)
 }
+
+func (s *profileServer) ProfileWatch(req *pb.ProfileWatchRequest, stream rpcpb.Profiles_ProfileWatchServer) error {
+	events, err := s.srv.ProfileWatch(stream.Context(), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	for event := range events {
+		event, err := redactEvent(event)
+		if err != nil {
+			return grpcError(err)
+		}
+		if err := stream.Send(&pb.ProfileWatchResponse{Event: event}); err != nil {
+			return err
+		}
+	}
+	if stream.Context().Err() == nil {
+		return errWatchClosed
+	}
+	return nil
+}
@@ -52,6 +52,8 @@ type GroupsClient interface {
 	GroupDelete(ctx context.Context, in *serverpb.GroupDeleteRequest, opts ...grpc.CallOption) (*serverpb.GroupDeleteResponse, error)
 	// List all machine Groups.
 	GroupList(ctx context.Context, in *serverpb.GroupListRequest, opts ...grpc.CallOption) (*serverpb.GroupListResponse, error)
+	// Watch machine Groups for changes.
+	GroupWatch(ctx context.Context, in *serverpb.GroupWatchRequest, opts ...grpc.CallOption) (Groups_GroupWatchClient, error)
 }
 
 type groupsClient struct {
@@ -98,6 +100,38 @@ func (c *groupsClient) GroupList(ctx context.Context, in *serverpb.GroupListRequ
 	return out, nil
 }
 
+func (c *groupsClient) GroupWatch(ctx context.Context, in *serverpb.GroupWatchRequest, opts ...grpc.CallOption) (Groups_GroupWatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Groups_serviceDesc.Streams[0], c.cc, "/rpcpb.Groups/GroupWatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &groupsGroupWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Groups_GroupWatchClient interface {
+	Recv() (*serverpb.GroupWatchResponse, error)
+	grpc.ClientStream
+}
+
+type groupsGroupWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *groupsGroupWatchClient) Recv() (*serverpb.GroupWatchResponse, error) {
+	m := new(serverpb.GroupWatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Groups service
 
 type GroupsServer interface {
@@ -109,6 +143,8 @@ type GroupsServer interface {
 	GroupDelete(context.Context, *serverpb.GroupDeleteRequest) (*serverpb.GroupDeleteResponse, error)
 	// List all machine Groups.
 	GroupList(context.Context, *serverpb.GroupListRequest) (*serverpb.GroupListResponse, error)
+	// Watch machine Groups for changes.
+	GroupWatch(*serverpb.GroupWatchRequest, Groups_GroupWatchServer) error
 }
 
 func RegisterGroupsServer(s *grpc.Server, srv GroupsServer) {
@@ -187,6 +223,27 @@ func _Groups_GroupList_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Groups_GroupWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(serverpb.GroupWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GroupsServer).GroupWatch(m, &groupsGroupWatchServer{stream})
+}
+
+type Groups_GroupWatchServer interface {
+	Send(*serverpb.GroupWatchResponse) error
+	grpc.ServerStream
+}
+
+type groupsGroupWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *groupsGroupWatchServer) Send(m *serverpb.GroupWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Groups_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.Groups",
 	HandlerType: (*GroupsServer)(nil),
@@ -208,7 +265,13 @@ var _Groups_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Groups_GroupList_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GroupWatch",
+			Handler:       _Groups_GroupWatch_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "rpc.proto",
 }
 
@@ -223,6 +286,8 @@ type ProfilesClient interface {
 	ProfileDelete(ctx context.Context, in *serverpb.ProfileDeleteRequest, opts ...grpc.CallOption) (*serverpb.ProfileDeleteResponse, error)
 	// List all Profiles.
 	ProfileList(ctx context.Context, in *serverpb.ProfileListRequest, opts ...grpc.CallOption) (*serverpb.ProfileListResponse, error)
+	// Watch Profiles for changes.
+	ProfileWatch(ctx context.Context, in *serverpb.ProfileWatchRequest, opts ...grpc.CallOption) (Profiles_ProfileWatchClient, error)
 }
 
 type profilesClient struct {
@@ -269,6 +334,38 @@ func (c *profilesClient) ProfileList(ctx context.Context, in *serverpb.ProfileLi
 	return out, nil
 }
 
+func (c *profilesClient) ProfileWatch(ctx context.Context, in *serverpb.ProfileWatchRequest, opts ...grpc.CallOption) (Profiles_ProfileWatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Profiles_serviceDesc.Streams[0], c.cc, "/rpcpb.Profiles/ProfileWatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &profilesProfileWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Profiles_ProfileWatchClient interface {
+	Recv() (*serverpb.ProfileWatchResponse, error)
+	grpc.ClientStream
+}
+
+type profilesProfileWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *profilesProfileWatchClient) Recv() (*serverpb.ProfileWatchResponse, error) {
+	m := new(serverpb.ProfileWatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Profiles service
 
 type ProfilesServer interface {
@@ -280,6 +377,8 @@ type ProfilesServer interface {
 	ProfileDelete(context.Context, *serverpb.ProfileDeleteRequest) (*serverpb.ProfileDeleteResponse, error)
 	// List all Profiles.
 	ProfileList(context.Context, *serverpb.ProfileListRequest) (*serverpb.ProfileListResponse, error)
+	// Watch Profiles for changes.
+	ProfileWatch(*serverpb.ProfileWatchRequest, Profiles_ProfileWatchServer) error
 }
 
 func RegisterProfilesServer(s *grpc.Server, srv ProfilesServer) {
@@ -358,6 +457,27 @@ func _Profiles_ProfileList_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Profiles_ProfileWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(serverpb.ProfileWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfilesServer).ProfileWatch(m, &profilesProfileWatchServer{stream})
+}
+
+type Profiles_ProfileWatchServer interface {
+	Send(*serverpb.ProfileWatchResponse) error
+	grpc.ServerStream
+}
+
+type profilesProfileWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *profilesProfileWatchServer) Send(m *serverpb.ProfileWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Profiles_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.Profiles",
 	HandlerType: (*ProfilesServer)(nil),
@@ -379,7 +499,13 @@ var _Profiles_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Profiles_ProfileList_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProfileWatch",
+			Handler:       _Profiles_ProfileWatch_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "rpc.proto",
 }
 
@@ -392,6 +518,10 @@ type IgnitionClient interface {
 	IgnitionGet(ctx context.Context, in *serverpb.IgnitionGetRequest, opts ...grpc.CallOption) (*serverpb.IgnitionGetResponse, error)
 	// Delete a Container Linux Config template by name.
 	IgnitionDelete(ctx context.Context, in *serverpb.IgnitionDeleteRequest, opts ...grpc.CallOption) (*serverpb.IgnitionDeleteResponse, error)
+	// List the names of all Container Linux Config templates.
+	IgnitionList(ctx context.Context, in *serverpb.IgnitionListRequest, opts ...grpc.CallOption) (*serverpb.IgnitionListResponse, error)
+	// Watch Container Linux Config templates for changes.
+	IgnitionWatch(ctx context.Context, in *serverpb.IgnitionWatchRequest, opts ...grpc.CallOption) (Ignition_IgnitionWatchClient, error)
 }
 
 type ignitionClient struct {
@@ -429,6 +559,47 @@ func (c *ignitionClient) IgnitionDelete(ctx context.Context, in *serverpb.Igniti
 	return out, nil
 }
 
+func (c *ignitionClient) IgnitionList(ctx context.Context, in *serverpb.IgnitionListRequest, opts ...grpc.CallOption) (*serverpb.IgnitionListResponse, error) {
+	out := new(serverpb.IgnitionListResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Ignition/IgnitionList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ignitionClient) IgnitionWatch(ctx context.Context, in *serverpb.IgnitionWatchRequest, opts ...grpc.CallOption) (Ignition_IgnitionWatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Ignition_serviceDesc.Streams[0], c.cc, "/rpcpb.Ignition/IgnitionWatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ignitionIgnitionWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Ignition_IgnitionWatchClient interface {
+	Recv() (*serverpb.IgnitionWatchResponse, error)
+	grpc.ClientStream
+}
+
+type ignitionIgnitionWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *ignitionIgnitionWatchClient) Recv() (*serverpb.IgnitionWatchResponse, error) {
+	m := new(serverpb.IgnitionWatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Ignition service
 
 type IgnitionServer interface {
@@ -438,6 +609,10 @@ type IgnitionServer interface {
 	IgnitionGet(context.Context, *serverpb.IgnitionGetRequest) (*serverpb.IgnitionGetResponse, error)
 	// Delete a Container Linux Config template by name.
 	IgnitionDelete(context.Context, *serverpb.IgnitionDeleteRequest) (*serverpb.IgnitionDeleteResponse, error)
+	// List the names of all Container Linux Config templates.
+	IgnitionList(context.Context, *serverpb.IgnitionListRequest) (*serverpb.IgnitionListResponse, error)
+	// Watch Container Linux Config templates for changes.
+	IgnitionWatch(*serverpb.IgnitionWatchRequest, Ignition_IgnitionWatchServer) error
 }
 
 func RegisterIgnitionServer(s *grpc.Server, srv IgnitionServer) {
@@ -498,6 +673,45 @@ func _Ignition_IgnitionDelete_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ignition_IgnitionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.IgnitionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IgnitionServer).IgnitionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Ignition/IgnitionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IgnitionServer).IgnitionList(ctx, req.(*serverpb.IgnitionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ignition_IgnitionWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(serverpb.IgnitionWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IgnitionServer).IgnitionWatch(m, &ignitionIgnitionWatchServer{stream})
+}
+
+type Ignition_IgnitionWatchServer interface {
+	Send(*serverpb.IgnitionWatchResponse) error
+	grpc.ServerStream
+}
+
+type ignitionIgnitionWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *ignitionIgnitionWatchServer) Send(m *serverpb.IgnitionWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Ignition_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.Ignition",
 	HandlerType: (*IgnitionServer)(nil),
@@ -514,8 +728,18 @@ var _Ignition_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IgnitionDelete",
 			Handler:    _Ignition_IgnitionDelete_Handler,
 		},
+		{
+			MethodName: "IgnitionList",
+			Handler:    _Ignition_IgnitionList_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IgnitionWatch",
+			Handler:       _Ignition_IgnitionWatch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
 
@@ -528,6 +752,10 @@ type GenericClient interface {
 	GenericGet(ctx context.Context, in *serverpb.GenericGetRequest, opts ...grpc.CallOption) (*serverpb.GenericGetResponse, error)
 	// Delete a Generic template by name.
 	GenericDelete(ctx context.Context, in *serverpb.GenericDeleteRequest, opts ...grpc.CallOption) (*serverpb.GenericDeleteResponse, error)
+	// List the names of all Generic templates.
+	GenericList(ctx context.Context, in *serverpb.GenericListRequest, opts ...grpc.CallOption) (*serverpb.GenericListResponse, error)
+	// Watch Generic templates for changes.
+	GenericWatch(ctx context.Context, in *serverpb.GenericWatchRequest, opts ...grpc.CallOption) (Generic_GenericWatchClient, error)
 }
 
 type genericClient struct {
@@ -565,6 +793,47 @@ func (c *genericClient) GenericDelete(ctx context.Context, in *serverpb.GenericD
 	return out, nil
 }
 
+func (c *genericClient) GenericList(ctx context.Context, in *serverpb.GenericListRequest, opts ...grpc.CallOption) (*serverpb.GenericListResponse, error) {
+	out := new(serverpb.GenericListResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Generic/GenericList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *genericClient) GenericWatch(ctx context.Context, in *serverpb.GenericWatchRequest, opts ...grpc.CallOption) (Generic_GenericWatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Generic_serviceDesc.Streams[0], c.cc, "/rpcpb.Generic/GenericWatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &genericGenericWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Generic_GenericWatchClient interface {
+	Recv() (*serverpb.GenericWatchResponse, error)
+	grpc.ClientStream
+}
+
+type genericGenericWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *genericGenericWatchClient) Recv() (*serverpb.GenericWatchResponse, error) {
+	m := new(serverpb.GenericWatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Generic service
 
 type GenericServer interface {
@@ -574,6 +843,10 @@ type GenericServer interface {
 	GenericGet(context.Context, *serverpb.GenericGetRequest) (*serverpb.GenericGetResponse, error)
 	// Delete a Generic template by name.
 	GenericDelete(context.Context, *serverpb.GenericDeleteRequest) (*serverpb.GenericDeleteResponse, error)
+	// List the names of all Generic templates.
+	GenericList(context.Context, *serverpb.GenericListRequest) (*serverpb.GenericListResponse, error)
+	// Watch Generic templates for changes.
+	GenericWatch(*serverpb.GenericWatchRequest, Generic_GenericWatchServer) error
 }
 
 func RegisterGenericServer(s *grpc.Server, srv GenericServer) {
@@ -634,6 +907,45 @@ func _Generic_GenericDelete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Generic_GenericList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.GenericListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GenericServer).GenericList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Generic/GenericList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GenericServer).GenericList(ctx, req.(*serverpb.GenericListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Generic_GenericWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(serverpb.GenericWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GenericServer).GenericWatch(m, &genericGenericWatchServer{stream})
+}
+
+type Generic_GenericWatchServer interface {
+	Send(*serverpb.GenericWatchResponse) error
+	grpc.ServerStream
+}
+
+type genericGenericWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *genericGenericWatchServer) Send(m *serverpb.GenericWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Generic_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.Generic",
 	HandlerType: (*GenericServer)(nil),
@@ -650,6 +962,588 @@ var _Generic_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GenericDelete",
 			Handler:    _Generic_GenericDelete_Handler,
 		},
+		{
+			MethodName: "GenericList",
+			Handler:    _Generic_GenericList_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenericWatch",
+			Handler:       _Generic_GenericWatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc.proto",
+}
+
+// Client API for Cloud service
+
+type CloudClient interface {
+	// Create or update a Cloud-Config template.
+	CloudPut(ctx context.Context, in *serverpb.CloudPutRequest, opts ...grpc.CallOption) (*serverpb.CloudPutResponse, error)
+	// Get a Cloud-Config template by name.
+	CloudGet(ctx context.Context, in *serverpb.CloudGetRequest, opts ...grpc.CallOption) (*serverpb.CloudGetResponse, error)
+	// Delete a Cloud-Config template by name.
+	CloudDelete(ctx context.Context, in *serverpb.CloudDeleteRequest, opts ...grpc.CallOption) (*serverpb.CloudDeleteResponse, error)
+	// List the names of all Cloud-Config templates.
+	CloudList(ctx context.Context, in *serverpb.CloudListRequest, opts ...grpc.CallOption) (*serverpb.CloudListResponse, error)
+	// Watch Cloud-Config templates for changes.
+	CloudWatch(ctx context.Context, in *serverpb.CloudWatchRequest, opts ...grpc.CallOption) (Cloud_CloudWatchClient, error)
+}
+
+type cloudClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCloudClient(cc *grpc.ClientConn) CloudClient {
+	return &cloudClient{cc}
+}
+
+func (c *cloudClient) CloudPut(ctx context.Context, in *serverpb.CloudPutRequest, opts ...grpc.CallOption) (*serverpb.CloudPutResponse, error) {
+	out := new(serverpb.CloudPutResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Cloud/CloudPut", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudClient) CloudGet(ctx context.Context, in *serverpb.CloudGetRequest, opts ...grpc.CallOption) (*serverpb.CloudGetResponse, error) {
+	out := new(serverpb.CloudGetResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Cloud/CloudGet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudClient) CloudDelete(ctx context.Context, in *serverpb.CloudDeleteRequest, opts ...grpc.CallOption) (*serverpb.CloudDeleteResponse, error) {
+	out := new(serverpb.CloudDeleteResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Cloud/CloudDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudClient) CloudList(ctx context.Context, in *serverpb.CloudListRequest, opts ...grpc.CallOption) (*serverpb.CloudListResponse, error) {
+	out := new(serverpb.CloudListResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Cloud/CloudList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudClient) CloudWatch(ctx context.Context, in *serverpb.CloudWatchRequest, opts ...grpc.CallOption) (Cloud_CloudWatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Cloud_serviceDesc.Streams[0], c.cc, "/rpcpb.Cloud/CloudWatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cloudCloudWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cloud_CloudWatchClient interface {
+	Recv() (*serverpb.CloudWatchResponse, error)
+	grpc.ClientStream
+}
+
+type cloudCloudWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *cloudCloudWatchClient) Recv() (*serverpb.CloudWatchResponse, error) {
+	m := new(serverpb.CloudWatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Cloud service
+
+type CloudServer interface {
+	// Create or update a Cloud-Config template.
+	CloudPut(context.Context, *serverpb.CloudPutRequest) (*serverpb.CloudPutResponse, error)
+	// Get a Cloud-Config template by name.
+	CloudGet(context.Context, *serverpb.CloudGetRequest) (*serverpb.CloudGetResponse, error)
+	// Delete a Cloud-Config template by name.
+	CloudDelete(context.Context, *serverpb.CloudDeleteRequest) (*serverpb.CloudDeleteResponse, error)
+	// List the names of all Cloud-Config templates.
+	CloudList(context.Context, *serverpb.CloudListRequest) (*serverpb.CloudListResponse, error)
+	// Watch Cloud-Config templates for changes.
+	CloudWatch(*serverpb.CloudWatchRequest, Cloud_CloudWatchServer) error
+}
+
+func RegisterCloudServer(s *grpc.Server, srv CloudServer) {
+	s.RegisterService(&_Cloud_serviceDesc, srv)
+}
+
+func _Cloud_CloudPut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudPutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudPut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudPut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudPut(ctx, req.(*serverpb.CloudPutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloud_CloudGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudGet(ctx, req.(*serverpb.CloudGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloud_CloudDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudDelete(ctx, req.(*serverpb.CloudDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloud_CloudList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudList(ctx, req.(*serverpb.CloudListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloud_CloudWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(serverpb.CloudWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CloudServer).CloudWatch(m, &cloudCloudWatchServer{stream})
+}
+
+type Cloud_CloudWatchServer interface {
+	Send(*serverpb.CloudWatchResponse) error
+	grpc.ServerStream
+}
+
+type cloudCloudWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *cloudCloudWatchServer) Send(m *serverpb.CloudWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Cloud_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Cloud",
+	HandlerType: (*CloudServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CloudPut",
+			Handler:    _Cloud_CloudPut_Handler,
+		},
+		{
+			MethodName: "CloudGet",
+			Handler:    _Cloud_CloudGet_Handler,
+		},
+		{
+			MethodName: "CloudDelete",
+			Handler:    _Cloud_CloudDelete_Handler,
+		},
+		{
+			MethodName: "CloudList",
+			Handler:    _Cloud_CloudList_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CloudWatch",
+			Handler:       _Cloud_CloudWatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc.proto",
+}
+
+// Client API for Partials service
+
+type PartialsClient interface {
+	// Create or update a partial template.
+	PartialPut(ctx context.Context, in *serverpb.PartialPutRequest, opts ...grpc.CallOption) (*serverpb.PartialPutResponse, error)
+	// Get a partial template by name.
+	PartialGet(ctx context.Context, in *serverpb.PartialGetRequest, opts ...grpc.CallOption) (*serverpb.PartialGetResponse, error)
+	// Delete a partial template by name.
+	PartialDelete(ctx context.Context, in *serverpb.PartialDeleteRequest, opts ...grpc.CallOption) (*serverpb.PartialDeleteResponse, error)
+	// List the names of all partial templates.
+	PartialList(ctx context.Context, in *serverpb.PartialListRequest, opts ...grpc.CallOption) (*serverpb.PartialListResponse, error)
+}
+
+type partialsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPartialsClient(cc *grpc.ClientConn) PartialsClient {
+	return &partialsClient{cc}
+}
+
+func (c *partialsClient) PartialPut(ctx context.Context, in *serverpb.PartialPutRequest, opts ...grpc.CallOption) (*serverpb.PartialPutResponse, error) {
+	out := new(serverpb.PartialPutResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Partials/PartialPut", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partialsClient) PartialGet(ctx context.Context, in *serverpb.PartialGetRequest, opts ...grpc.CallOption) (*serverpb.PartialGetResponse, error) {
+	out := new(serverpb.PartialGetResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Partials/PartialGet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partialsClient) PartialDelete(ctx context.Context, in *serverpb.PartialDeleteRequest, opts ...grpc.CallOption) (*serverpb.PartialDeleteResponse, error) {
+	out := new(serverpb.PartialDeleteResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Partials/PartialDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partialsClient) PartialList(ctx context.Context, in *serverpb.PartialListRequest, opts ...grpc.CallOption) (*serverpb.PartialListResponse, error) {
+	out := new(serverpb.PartialListResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Partials/PartialList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Partials service
+
+type PartialsServer interface {
+	// Create or update a partial template.
+	PartialPut(context.Context, *serverpb.PartialPutRequest) (*serverpb.PartialPutResponse, error)
+	// Get a partial template by name.
+	PartialGet(context.Context, *serverpb.PartialGetRequest) (*serverpb.PartialGetResponse, error)
+	// Delete a partial template by name.
+	PartialDelete(context.Context, *serverpb.PartialDeleteRequest) (*serverpb.PartialDeleteResponse, error)
+	// List the names of all partial templates.
+	PartialList(context.Context, *serverpb.PartialListRequest) (*serverpb.PartialListResponse, error)
+}
+
+func RegisterPartialsServer(s *grpc.Server, srv PartialsServer) {
+	s.RegisterService(&_Partials_serviceDesc, srv)
+}
+
+func _Partials_PartialPut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialPutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartialsServer).PartialPut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Partials/PartialPut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartialsServer).PartialPut(ctx, req.(*serverpb.PartialPutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partials_PartialGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartialsServer).PartialGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Partials/PartialGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartialsServer).PartialGet(ctx, req.(*serverpb.PartialGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partials_PartialDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartialsServer).PartialDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Partials/PartialDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartialsServer).PartialDelete(ctx, req.(*serverpb.PartialDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partials_PartialList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartialsServer).PartialList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Partials/PartialList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartialsServer).PartialList(ctx, req.(*serverpb.PartialListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Partials_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Partials",
+	HandlerType: (*PartialsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PartialPut",
+			Handler:    _Partials_PartialPut_Handler,
+		},
+		{
+			MethodName: "PartialGet",
+			Handler:    _Partials_PartialGet_Handler,
+		},
+		{
+			MethodName: "PartialDelete",
+			Handler:    _Partials_PartialDelete_Handler,
+		},
+		{
+			MethodName: "PartialList",
+			Handler:    _Partials_PartialList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
+
+// Client API for History service
+
+type HistoryClient interface {
+	// History returns the recorded revisions of a resource.
+	History(ctx context.Context, in *serverpb.HistoryRequest, opts ...grpc.CallOption) (*serverpb.HistoryResponse, error)
+	// Rollback restores a resource to a recorded revision.
+	Rollback(ctx context.Context, in *serverpb.RollbackRequest, opts ...grpc.CallOption) (*serverpb.RollbackResponse, error)
+}
+
+type historyClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHistoryClient(cc *grpc.ClientConn) HistoryClient {
+	return &historyClient{cc}
+}
+
+func (c *historyClient) History(ctx context.Context, in *serverpb.HistoryRequest, opts ...grpc.CallOption) (*serverpb.HistoryResponse, error) {
+	out := new(serverpb.HistoryResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.History/History", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyClient) Rollback(ctx context.Context, in *serverpb.RollbackRequest, opts ...grpc.CallOption) (*serverpb.RollbackResponse, error) {
+	out := new(serverpb.RollbackResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.History/Rollback", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for History service
+
+type HistoryServer interface {
+	// History returns the recorded revisions of a resource.
+	History(context.Context, *serverpb.HistoryRequest) (*serverpb.HistoryResponse, error)
+	// Rollback restores a resource to a recorded revision.
+	Rollback(context.Context, *serverpb.RollbackRequest) (*serverpb.RollbackResponse, error)
+}
+
+func RegisterHistoryServer(s *grpc.Server, srv HistoryServer) {
+	s.RegisterService(&_History_serviceDesc, srv)
+}
+
+func _History_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.History/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServer).History(ctx, req.(*serverpb.HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _History_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.RollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.History/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServer).Rollback(ctx, req.(*serverpb.RollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _History_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.History",
+	HandlerType: (*HistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "History",
+			Handler:    _History_History_Handler,
+		},
+		{
+			MethodName: "Rollback",
+			Handler:    _History_Rollback_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
+
+// Client API for Validate service
+
+type ValidateClient interface {
+	// Validate returns the problems of the stored resources.
+	Validate(ctx context.Context, in *serverpb.ValidateRequest, opts ...grpc.CallOption) (*serverpb.ValidateResponse, error)
+}
+
+type validateClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewValidateClient(cc *grpc.ClientConn) ValidateClient {
+	return &validateClient{cc}
+}
+
+func (c *validateClient) Validate(ctx context.Context, in *serverpb.ValidateRequest, opts ...grpc.CallOption) (*serverpb.ValidateResponse, error) {
+	out := new(serverpb.ValidateResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.Validate/Validate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Validate service
+
+type ValidateServer interface {
+	// Validate returns the problems of the stored resources.
+	Validate(context.Context, *serverpb.ValidateRequest) (*serverpb.ValidateResponse, error)
+}
+
+func RegisterValidateServer(s *grpc.Server, srv ValidateServer) {
+	s.RegisterService(&_Validate_serviceDesc, srv)
+}
+
+func _Validate_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidateServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Validate/Validate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidateServer).Validate(ctx, req.(*serverpb.ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Validate_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Validate",
+	HandlerType: (*ValidateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Validate",
+			Handler:    _Validate_Validate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
@@ -759,31 +1653,48 @@ var _Select_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 411 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x94, 0xd1, 0x4e, 0xc2, 0x30,
-	0x14, 0x86, 0x85, 0x44, 0x84, 0x1a, 0xbd, 0xd8, 0x9d, 0x08, 0x62, 0x7c, 0x80, 0x91, 0xe0, 0x1b,
-	0xa8, 0x71, 0x21, 0xe1, 0x82, 0x60, 0x7c, 0x00, 0xb6, 0x1c, 0x61, 0x09, 0xac, 0xb3, 0xed, 0x8c,
-	0x8f, 0x64, 0x7c, 0x0c, 0x5f, 0xc9, 0x6b, 0x13, 0xd3, 0xae, 0xed, 0xce, 0xd6, 0xf6, 0x8a, 0x93,
-	0xff, 0xdb, 0xf9, 0x39, 0xfb, 0xcf, 0x01, 0x32, 0x62, 0x65, 0x16, 0x97, 0x8c, 0x0a, 0x1a, 0x9d,
-	0xb2, 0x32, 0x2b, 0xd3, 0xf1, 0xc3, 0x2e, 0x17, 0xfb, 0x2a, 0x8d, 0x33, 0x7a, 0x9c, 0x67, 0x94,
-	0x01, 0xe5, 0xf3, 0xe3, 0x56, 0x64, 0xfb, 0x94, 0x7e, 0x36, 0x05, 0x07, 0xf6, 0x01, 0x4c, 0x7f,
-	0x94, 0xe9, 0xfc, 0x08, 0x9c, 0x6f, 0x77, 0xc0, 0x6b, 0xab, 0xc5, 0x57, 0x9f, 0x0c, 0x12, 0x46,
-	0xab, 0x92, 0x47, 0x8f, 0x64, 0xa8, 0xaa, 0x75, 0x25, 0xa2, 0xab, 0xd8, 0x34, 0xc4, 0x46, 0xdb,
-	0xc0, 0x7b, 0x05, 0x5c, 0x8c, 0xc7, 0x3e, 0xc4, 0x4b, 0x5a, 0x70, 0xb8, 0x3b, 0xb1, 0x26, 0x09,
-	0xb8, 0x26, 0x09, 0x04, 0x4d, 0x14, 0xb2, 0x26, 0x2b, 0x72, 0xae, 0xd4, 0x27, 0x38, 0x80, 0x80,
-	0x68, 0xd2, 0x79, 0xb8, 0x96, 0x8d, 0xd5, 0x34, 0x40, 0xad, 0xdb, 0x33, 0x19, 0x29, 0xb0, 0xca,
-	0xb9, 0x88, 0xba, 0x5f, 0x2c, 0x45, 0xe3, 0x74, 0xed, 0x65, 0xc6, 0x67, 0xf1, 0xd3, 0x27, 0xc3,
-	0x35, 0xa3, 0x6f, 0xf9, 0x01, 0x78, 0xb4, 0x24, 0x44, 0xd7, 0x32, 0x2e, 0xd4, 0xd9, 0xa8, 0xc6,
-	0x76, 0xe2, 0x87, 0x76, 0xbe, 0xc6, 0x4a, 0x86, 0xe6, 0x5a, 0xa1, 0xd8, 0x26, 0x7e, 0x68, 0xad,
-	0x36, 0xe4, 0x42, 0xeb, 0x3a, 0xba, 0x1b, 0xa7, 0xa1, 0x1d, 0xde, 0x2c, 0xc8, 0xf1, 0x32, 0x34,
-	0x52, 0x01, 0xba, 0x23, 0xe0, 0x08, 0xa7, 0x01, 0x6a, 0x43, 0xfc, 0xeb, 0x91, 0xe1, 0x72, 0x57,
-	0xe4, 0x22, 0xa7, 0x85, 0xb4, 0x36, 0xb5, 0x4c, 0x11, 0x59, 0x23, 0xd9, 0x63, 0xdd, 0xa2, 0x78,
-	0x50, 0x03, 0x64, 0x90, 0x1e, 0x37, 0x94, 0xe4, 0x34, 0x40, 0xad, 0xdb, 0x2b, 0xb9, 0x34, 0x40,
-	0x67, 0x39, 0x73, 0x5b, 0xda, 0x61, 0xde, 0x86, 0x1f, 0xb0, 0xef, 0xff, 0xdb, 0x23, 0x67, 0x09,
-	0x14, 0xc0, 0xf2, 0x4c, 0x2e, 0x5e, 0x97, 0x9d, 0x1b, 0x6a, 0x54, 0xcf, 0xe2, 0x31, 0xc4, 0x37,
-	0xa4, 0xf5, 0xce, 0x0d, 0x35, 0x6a, 0xd8, 0xca, 0xb9, 0x21, 0xad, 0xbb, 0x37, 0xd4, 0x02, 0x9e,
-	0x1b, 0xea, 0x70, 0xfb, 0xd6, 0xdf, 0x3d, 0x32, 0x78, 0x81, 0x03, 0x64, 0x42, 0x6e, 0xa9, 0xae,
-	0xd4, 0x4f, 0x0c, 0x6f, 0x09, 0xc9, 0x9e, 0x2d, 0xb5, 0x28, 0x1e, 0xb6, 0x06, 0xfa, 0xda, 0xf0,
-	0xb0, 0x2d, 0xe0, 0x19, 0xb6, 0xc3, 0x8d, 0x67, 0x3a, 0x50, 0xff, 0x8c, 0xf7, 0xff, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xf6, 0x7f, 0x75, 0x65, 0x71, 0x05, 0x00, 0x00,
+	// 678 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x96, 0xcd, 0x6e, 0xd4, 0x30,
+	0x14, 0x85, 0x69, 0xab, 0xb6, 0x53, 0xd3, 0xb2, 0xc8, 0x8a, 0x96, 0xfe, 0x20, 0x1e, 0x60, 0x8a,
+	0xca, 0x0b, 0x20, 0x8a, 0x08, 0x15, 0x95, 0x3a, 0x0c, 0x7f, 0xeb, 0x24, 0xbd, 0x4c, 0x23, 0x32,
+	0xe3, 0x10, 0x3b, 0x08, 0xde, 0x82, 0x05, 0xbc, 0x01, 0x2b, 0x1e, 0x83, 0x67, 0x82, 0x3d, 0x8a,
+	0x7f, 0xaf, 0xed, 0x9b, 0xae, 0x7a, 0x75, 0xbe, 0xf8, 0xd4, 0xe3, 0x13, 0xcf, 0x19, 0xb6, 0xd3,
+	0xb5, 0xd5, 0xb4, 0xed, 0xb8, 0xe4, 0xd9, 0x66, 0xd7, 0x56, 0x6d, 0x79, 0xf0, 0x6c, 0x51, 0xcb,
+	0x9b, 0xbe, 0x9c, 0x56, 0x7c, 0x79, 0x5a, 0xf1, 0x0e, 0xb8, 0x38, 0x5d, 0x16, 0xb2, 0xba, 0x29,
+	0xf9, 0x57, 0x3f, 0x08, 0xe8, 0xbe, 0x40, 0x67, 0xfe, 0xb4, 0xe5, 0xe9, 0x12, 0x84, 0x28, 0x16,
+	0x20, 0xb4, 0xd5, 0xd9, 0xbf, 0x75, 0xb6, 0x95, 0x77, 0xbc, 0x6f, 0x45, 0x76, 0xce, 0x26, 0x6a,
+	0x9a, 0xf5, 0x32, 0xdb, 0x9f, 0xda, 0x05, 0x53, 0xab, 0xcd, 0xe1, 0x73, 0x0f, 0x42, 0x1e, 0x1c,
+	0x50, 0x48, 0xb4, 0x7c, 0x25, 0xe0, 0xd1, 0x1d, 0x67, 0x92, 0x43, 0x6a, 0x92, 0xc3, 0xa8, 0x49,
+	0x0e, 0xd8, 0xe4, 0x92, 0xdd, 0x55, 0xea, 0x73, 0x68, 0x40, 0x42, 0x76, 0x18, 0x3d, 0xac, 0x65,
+	0x6b, 0x75, 0x34, 0x42, 0x9d, 0xdb, 0x0b, 0xb6, 0xa3, 0xc0, 0x65, 0x2d, 0x64, 0x16, 0xff, 0xe3,
+	0x41, 0xb4, 0x4e, 0x0f, 0x48, 0xe6, 0x7c, 0x5e, 0x31, 0xa6, 0xe4, 0x0f, 0xc3, 0xd1, 0x66, 0xf1,
+	0xc3, 0x4a, 0xb5, 0x4e, 0x87, 0x34, 0xb4, 0x56, 0x8f, 0xd7, 0xce, 0x7e, 0x6e, 0xb0, 0xc9, 0xac,
+	0xe3, 0x1f, 0xeb, 0x06, 0x44, 0x76, 0xc1, 0x98, 0x99, 0x87, 0xb3, 0x47, 0xce, 0x5e, 0x25, 0x9c,
+	0x31, 0x74, 0x9b, 0xf4, 0x56, 0x39, 0x50, 0x56, 0x39, 0xdc, 0x62, 0x15, 0xa6, 0x30, 0x67, 0x7b,
+	0x46, 0x37, 0x39, 0x1c, 0x27, 0x0b, 0xc2, 0x24, 0x4e, 0x46, 0x39, 0x4e, 0xd6, 0x20, 0x95, 0x46,
+	0xba, 0x05, 0x9c, 0xc7, 0xd1, 0x08, 0x75, 0x6e, 0xaf, 0xd9, 0xae, 0x01, 0x3a, 0x93, 0x74, 0x41,
+	0x90, 0xca, 0xf1, 0x18, 0x46, 0xb9, 0xfc, 0xda, 0x60, 0x93, 0x8b, 0xc5, 0xaa, 0x96, 0x35, 0x5f,
+	0x0d, 0xbb, 0xb5, 0xf3, 0xac, 0x0f, 0x76, 0x8b, 0x64, 0x62, 0xb7, 0x01, 0xc5, 0x9f, 0xdd, 0x82,
+	0x1c, 0x48, 0xb7, 0x1c, 0x6e, 0x73, 0x0b, 0xd3, 0x79, 0xc7, 0xee, 0x59, 0x60, 0xe2, 0x39, 0x49,
+	0x97, 0x84, 0xf9, 0x3c, 0x1c, 0x7f, 0xc0, 0xd9, 0x5e, 0xb1, 0x5d, 0xcb, 0x54, 0x42, 0xc4, 0x3e,
+	0x70, 0x44, 0xc7, 0x63, 0xd8, 0x19, 0xbe, 0x65, 0x7b, 0x96, 0xe8, 0x90, 0x88, 0x25, 0x41, 0x4a,
+	0x27, 0xa3, 0x1c, 0xc5, 0xf4, 0x63, 0x83, 0x6d, 0xe7, 0xb0, 0x82, 0xae, 0xae, 0x86, 0x57, 0xde,
+	0x8c, 0xd1, 0xed, 0xf1, 0x2a, 0x75, 0x2f, 0x11, 0xc4, 0xb7, 0xc7, 0xe8, 0xd1, 0xed, 0xf1, 0xea,
+	0xb8, 0x55, 0x72, 0x7b, 0x8c, 0x9e, 0xde, 0x9e, 0x00, 0x10, 0x9f, 0x3b, 0xe2, 0xc1, 0xf7, 0xa2,
+	0x46, 0xf1, 0xed, 0x41, 0x32, 0xf5, 0xbd, 0x88, 0x29, 0xbe, 0x3d, 0x06, 0x24, 0xb7, 0x07, 0xeb,
+	0x44, 0xd4, 0x21, 0x46, 0xb1, 0xfc, 0x5d, 0x67, 0x9b, 0xe7, 0x0d, 0xef, 0xaf, 0x87, 0x1e, 0x50,
+	0x43, 0x54, 0x26, 0x56, 0x23, 0x7a, 0xc0, 0x23, 0x5c, 0x26, 0x4a, 0x8d, 0xca, 0xc4, 0x6a, 0x63,
+	0x26, 0x49, 0x99, 0x28, 0x35, 0x2d, 0x13, 0x24, 0x13, 0x87, 0x16, 0x50, 0x5c, 0x26, 0x0a, 0xc4,
+	0x65, 0xe2, 0x44, 0xa2, 0x4c, 0x10, 0xc3, 0x65, 0xa2, 0xe4, 0xa4, 0x4c, 0xbc, 0x4a, 0xbc, 0x69,
+	0x18, 0xa2, 0x63, 0xff, 0xb3, 0xce, 0x26, 0xb3, 0xa2, 0x93, 0x75, 0xd1, 0xe8, 0x32, 0xd1, 0x73,
+	0x5c, 0x26, 0x4e, 0xa5, 0x1a, 0x00, 0xc1, 0xa0, 0x4c, 0xb4, 0x1e, 0x97, 0x89, 0x53, 0xc7, 0xad,
+	0xd2, 0x32, 0xd1, 0x3a, 0x51, 0x26, 0x18, 0x50, 0x65, 0x12, 0xf2, 0xa0, 0x4c, 0x34, 0x4a, 0xca,
+	0xc4, 0xcb, 0x54, 0x99, 0x60, 0x6a, 0xdd, 0xce, 0xbe, 0xaf, 0xb1, 0xed, 0x97, 0xb5, 0x90, 0xbc,
+	0xfb, 0x96, 0x3d, 0xf5, 0xe3, 0x7d, 0xbf, 0xce, 0x48, 0xd6, 0x71, 0x9f, 0x20, 0xf8, 0xd5, 0x9d,
+	0xf3, 0xa6, 0x29, 0x8b, 0xea, 0x13, 0x7e, 0x75, 0xad, 0x46, 0xbc, 0xba, 0x1e, 0xb9, 0x2d, 0x5d,
+	0xb1, 0xc9, 0xfb, 0xa2, 0xa9, 0xaf, 0x0b, 0x09, 0xd9, 0x39, 0x9a, 0x91, 0xa1, 0xd5, 0x08, 0x43,
+	0x8f, 0x9c, 0xe1, 0xef, 0x35, 0xb6, 0xf5, 0x06, 0x1a, 0xa8, 0xe4, 0x70, 0x78, 0x7a, 0x52, 0x3f,
+	0x50, 0xf0, 0xe1, 0x21, 0x99, 0x38, 0xbc, 0x80, 0xe2, 0x78, 0x35, 0x30, 0xc5, 0x8a, 0xe3, 0x0d,
+	0x00, 0x11, 0x6f, 0xc4, 0xad, 0x67, 0xb9, 0xa5, 0x7e, 0xa1, 0x3e, 0xf9, 0x3f, 0x00, 0x2b, 0x3e,
+	0xff, 0xd0, 0xf9, 0x0a, 0x00, 0x00,
 }
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"github.com/coreos/matchbox/matchbox/secret"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// redactGroup returns a copy of a Group with the strings of its secret
+// values redacted, since gRPC clients may only write secrets.
+func redactGroup(group *storagepb.Group) (*storagepb.Group, error) {
+	if group == nil {
+		return nil, nil
+	}
+	metadata, err := secret.Redact(group.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	redacted := *group
+	redacted.Metadata = metadata
+	return &redacted, nil
+}
+
+// redactGroups returns copies of Groups with their secret values redacted.
+func redactGroups(groups []*storagepb.Group) ([]*storagepb.Group, error) {
+	redacted := make([]*storagepb.Group, len(groups))
+	for i, group := range groups {
+		var err error
+		if redacted[i], err = redactGroup(group); err != nil {
+			return nil, err
+		}
+	}
+	return redacted, nil
+}
+
+// redactProfile returns a copy of a Profile with the strings of its secret
+// values redacted, since gRPC clients may only write secrets.
+func redactProfile(profile *storagepb.Profile) (*storagepb.Profile, error) {
+	if profile == nil {
+		return nil, nil
+	}
+	metadata, err := secret.Redact(profile.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	redacted := *profile
+	redacted.Metadata = metadata
+	return &redacted, nil
+}
+
+// redactProfiles returns copies of Profiles with their secret values
+// redacted.
+func redactProfiles(profiles []*storagepb.Profile) ([]*storagepb.Profile, error) {
+	redacted := make([]*storagepb.Profile, len(profiles))
+	for i, profile := range profiles {
+		var err error
+		if redacted[i], err = redactProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+	return redacted, nil
+}
+
+// redactEvent returns a copy of an Event with the secret values of its
+// Group or Profile redacted.
+func redactEvent(event *storagepb.Event) (*storagepb.Event, error) {
+	group, err := redactGroup(event.Group)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := redactProfile(event.Profile)
+	if err != nil {
+		return nil, err
+	}
+	redacted := *event
+	redacted.Group = group
+	redacted.Profile = profile
+	return &redacted, nil
+}
+
+// redactRevisions returns copies of Revisions with the secret values of
+// recorded Groups and Profiles redacted.
+func redactRevisions(revisions []*storagepb.Revision) ([]*storagepb.Revision, error) {
+	redacted := make([]*storagepb.Revision, len(revisions))
+	for i, revision := range revisions {
+		copied := *revision
+		if revision.Kind == "groups" || revision.Kind == "profiles" {
+			content, err := secret.Redact(revision.Content)
+			if err != nil {
+				return nil, err
+			}
+			copied.Content = content
+		}
+		redacted[i] = &copied
+	}
+	return redacted, nil
+}
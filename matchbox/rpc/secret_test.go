@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestGroupServer_RedactsSecrets(t *testing.T) {
+	group := &storagepb.Group{
+		Id:       "node1",
+		Metadata: []byte(`{"user":"core","token":{"$encrypted":"c2VjcmV0"}}`),
+	}
+	store := &fake.FixedStore{Groups: map[string]*storagepb.Group{group.Id: group}}
+	srv := newGroupServer(server.NewServer(&server.Config{Store: store}))
+	redacted := `{"user":"core","token":{"$encrypted":"REDACTED"}}`
+
+	// assert that secret values are redacted in responses
+	getResp, err := srv.GroupGet(context.Background(), &pb.GroupGetRequest{Id: group.Id})
+	assert.Nil(t, err)
+	assert.JSONEq(t, redacted, string(getResp.Group.Metadata))
+	listResp, err := srv.GroupList(context.Background(), &pb.GroupListRequest{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(listResp.Groups)) {
+		assert.JSONEq(t, redacted, string(listResp.Groups[0].Metadata))
+	}
+	// but not in the Store
+	assert.JSONEq(t, `{"user":"core","token":{"$encrypted":"c2VjcmV0"}}`, string(store.Groups[group.Id].Metadata))
+}
+
+func TestProfileServer_RedactsSecrets(t *testing.T) {
+	profile := &storagepb.Profile{
+		Id:       "worker",
+		Metadata: []byte(`{"user":"core","token":{"$encrypted":"c2VjcmV0"}}`),
+	}
+	store := &fake.FixedStore{Profiles: map[string]*storagepb.Profile{profile.Id: profile}}
+	srv := newProfileServer(server.NewServer(&server.Config{Store: store}))
+	redacted := `{"user":"core","token":{"$encrypted":"REDACTED"}}`
+
+	// assert that secret values are redacted in responses
+	getResp, err := srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: profile.Id})
+	assert.Nil(t, err)
+	assert.JSONEq(t, redacted, string(getResp.Profile.Metadata))
+	listResp, err := srv.ProfileList(context.Background(), &pb.ProfileListRequest{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(listResp.Profiles)) {
+		assert.JSONEq(t, redacted, string(listResp.Profiles[0].Metadata))
+	}
+	// but not in the Store
+	assert.JSONEq(t, `{"user":"core","token":{"$encrypted":"c2VjcmV0"}}`, string(store.Profiles[profile.Id].Metadata))
+}
+
+func TestRedactRevisions(t *testing.T) {
+	revisions := []*storagepb.Revision{
+		{Kind: "groups", Name: "node1", Content: []byte(`{"token":{"$encrypted":"c2VjcmV0"}}`)},
+		{Kind: "profiles", Name: "worker", Content: []byte(`{"token":{"$encrypted":"c2VjcmV0"}}`)},
+		{Kind: "generic", Name: "generic.tmpl", Content: []byte(`{"token":{"$encrypted":"c2VjcmV0"}}`)},
+	}
+
+	// assert that secret values of Groups and Profiles are redacted
+	redacted, err := redactRevisions(revisions)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"token":{"$encrypted":"REDACTED"}}`, string(redacted[0].Content))
+	assert.JSONEq(t, `{"token":{"$encrypted":"REDACTED"}}`, string(redacted[1].Content))
+	assert.Equal(t, revisions[2].Content, redacted[2].Content)
+}
@@ -21,10 +21,16 @@ func newSelectServer(s server.Server) rpcpb.SelectServer {
 
 func (s *selectServer) SelectGroup(ctx context.Context, req *pb.SelectGroupRequest) (*pb.SelectGroupResponse, error) {
 	group, err := s.srv.SelectGroup(ctx, req)
+	if err == nil {
+		group, err = redactGroup(group)
+	}
 	return &pb.SelectGroupResponse{Group: group}, grpcError(err)
 }
 
 func (s *selectServer) SelectProfile(ctx context.Context, req *pb.SelectProfileRequest) (*pb.SelectProfileResponse, error) {
 	profile, err := s.srv.SelectProfile(ctx, req)
+	if err == nil {
+		profile, err = redactProfile(profile)
+	}
 	return &pb.SelectProfileResponse{Profile: profile}, grpcError(err)
 }
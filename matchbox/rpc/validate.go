@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/coreos/matchbox/matchbox/rpc/rpcpb"
+	"github.com/coreos/matchbox/matchbox/server"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+)
+
+// validateServer takes a matchbox Server and implements a gRPC
+// ValidateServer.
+type validateServer struct {
+	srv server.Server
+}
+
+func newValidateServer(s server.Server) rpcpb.ValidateServer {
+	return &validateServer{
+		srv: s,
+	}
+}
+
+func (s *validateServer) Validate(ctx context.Context, req *pb.ValidateRequest) (*pb.ValidateResponse, error) {
+	problems, err := s.srv.Validate(ctx, req)
+	return &pb.ValidateResponse{Problems: problems}, grpcError(err)
+}
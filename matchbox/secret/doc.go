@@ -0,0 +1,3 @@
+// Package secret encrypts secret values of Group metadata with a server-side
+// key, so they are stored encrypted and decrypted only to render templates.
+package secret
@@ -0,0 +1,83 @@
+package secret
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// KeySize is the size of a secret Key, which is an AES-256 key.
+const KeySize = 32
+
+var (
+	errKeySize    = fmt.Errorf("secret: key must be %d bytes", KeySize)
+	errCiphertext = errors.New("secret: ciphertext is too short")
+)
+
+// A Key encrypts and decrypts secret values with AES-256-GCM.
+type Key struct {
+	aead cipher.AEAD
+}
+
+// NewKey returns a Key from KeySize bytes of key material.
+func NewKey(key []byte) (*Key, error) {
+	if len(key) != KeySize {
+		return nil, errKeySize
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{aead: aead}, nil
+}
+
+// LoadKey reads a key file, which holds KeySize random bytes encoded as
+// base64 (e.g. from `openssl rand -base64 32`), and returns its Key.
+func LoadKey(path string) (*Key, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("secret: invalid key file %s: %v", path, err)
+	}
+	return NewKey(key)
+}
+
+// Encrypt encrypts a plaintext and returns the base64 encoded nonce and
+// ciphertext.
+func (k *Key) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := k.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a ciphertext returned by Encrypt.
+func (k *Key) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	size := k.aead.NonceSize()
+	if len(sealed) < size {
+		return "", errCiphertext
+	}
+	plaintext, err := k.aead.Open(nil, sealed[:size], sealed[size:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,44 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadKey(t *testing.T) {
+	key, err := LoadKey("fixtures/secret.key")
+	assert.Nil(t, err)
+	assert.NotNil(t, key)
+}
+
+func TestLoadKey_Invalid(t *testing.T) {
+	_, err := LoadKey("fixtures/missing.key")
+	assert.Error(t, err)
+	_, err = LoadKey("fixtures/short.key")
+	assert.Equal(t, errKeySize, err)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, err := LoadKey("fixtures/secret.key")
+	assert.Nil(t, err)
+	ciphertext, err := key.Encrypt("s3cr3t")
+	assert.Nil(t, err)
+	assert.NotContains(t, ciphertext, "s3cr3t")
+	// assert that each encryption uses a new nonce
+	other, err := key.Encrypt("s3cr3t")
+	assert.Nil(t, err)
+	assert.NotEqual(t, ciphertext, other)
+
+	plaintext, err := key.Decrypt(ciphertext)
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cr3t", plaintext)
+
+	// assert that ciphertexts of other keys or truncated ones are rejected
+	otherKey, err := NewKey([]byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, err)
+	_, err = otherKey.Decrypt(ciphertext)
+	assert.Error(t, err)
+	_, err = key.Decrypt("AAAA")
+	assert.Equal(t, errCiphertext, err)
+}
@@ -0,0 +1,211 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Members of secret values. A secret value is a JSON object with a single
+// member, either "$secret" with a plaintext string, which is encrypted when a
+// Group is written through the API, or "$encrypted" with a ciphertext.
+const (
+	PlaintextMember = "$secret"
+	EncryptedMember = "$encrypted"
+)
+
+// Redacted replaces the strings of the secret values of Groups returned to
+// clients.
+const Redacted = "REDACTED"
+
+// ErrNoKey is returned when secret values must be encrypted or decrypted,
+// but there is no Key.
+var ErrNoKey = errors.New("secret: no secret key to encrypt or decrypt secret values")
+
+// pointerEscaper escapes object member names in JSON pointers (RFC 6901).
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// secretFunc returns the replacement of a secret value, given its JSON
+// pointer, member, and string, or nil to keep it.
+type secretFunc func(path, member, s string) (interface{}, error)
+
+// Encrypt returns Group metadata with its plaintext secret values encrypted.
+func Encrypt(key *Key, metadata []byte) ([]byte, error) {
+	return transformMetadata(metadata, func(path, member, s string) (interface{}, error) {
+		if member != PlaintextMember {
+			return nil, nil
+		}
+		if key == nil {
+			return nil, ErrNoKey
+		}
+		ciphertext, err := key.Encrypt(s)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{EncryptedMember: ciphertext}, nil
+	})
+}
+
+// Decrypt returns a decoded metadata value with its secret values replaced
+// by their plaintext strings, for rendering templates. The path is the JSON
+// pointer of the value within the metadata, which errors name.
+func Decrypt(key *Key, path string, value interface{}) (interface{}, error) {
+	value, _, err := transform(value, path, func(path, member, s string) (interface{}, error) {
+		if member == PlaintextMember {
+			return s, nil
+		}
+		if key == nil {
+			return nil, ErrNoKey
+		}
+		plaintext, err := key.Decrypt(s)
+		if err != nil {
+			return nil, fmt.Errorf("secret: cannot decrypt %s: %v", path, err)
+		}
+		return plaintext, nil
+	})
+	return value, err
+}
+
+// Redact returns Group metadata with the strings of its secret values
+// replaced by Redacted.
+func Redact(metadata []byte) ([]byte, error) {
+	return transformMetadata(metadata, func(path, member, s string) (interface{}, error) {
+		if s == Redacted {
+			return nil, nil
+		}
+		return map[string]interface{}{member: Redacted}, nil
+	})
+}
+
+// Unredact returns Group metadata with its redacted secret values replaced
+// by the secret values at the same paths of the stored metadata, so a
+// redacted Group can be written back.
+func Unredact(metadata, stored []byte) ([]byte, error) {
+	storedSecrets := make(map[string]interface{})
+	_, err := transformMetadata(stored, func(path, member, s string) (interface{}, error) {
+		storedSecrets[path] = map[string]interface{}{member: s}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transformMetadata(metadata, func(path, member, s string) (interface{}, error) {
+		if s != Redacted {
+			return nil, nil
+		}
+		value, ok := storedSecrets[path]
+		if !ok {
+			return nil, fmt.Errorf("secret: redacted secret value %s has no stored value", path)
+		}
+		return value, nil
+	})
+}
+
+// Problems returns sorted descriptions of the secret values of Group
+// metadata which are stored in plaintext or cannot be decrypted.
+func Problems(key *Key, metadata []byte) ([]string, error) {
+	var problems []string
+	_, err := transformMetadata(metadata, func(path, member, s string) (interface{}, error) {
+		switch {
+		case member == PlaintextMember:
+			problems = append(problems, fmt.Sprintf("secret value %s is not encrypted", path))
+		case key == nil:
+			problems = append(problems, fmt.Sprintf("secret value %s cannot be decrypted without a secret key", path))
+		default:
+			if _, err := key.Decrypt(s); err != nil {
+				problems = append(problems, fmt.Sprintf("secret value %s cannot be decrypted: %v", path, err))
+			}
+		}
+		return nil, nil
+	})
+	sort.Strings(problems)
+	return problems, err
+}
+
+// transformMetadata returns Group metadata with its secret values replaced
+// by the results of fn. Metadata without replacements is returned as is.
+func transformMetadata(metadata []byte, fn secretFunc) ([]byte, error) {
+	if len(metadata) == 0 {
+		return metadata, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(metadata))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	value, changed, err := transform(value, "", fn)
+	if err != nil || !changed {
+		return metadata, err
+	}
+	return json.Marshal(value)
+}
+
+// transform returns a decoded JSON value with its secret values replaced by
+// the results of fn, unless they are nil, and whether any was replaced.
+// Objects and arrays are modified in place.
+func transform(value interface{}, path string, fn secretFunc) (interface{}, bool, error) {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if member, s, ok := secretValue(v); ok {
+			replacement, err := fn(path, member, s)
+			if err != nil || replacement == nil {
+				return value, false, err
+			}
+			return replacement, true, nil
+		}
+		for key, val := range v {
+			val, ok, err := transform(val, path+"/"+pointerEscaper.Replace(key), fn)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				v[key] = val
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, val := range v {
+			val, ok, err := transform(val, path+"/"+strconv.Itoa(i), fn)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				v[i] = val
+				changed = true
+			}
+		}
+	}
+	return value, changed, nil
+}
+
+// IsValue returns true if a decoded metadata value is a secret value.
+func IsValue(value interface{}) bool {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, _, ok = secretValue(object)
+	return ok
+}
+
+// secretValue returns the member and string of a secret value and true, or
+// false if the object is not a secret value.
+func secretValue(object map[string]interface{}) (member, s string, ok bool) {
+	if len(object) != 1 {
+		return "", "", false
+	}
+	for member, value := range object {
+		if member != PlaintextMember && member != EncryptedMember {
+			return "", "", false
+		}
+		s, ok = value.(string)
+		return member, s, ok
+	}
+	return "", "", false
+}
@@ -0,0 +1,104 @@
+package secret
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	key, err := LoadKey("fixtures/secret.key")
+	assert.Nil(t, err)
+	metadata := []byte(`{"user":"core","token":{"$secret":"abc"},"keys":[{"$secret":"ssh"}],"port":8080}`)
+
+	// assert that plaintext secret values are encrypted and others kept
+	encrypted, err := Encrypt(key, metadata)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(encrypted), "abc")
+	assert.NotContains(t, string(encrypted), PlaintextMember)
+	var value map[string]interface{}
+	assert.Nil(t, json.Unmarshal(encrypted, &value))
+	assert.Equal(t, "core", value["user"])
+	assert.Equal(t, float64(8080), value["port"])
+
+	// assert that they decrypt to their plaintext strings
+	decrypted, err := Decrypt(key, "", value)
+	assert.Nil(t, err)
+	expected := map[string]interface{}{
+		"user":  "core",
+		"token": "abc",
+		"keys":  []interface{}{"ssh"},
+		"port":  float64(8080),
+	}
+	assert.Equal(t, expected, decrypted)
+
+	// assert that metadata without secret values is not rewritten
+	plain := []byte(`{"b": 1, "a": {"$secret": "x", "other": "y"}}`)
+	same, err := Encrypt(nil, plain)
+	assert.Nil(t, err)
+	assert.Equal(t, plain, same)
+
+	// assert that plaintext secret values cannot be written without a Key
+	_, err = Encrypt(nil, metadata)
+	assert.Equal(t, ErrNoKey, err)
+}
+
+func TestDecrypt_Invalid(t *testing.T) {
+	key, err := LoadKey("fixtures/secret.key")
+	assert.Nil(t, err)
+	value := map[string]interface{}{"a": map[string]interface{}{EncryptedMember: "AAAA"}}
+	_, err = Decrypt(key, "", value)
+	assert.EqualError(t, err, "secret: cannot decrypt /a: "+errCiphertext.Error())
+	_, err = Decrypt(key, "/metadata", value)
+	assert.EqualError(t, err, "secret: cannot decrypt /metadata/a: "+errCiphertext.Error())
+	_, err = Decrypt(nil, "", value)
+	assert.Equal(t, ErrNoKey, err)
+}
+
+func TestRedact(t *testing.T) {
+	stored := []byte(`{"token":{"$encrypted":"c2VjcmV0"},"list":[{"$secret":"plain"}],"user":"core"}`)
+	redacted, err := Redact(stored)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"token":{"$encrypted":"REDACTED"},"list":[{"$secret":"REDACTED"}],"user":"core"}`, string(redacted))
+
+	// assert that redacted secret values are restored from stored metadata
+	edited := []byte(`{"token":{"$encrypted":"REDACTED"},"list":[{"$secret":"REDACTED"}],"user":"admin"}`)
+	unredacted, err := Unredact(edited, stored)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"token":{"$encrypted":"c2VjcmV0"},"list":[{"$secret":"plain"}],"user":"admin"}`, string(unredacted))
+
+	// assert that redacted secret values must have a stored value
+	_, err = Unredact([]byte(`{"moved":{"$encrypted":"REDACTED"}}`), stored)
+	assert.EqualError(t, err, "secret: redacted secret value /moved has no stored value")
+	_, err = Unredact(edited, nil)
+	assert.Error(t, err)
+}
+
+func TestProblems(t *testing.T) {
+	key, err := LoadKey("fixtures/secret.key")
+	assert.Nil(t, err)
+	ciphertext, err := key.Encrypt("abc")
+	assert.Nil(t, err)
+	metadata := []byte(`{"ok":{"$encrypted":"` + ciphertext + `"},"plain":{"$secret":"abc"},"a/b":{"$encrypted":"AAAA"}}`)
+
+	problems, err := Problems(key, metadata)
+	assert.Nil(t, err)
+	expected := []string{
+		"secret value /a~1b cannot be decrypted: " + errCiphertext.Error(),
+		"secret value /plain is not encrypted",
+	}
+	assert.Equal(t, expected, problems)
+
+	problems, err = Problems(nil, []byte(`{"ok":{"$encrypted":"`+ciphertext+`"}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"secret value /ok cannot be decrypted without a secret key"}, problems)
+}
+
+func TestIsValue(t *testing.T) {
+	assert.True(t, IsValue(map[string]interface{}{EncryptedMember: "Y2lwaGVy"}))
+	assert.True(t, IsValue(map[string]interface{}{PlaintextMember: "secret"}))
+	assert.False(t, IsValue(map[string]interface{}{EncryptedMember: "Y2lwaGVy", "other": "value"}))
+	assert.False(t, IsValue(map[string]interface{}{"name": "value"}))
+	assert.False(t, IsValue("value"))
+}
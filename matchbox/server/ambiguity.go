@@ -0,0 +1,50 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// An AmbiguousGroupError is returned by puts of Groups which have the same
+// rank as other Groups and could match the same machines, so which of them
+// is selected would depend on their selector strings.
+type AmbiguousGroupError struct {
+	// Id of the Group to be written
+	Id string
+	// Groups are the sorted ids of the ambiguous Groups
+	Groups []string
+}
+
+func (e *AmbiguousGroupError) Error() string {
+	return fmt.Sprintf("matchbox: Group %q may match the same machines as Groups %s with the same rank, set a priority to order them", e.Id, strings.Join(e.Groups, ", "))
+}
+
+// checkAmbiguity returns an AmbiguousGroupError if a Group is ambiguous with
+// any other of the Store's Groups.
+func checkAmbiguity(store storage.Store, group *storagepb.Group) error {
+	groups, err := store.GroupList()
+	if err != nil {
+		return err
+	}
+	if ambiguous := ambiguousGroups(group, groups); len(ambiguous) > 0 {
+		return &AmbiguousGroupError{Id: group.Id, Groups: ambiguous}
+	}
+	return nil
+}
+
+// ambiguousGroups returns the sorted ids of the Groups, other than the Group
+// itself, which are ambiguous with the Group.
+func ambiguousGroups(group *storagepb.Group, groups []*storagepb.Group) []string {
+	var ambiguous []string
+	for _, other := range groups {
+		if other.Id != group.Id && group.Ambiguous(other) {
+			ambiguous = append(ambiguous, other.Id)
+		}
+	}
+	sort.Strings(ambiguous)
+	return ambiguous
+}
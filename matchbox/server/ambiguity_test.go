@@ -0,0 +1,58 @@
+package server
+
+import (
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestGroupPut_Ambiguous(t *testing.T) {
+	srv := NewServer(&Config{Store: newReferencedStore()})
+	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	assert.Nil(t, err)
+
+	// assert that:
+	// - a Group with the same rank which may match the same machines is rejected
+	// - a Group whose selectors cannot match the same machines is written
+	// - a Group with a different priority is written
+	// - rewriting a Group is not ambiguous with itself
+	ambiguous := &storagepb.Group{Id: "os", Profile: fake.Profile.Id, Selector: map[string]string{"os": "installed"}}
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: ambiguous})
+	assert.Equal(t, &AmbiguousGroupError{Id: "os", Groups: []string{fake.Group.Id}}, err)
+
+	disjoint := &storagepb.Group{Id: "other-uuid", Profile: fake.Profile.Id, Selector: map[string]string{"uuid": "e5f6"}}
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: disjoint})
+	assert.Nil(t, err)
+
+	ambiguous.Priority = 10
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: ambiguous})
+	assert.Nil(t, err)
+
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	assert.Nil(t, err)
+}
+
+func TestSelectGroup_Priority(t *testing.T) {
+	store := newReferencedStore()
+	specific := &storagepb.Group{
+		Id:       "specific",
+		Profile:  fake.Profile.Id,
+		Selector: map[string]string{"uuid": "a1b2c3d4", "os": "installed"},
+	}
+	preferred := &storagepb.Group{Id: "preferred", Profile: fake.Profile.Id, Priority: 1}
+	store.Groups[specific.Id] = specific
+	store.Groups[preferred.Id] = preferred
+	srv := NewServer(&Config{Store: store})
+
+	// assert that a Group with a higher priority is selected over Groups with
+	// more selectors
+	labels := map[string]string{"uuid": "a1b2c3d4", "os": "installed"}
+	group, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: labels})
+	assert.Nil(t, err)
+	assert.Equal(t, preferred, group)
+}
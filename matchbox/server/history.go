@@ -0,0 +1,179 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"context"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// History errors
+var (
+	ErrHistoryUnsupported = errors.New("matchbox: Store does not keep history")
+	ErrInvalidKind        = errors.New("matchbox: Invalid resource kind")
+	ErrNoRevision         = errors.New("matchbox: No matching Revision")
+)
+
+// historyKinds are the resource kinds whose writes are recorded.
+var historyKinds = map[string]bool{
+	"groups":   true,
+	"profiles": true,
+	"ignition": true,
+	"generic":  true,
+	"cloud":    true,
+	"partials": true,
+}
+
+// authorKey is the context key of the author of writes.
+type authorKey struct{}
+
+// WithAuthor returns a copy of the context which attributes writes to the
+// given author in the recorded history.
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// authorFromContext returns the author of writes made with the context.
+func authorFromContext(ctx context.Context) string {
+	author, _ := ctx.Value(authorKey{}).(string)
+	return author
+}
+
+// History returns the recorded Revisions of a resource, oldest first.
+func (s *server) History(ctx context.Context, req *pb.HistoryRequest) ([]*storagepb.Revision, error) {
+	if !historyKinds[req.Kind] {
+		return nil, ErrInvalidKind
+	}
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	historian, ok := ns.store.(storage.Historian)
+	if !ok {
+		return nil, ErrHistoryUnsupported
+	}
+	return historian.History(req.Kind, req.Name)
+}
+
+// Rollback writes the contents of a recorded Revision of a resource, by
+// default the one before the latest, and returns the new resource version.
+// The rollback itself is recorded as a new Revision.
+func (s *server) Rollback(ctx context.Context, req *pb.RollbackRequest) (int64, error) {
+	revisions, err := s.History(ctx, &pb.HistoryRequest{Kind: req.Kind, Name: req.Name, Namespace: req.Namespace})
+	if err != nil {
+		return 0, err
+	}
+	target := rollbackTarget(revisions, req.ResourceVersion)
+	if target == nil {
+		return 0, ErrNoRevision
+	}
+	switch req.Kind {
+	case "groups":
+		group, err := storagepb.ParseGroup(target.Content)
+		if err != nil {
+			return 0, err
+		}
+		group, err = s.GroupPut(ctx, &pb.GroupPutRequest{Group: group, Namespace: req.Namespace})
+		if err != nil {
+			return 0, err
+		}
+		return group.ResourceVersion, nil
+	case "profiles":
+		profile, err := storagepb.ParseProfile(target.Content)
+		if err != nil {
+			return 0, err
+		}
+		profile, err = s.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: profile, Namespace: req.Namespace})
+		if err != nil {
+			return 0, err
+		}
+		return profile.ResourceVersion, nil
+	case "ignition":
+		return s.IgnitionPut(ctx, &pb.IgnitionPutRequest{Name: req.Name, Config: target.Content, Namespace: req.Namespace})
+	case "cloud":
+		return s.CloudPut(ctx, &pb.CloudPutRequest{Name: req.Name, Config: target.Content, Namespace: req.Namespace})
+	case "partials":
+		return s.PartialPut(ctx, &pb.PartialPutRequest{Name: req.Name, Config: target.Content, Namespace: req.Namespace})
+	default:
+		return s.GenericPut(ctx, &pb.GenericPutRequest{Name: req.Name, Config: target.Content, Namespace: req.Namespace})
+	}
+}
+
+// rollbackTarget returns the latest Revision which wrote the given resource
+// version or, if the version is 0, the latest written Revision before the
+// last one. Deletions cannot be restored.
+func rollbackTarget(revisions []*storagepb.Revision, version int64) *storagepb.Revision {
+	last := len(revisions) - 1
+	if version == 0 {
+		last--
+	}
+	for i := last; i >= 0; i-- {
+		revision := revisions[i]
+		if revision.Deleted {
+			continue
+		}
+		if version == 0 || revision.ResourceVersion == version {
+			return revision
+		}
+	}
+	return nil
+}
+
+// write performs a put or delete of a resource with a namespace's Store and
+// records a Revision of the write, if the Store keeps a history. Recorders
+// append the Revision in the same transaction as the write. Other Historians
+// append it after the write, and a failure to do so is logged rather than
+// returned, since the write has happened. Deletions have no content.
+func (s *server) write(ctx context.Context, ns *namespace, kind, name string, content []byte, deleted bool, write func(store storage.Store) (int64, error)) (int64, error) {
+	historian, ok := ns.store.(storage.Historian)
+	if !ok {
+		return write(ns.store)
+	}
+	revision := &storagepb.Revision{
+		Kind:    kind,
+		Name:    name,
+		Time:    time.Now().Unix(),
+		Author:  authorFromContext(ctx),
+		Deleted: deleted,
+		Content: content,
+	}
+	if recorder, ok := historian.(storage.Recorder); ok {
+		return write(recorder.Recording(revision))
+	}
+	version, err := write(ns.store)
+	if err != nil {
+		return 0, err
+	}
+	if !deleted {
+		revision.ResourceVersion = version
+	}
+	if err := historian.HistoryAppend(revision); err != nil && s.logger != nil {
+		s.logger.Errorf("error recording the history of %s %s: %v", kind, name, err)
+	}
+	return version, nil
+}
+
+// groupContent returns the recorded content of a Group, in the format of a
+// group file.
+func groupContent(group *storagepb.Group) ([]byte, error) {
+	richGroup, err := group.ToRichGroup()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(richGroup)
+}
+
+// profileContent returns the recorded content of a Profile, in the format of
+// a profile file.
+func profileContent(profile *storagepb.Profile) ([]byte, error) {
+	richProfile, err := profile.ToRichProfile()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(richProfile)
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"context"
+	logtest "github.com/Sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestHistory(t *testing.T) {
+	srv := NewServer(&Config{Store: newReferencedStore()})
+	ctx := WithAuthor(context.Background(), "alice")
+	// assert that puts and deletes are recorded with their author
+	created, err := srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: fake.Profile})
+	assert.Nil(t, err)
+	err = srv.ProfileDelete(context.Background(), &pb.ProfileDeleteRequest{Id: fake.Profile.Id})
+	assert.Nil(t, err)
+
+	revisions, err := srv.History(context.Background(), &pb.HistoryRequest{Kind: "profiles", Name: fake.Profile.Id})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(revisions)) {
+		assert.Equal(t, created.ResourceVersion, revisions[0].ResourceVersion)
+		assert.Equal(t, "alice", revisions[0].Author)
+		assert.False(t, revisions[0].Deleted)
+		profile, err := storagepb.ParseProfile(revisions[0].Content)
+		assert.Nil(t, err)
+		assert.Equal(t, fake.Profile, profile)
+		assert.Equal(t, "", revisions[1].Author)
+		assert.True(t, revisions[1].Deleted)
+	}
+}
+
+func TestHistory_Recorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	store, err := storage.NewBoltStore(&storage.BoltConfig{Path: filepath.Join(dir, "matchbox.db")})
+	assert.Nil(t, err)
+	srv := NewServer(&Config{Store: store})
+	ctx := WithAuthor(context.Background(), "alice")
+	// assert that writes to a Recorder are recorded with their new version
+	version, err := srv.IgnitionPut(ctx, &pb.IgnitionPutRequest{Name: "a.ign", Config: []byte("{}")})
+	assert.Nil(t, err)
+	err = srv.IgnitionDelete(ctx, &pb.IgnitionDeleteRequest{Name: "a.ign"})
+	assert.Nil(t, err)
+	revisions, err := srv.History(ctx, &pb.HistoryRequest{Kind: "ignition", Name: "a.ign"})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(revisions)) {
+		assert.Equal(t, version, revisions[0].ResourceVersion)
+		assert.Equal(t, "alice", revisions[0].Author)
+		assert.Equal(t, []byte("{}"), revisions[0].Content)
+		assert.True(t, revisions[1].Deleted)
+	}
+}
+
+func TestHistory_AppendFails(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	store := newReferencedStore()
+	srv := NewServer(&Config{Store: unrecordedStore{store}, Logger: logger})
+	// assert that writes succeed if their Revision cannot be appended, which
+	// is logged
+	version, err := srv.IgnitionPut(context.Background(), &pb.IgnitionPutRequest{Name: "b.ign", Config: []byte("{}")})
+	assert.Nil(t, err)
+	assert.True(t, version > 0)
+	if assert.NotNil(t, hook.LastEntry()) {
+		assert.Equal(t, "error recording the history of ignition b.ign: history unavailable", hook.LastEntry().Message)
+	}
+	err = srv.IgnitionDelete(context.Background(), &pb.IgnitionDeleteRequest{Name: "b.ign"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(hook.Entries))
+	_, err = store.IgnitionGet("b.ign")
+	assert.Error(t, err)
+}
+
+// unrecordedStore is a Store whose Revisions cannot be appended.
+type unrecordedStore struct {
+	*fake.FixedStore
+}
+
+func (s unrecordedStore) HistoryAppend(revision *storagepb.Revision) error {
+	return errors.New("history unavailable")
+}
+
+func TestHistory_Errors(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	_, err := srv.History(context.Background(), &pb.HistoryRequest{Kind: "assets", Name: "vmlinuz"})
+	assert.Equal(t, ErrInvalidKind, err)
+	_, err = srv.Rollback(context.Background(), &pb.RollbackRequest{Kind: "ignition", Name: fake.IgnitionYAMLName})
+	assert.Equal(t, ErrNoRevision, err)
+
+	srv = NewServer(&Config{Store: &fake.EmptyStore{}})
+	_, err = srv.History(context.Background(), &pb.HistoryRequest{Kind: "groups", Name: fake.Group.Id})
+	assert.Equal(t, ErrHistoryUnsupported, err)
+}
+
+func TestRollback(t *testing.T) {
+	store := newReferencedStore()
+	srv := NewServer(&Config{Store: store})
+	put := func(config string) int64 {
+		req := &pb.IgnitionPutRequest{Name: fake.IgnitionYAMLName, Config: []byte(config)}
+		version, err := srv.IgnitionPut(context.Background(), req)
+		assert.Nil(t, err)
+		return version
+	}
+	v1 := put("one")
+	put("two")
+	put("three")
+
+	// assert that:
+	// - rollback defaults to the revision before the latest one
+	// - rollback restores the revision with a given resource version
+	// - rollbacks are recorded
+	_, err := srv.Rollback(context.Background(), &pb.RollbackRequest{Kind: "ignition", Name: fake.IgnitionYAMLName})
+	assert.Nil(t, err)
+	assert.Equal(t, "two", store.IgnitionConfigs[fake.IgnitionYAMLName])
+	_, err = srv.Rollback(context.Background(), &pb.RollbackRequest{Kind: "ignition", Name: fake.IgnitionYAMLName, ResourceVersion: v1})
+	assert.Nil(t, err)
+	assert.Equal(t, "one", store.IgnitionConfigs[fake.IgnitionYAMLName])
+	revisions, err := srv.History(context.Background(), &pb.HistoryRequest{Kind: "ignition", Name: fake.IgnitionYAMLName})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(revisions))
+
+	// assert that deleted Groups can be restored
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	assert.Nil(t, err)
+	err = srv.GroupDelete(context.Background(), &pb.GroupDeleteRequest{Id: fake.Group.Id})
+	assert.Nil(t, err)
+	_, err = srv.Rollback(context.Background(), &pb.RollbackRequest{Kind: "groups", Name: fake.Group.Id})
+	assert.Nil(t, err)
+	group, err := srv.GroupGet(context.Background(), &pb.GroupGetRequest{Id: fake.Group.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Selector, group.Selector)
+	assert.Equal(t, fake.Group.Profile, group.Profile)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// A ProfileCycleError is returned when Profiles inherit from each other in a
+// cycle, by puts of Profiles which would complete the cycle or by resolving
+// Profiles written otherwise.
+type ProfileCycleError struct {
+	// Profiles are the ids of the Profiles from the child to the Profile
+	// which is inherited twice
+	Profiles []string
+}
+
+func (e *ProfileCycleError) Error() string {
+	return fmt.Sprintf("matchbox: Profiles inherit from each other in a cycle %s", strings.Join(e.Profiles, " -> "))
+}
+
+// resolveProfile returns a Profile with the settings inherited from its
+// parents, or the Profile itself if it has no parent.
+func resolveProfile(store storage.Store, profile *storagepb.Profile) (*storagepb.Profile, error) {
+	chain, err := profileChain(store, profile)
+	if err != nil {
+		return nil, err
+	}
+	resolved := chain[len(chain)-1]
+	for i := len(chain) - 2; i >= 0; i-- {
+		if resolved, err = chain[i].Inherit(resolved); err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
+
+// checkProfileCycle returns a ProfileCycleError if a Profile to be written
+// would inherit from itself.
+func checkProfileCycle(store storage.Store, profile *storagepb.Profile) error {
+	_, err := profileChain(store, profile)
+	if cycle, ok := err.(*ProfileCycleError); ok {
+		return cycle
+	}
+	return nil
+}
+
+// profileChain returns a Profile followed by its parent, its parent's parent,
+// and so on.
+func profileChain(store storage.Store, profile *storagepb.Profile) ([]*storagepb.Profile, error) {
+	chain := []*storagepb.Profile{profile}
+	ids := []string{profile.Id}
+	seen := map[string]bool{profile.Id: true}
+	for current := profile; current.Parent != ""; {
+		ids = append(ids, current.Parent)
+		if seen[current.Parent] {
+			return nil, &ProfileCycleError{Profiles: ids}
+		}
+		seen[current.Parent] = true
+		parent, err := store.ProfileGet(current.Parent)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, parent)
+		current = parent
+	}
+	return chain, nil
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestProfileGet_Resolve(t *testing.T) {
+	store := newReferencedStore()
+	worker := &storagepb.Profile{
+		Id:     "worker",
+		Parent: fake.Profile.Id,
+		Boot:   &storagepb.NetBoot{Args: []string{"worker=true"}},
+	}
+	store.Profiles[worker.Id] = worker
+	store.Groups[fake.Group.Id] = &storagepb.Group{Id: fake.Group.Id, Profile: worker.Id, Selector: fake.Group.Selector}
+	srv := NewServer(&Config{Store: store})
+	ctx := context.Background()
+
+	// assert that Profiles are returned as stored unless resolved
+	profile, err := srv.ProfileGet(ctx, &pb.ProfileGetRequest{Id: worker.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, worker, profile)
+
+	expected := &storagepb.Profile{
+		Id:         worker.Id,
+		Parent:     fake.Profile.Id,
+		IgnitionId: fake.Profile.IgnitionId,
+		CloudId:    fake.Profile.CloudId,
+		GenericId:  fake.Profile.GenericId,
+		Boot: &storagepb.NetBoot{
+			Kernel: fake.Profile.Boot.Kernel,
+			Initrd: fake.Profile.Boot.Initrd,
+			Args:   append(append([]string{}, fake.Profile.Boot.Args...), "worker=true"),
+		},
+	}
+	profile, err = srv.ProfileGet(ctx, &pb.ProfileGetRequest{Id: worker.Id, Resolve: true})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, profile)
+
+	// assert that selected Profiles are resolved
+	profile, err = srv.SelectProfile(ctx, &pb.SelectProfileRequest{Labels: fake.Group.Selector})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, profile)
+}
+
+func TestProfilePut_Inheritance(t *testing.T) {
+	store := newReferencedStore()
+	srv := NewServer(&Config{Store: store})
+	ctx := context.Background()
+
+	// assert that:
+	// - a parent Profile must exist
+	// - a Profile cannot inherit from itself
+	// - a parent Profile with children is not deleted
+	child := &storagepb.Profile{Id: "child", Parent: "missing"}
+	_, err := srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: child})
+	assert.Equal(t, &MissingReferenceError{Kind: "profiles", Name: "missing"}, err)
+
+	child.Parent = fake.Profile.Id
+	_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: child})
+	assert.Nil(t, err)
+	grandchild := &storagepb.Profile{Id: "grandchild", Parent: child.Id}
+	_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: grandchild})
+	assert.Nil(t, err)
+
+	cyclic := &storagepb.Profile{Id: fake.Profile.Id, Parent: grandchild.Id}
+	_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: cyclic})
+	assert.Equal(t, &ProfileCycleError{Profiles: []string{fake.Profile.Id, grandchild.Id, child.Id, fake.Profile.Id}}, err)
+	assert.EqualError(t, err, "matchbox: Profiles inherit from each other in a cycle g1h2i3j4 -> grandchild -> child -> g1h2i3j4")
+
+	err = srv.ProfileDelete(ctx, &pb.ProfileDeleteRequest{Id: child.Id})
+	assert.Equal(t, &DependentsError{Kind: "profiles", Name: child.Id, Dependents: []string{"profiles/" + grandchild.Id}}, err)
+}
+
+func TestValidate_ProfileCycle(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Profiles["a"] = &storagepb.Profile{Id: "a", Parent: "b"}
+	store.Profiles["b"] = &storagepb.Profile{Id: "b", Parent: "a"}
+	store.Profiles["c"] = &storagepb.Profile{Id: "c", Parent: "missing"}
+	srv := NewServer(&Config{Store: store})
+
+	// assert that inheritance cycles and missing parents are reported, and
+	// resolving a Profile in a cycle fails
+	problems, err := srv.Validate(context.Background(), &pb.ValidateRequest{})
+	assert.Nil(t, err)
+	expected := []*storagepb.Problem{
+		{Kind: "profiles", Name: "a", Message: "inherits from itself through a -> b -> a"},
+		{Kind: "profiles", Name: "b", Message: "inherits from itself through b -> a -> b"},
+		{Kind: "profiles", Name: "c", Message: `Profile "missing" does not exist`},
+	}
+	assert.Equal(t, expected, problems)
+	_, err = srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: "a", Resolve: true})
+	assert.IsType(t, &ProfileCycleError{}, err)
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// defaultVersionInterval is the minimum interval between checks whether a
+// storage.GroupVersioner's Groups were changed by another writer.
+const defaultVersionInterval = time.Second
+
+// groupMatcher selects Groups using an in-memory index of a Store's Groups,
+// rather than listing and sorting the Groups for every selection. The index
+// is rebuilt after invalidate is called and, for Stores which implement
+// storage.GroupVersioner, when the Store's Group version changes.
+type groupMatcher struct {
+	store    storage.Store
+	interval time.Duration
+
+	mu      sync.RWMutex
+	index   *groupIndex
+	version string
+	checked time.Time
+}
+
+// groupIndex indexes Groups with a "mac" or "uuid" selector by its value, or
+// with an In match expression on "mac" or "uuid" by each of its values. Other
+// Groups are kept in a fallback list. Each list is in selection order,
+// the order used by SelectGroup before indexing.
+type groupIndex struct {
+	byMAC  map[string][]rankedGroup
+	byUUID map[string][]rankedGroup
+	others []rankedGroup
+}
+
+// rankedGroup is a Group and its position in selection order.
+type rankedGroup struct {
+	rank  int
+	group *storagepb.Group
+}
+
+// newGroupMatcher returns a new groupMatcher for the given Store.
+func newGroupMatcher(store storage.Store) *groupMatcher {
+	return &groupMatcher{
+		store:    store,
+		interval: defaultVersionInterval,
+	}
+}
+
+// match returns the first of the Store's Groups, sorted from most selectors
+// to least, which matches the given labels, or ErrNoMatchingGroup.
+func (m *groupMatcher) match(labels map[string]string) (*storagepb.Group, error) {
+	index, err := m.current()
+	if err != nil {
+		return nil, err
+	}
+	var best *rankedGroup
+	for _, list := range index.candidates(labels) {
+		for i := range list {
+			if best != nil && list[i].rank > best.rank {
+				break
+			}
+			if list[i].group.Matches(labels) {
+				best = &list[i]
+				break
+			}
+		}
+	}
+	if best == nil {
+		return nil, ErrNoMatchingGroup
+	}
+	return best.group, nil
+}
+
+// matchAll returns all of the Store's Groups which match the given labels,
+// in the order of match from most selectors to least, or ErrNoMatchingGroup.
+func (m *groupMatcher) matchAll(labels map[string]string) ([]*storagepb.Group, error) {
+	index, err := m.current()
+	if err != nil {
+		return nil, err
+	}
+	var matched []rankedGroup
+	for _, list := range index.candidates(labels) {
+		for _, rg := range list {
+			if rg.group.Matches(labels) {
+				matched = append(matched, rg)
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil, ErrNoMatchingGroup
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].rank < matched[j].rank
+	})
+	groups := make([]*storagepb.Group, len(matched))
+	for i, rg := range matched {
+		groups[i] = rg.group
+	}
+	return groups, nil
+}
+
+// invalidate discards the index so it is rebuilt on the next match. Call it
+// after writing Groups to the Store.
+func (m *groupMatcher) invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.index = nil
+}
+
+// current returns an up to date index, rebuilding it if needed.
+func (m *groupMatcher) current() (*groupIndex, error) {
+	m.mu.RLock()
+	index, checked := m.index, m.checked
+	m.mu.RUnlock()
+	versioner, versioned := m.store.(storage.GroupVersioner)
+	if index != nil && (!versioned || time.Since(checked) < m.interval) {
+		return index, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// another caller may have refreshed the index meanwhile
+	if m.index != nil && m.checked.After(checked) {
+		return m.index, nil
+	}
+	var version string
+	if versioned {
+		var err error
+		if version, err = versioner.GroupVersion(); err != nil {
+			m.index = nil
+			return nil, err
+		}
+		if m.index != nil && version == m.version {
+			m.checked = time.Now()
+			return m.index, nil
+		}
+	}
+	groups, err := m.store.GroupList()
+	if err != nil {
+		return nil, err
+	}
+	m.index = newGroupIndex(groups)
+	m.version = version
+	m.checked = time.Now()
+	return m.index, nil
+}
+
+// candidates returns the lists of Groups which may match the given labels.
+func (index *groupIndex) candidates(labels map[string]string) [][]rankedGroup {
+	candidates := [][]rankedGroup{index.others}
+	if mac, ok := labels["mac"]; ok {
+		candidates = append(candidates, index.byMAC[mac])
+	}
+	if uuid, ok := labels["uuid"]; ok {
+		candidates = append(candidates, index.byUUID[uuid])
+	}
+	return candidates
+}
+
+// newGroupIndex returns an index of the given Groups.
+func newGroupIndex(groups []*storagepb.Group) *groupIndex {
+	sorted := make([]*storagepb.Group, len(groups))
+	copy(sorted, groups)
+	sort.Sort(sort.Reverse(storagepb.ByReqs(sorted)))
+	index := &groupIndex{
+		byMAC:  make(map[string][]rankedGroup),
+		byUUID: make(map[string][]rankedGroup),
+	}
+	for rank, group := range sorted {
+		rg := rankedGroup{rank: rank, group: group}
+		if mac, ok := group.Selector["mac"]; ok {
+			index.byMAC[mac] = append(index.byMAC[mac], rg)
+		} else if uuid, ok := group.Selector["uuid"]; ok {
+			index.byUUID[uuid] = append(index.byUUID[uuid], rg)
+		} else if macs := inValues(group, "mac"); macs != nil {
+			for _, mac := range macs {
+				index.byMAC[mac] = append(index.byMAC[mac], rg)
+			}
+		} else if uuids := inValues(group, "uuid"); uuids != nil {
+			for _, uuid := range uuids {
+				index.byUUID[uuid] = append(index.byUUID[uuid], rg)
+			}
+		} else {
+			index.others = append(index.others, rg)
+		}
+	}
+	return index
+}
+
+// inValues returns the distinct values of a Group's first In match expression
+// on the given key, or nil if it has none.
+func inValues(group *storagepb.Group, key string) []string {
+	for _, expr := range group.MatchExpressions {
+		if expr.Key != key || !strings.EqualFold(expr.Operator, storagepb.OperatorIn) {
+			continue
+		}
+		seen := make(map[string]bool)
+		var values []string
+		for _, value := range expr.Values {
+			if !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	return nil
+}
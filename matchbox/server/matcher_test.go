@@ -0,0 +1,168 @@
+package server
+
+import (
+	"sort"
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestGroupMatcher(t *testing.T) {
+	groups := []*storagepb.Group{
+		{Id: "default", Profile: "p"},
+		{Id: "region", Profile: "p", Selector: map[string]string{"region": "us"}},
+		{Id: "uuid", Profile: "p", Selector: map[string]string{"uuid": "a1b2"}},
+		{Id: "uuid-os", Profile: "p", Selector: map[string]string{"uuid": "a1b2", "os": "installed"}},
+		{Id: "mac", Profile: "p", Selector: map[string]string{"mac": "52:54:00:89:d8:10"}},
+		{Id: "mac-uuid", Profile: "p", Selector: map[string]string{"mac": "52:54:00:89:d8:10", "uuid": "a1b2"}},
+		{Id: "mac-region-os", Profile: "p", Selector: map[string]string{"mac": "52:54:00:89:d8:10", "region": "us", "os": "installed"}},
+		{Id: "region-os-zone", Profile: "p", Selector: map[string]string{"region": "us", "os": "installed", "zone": "a"}},
+		{Id: "mac-in", Profile: "p", MatchExpressions: []*storagepb.MatchExpression{
+			{Key: "mac", Operator: storagepb.OperatorIn, Values: []string{"52:54:00:89:d8:10", "52:54:00:a1:9c:ae"}},
+		}},
+		{Id: "region-hostname", Profile: "p", Selector: map[string]string{"region": "us"}, MatchExpressions: []*storagepb.MatchExpression{
+			{Key: "hostname", Operator: storagepb.OperatorRegex, Values: []string{"^worker-"}},
+		}},
+	}
+	store := &fake.FixedStore{Groups: make(map[string]*storagepb.Group)}
+	for _, group := range groups {
+		store.Groups[group.Id] = group
+	}
+	cases := []map[string]string{
+		nil,
+		{"region": "us"},
+		{"uuid": "a1b2"},
+		{"uuid": "a1b2", "os": "installed"},
+		{"uuid": "c3d4", "os": "installed"},
+		{"mac": "52:54:00:89:d8:10"},
+		{"mac": "52:54:00:89:d8:10", "uuid": "a1b2"},
+		{"mac": "52:54:00:89:d8:10", "uuid": "a1b2", "region": "us", "os": "installed"},
+		{"mac": "52:54:00:89:d8:10", "region": "us", "os": "installed", "zone": "a"},
+		{"mac": "52:54:00:a1:9c:ae", "region": "us", "os": "installed", "zone": "a"},
+		{"mac": "52:54:00:a1:9c:ae"},
+		{"mac": "52:54:00:b2:2f:86", "region": "us", "hostname": "worker-1"},
+	}
+	// assert that the matcher selects what a sorted scan of all Groups selects
+	// and matches all the Groups which match in the scan, in order
+	matcher := newGroupMatcher(store)
+	for _, labels := range cases {
+		expected := scanGroups(groups, labels)
+		group, err := matcher.match(labels)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, group, "labels %v", labels)
+		all, err := matcher.matchAll(labels)
+		assert.Nil(t, err)
+		assert.Equal(t, scanAllGroups(groups, labels), all, "labels %v", labels)
+	}
+}
+
+func TestGroupMatcher_NoMatch(t *testing.T) {
+	store := &fake.FixedStore{
+		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+	}
+	matcher := newGroupMatcher(store)
+	_, err := matcher.match(map[string]string{"mac": "52:54:00:a1:9c:ae"})
+	assert.Equal(t, ErrNoMatchingGroup, err)
+}
+
+func TestSelectGroup_Invalidate(t *testing.T) {
+	store := &fake.FixedStore{
+		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	}
+	srv := NewServer(&Config{Store: store})
+	labels := map[string]string{"uuid": "a1b2c3d4", "mac": "52:54:00:a1:9c:ae"}
+	group, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: labels})
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group, group)
+
+	// assert that Groups written through the server are selected
+	specific := &storagepb.Group{
+		Id:       "specific",
+		Profile:  fake.Profile.Id,
+		Selector: map[string]string{"uuid": "a1b2c3d4", "mac": "52:54:00:a1:9c:ae"},
+	}
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: specific})
+	assert.Nil(t, err)
+	group, err = srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: labels})
+	assert.Nil(t, err)
+	assert.Equal(t, specific, group)
+
+	err = srv.GroupDelete(context.Background(), &pb.GroupDeleteRequest{Id: specific.Id})
+	assert.Nil(t, err)
+	group, err = srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: labels})
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group, group)
+}
+
+func TestGroupMatcher_Version(t *testing.T) {
+	store := &versionedStore{
+		FixedStore: &fake.FixedStore{
+			Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		},
+		version: "1",
+	}
+	matcher := newGroupMatcher(store)
+	matcher.interval = 0
+	labels := map[string]string{"uuid": "a1b2c3d4"}
+	_, err := matcher.match(labels)
+	assert.Nil(t, err)
+	_, err = matcher.match(labels)
+	assert.Nil(t, err)
+	// assert that Groups are only listed when the version changes
+	assert.Equal(t, 1, store.lists)
+
+	delete(store.Groups, fake.Group.Id)
+	store.version = "2"
+	_, err = matcher.match(labels)
+	assert.Equal(t, ErrNoMatchingGroup, err)
+	assert.Equal(t, 2, store.lists)
+}
+
+// versionedStore is a FixedStore which implements storage.GroupVersioner
+// and counts GroupList calls.
+type versionedStore struct {
+	*fake.FixedStore
+	version string
+	lists   int
+}
+
+func (s *versionedStore) GroupVersion() (string, error) {
+	return s.version, nil
+}
+
+func (s *versionedStore) GroupList() ([]*storagepb.Group, error) {
+	s.lists++
+	return s.FixedStore.GroupList()
+}
+
+// scanGroups selects a Group by scanning all Groups in sorted order.
+func scanAllGroups(groups []*storagepb.Group, labels map[string]string) []*storagepb.Group {
+	sorted := make([]*storagepb.Group, len(groups))
+	copy(sorted, groups)
+	sort.Sort(sort.Reverse(storagepb.ByReqs(sorted)))
+	var matched []*storagepb.Group
+	for _, group := range sorted {
+		if group.Matches(labels) {
+			matched = append(matched, group)
+		}
+	}
+	return matched
+}
+
+func scanGroups(groups []*storagepb.Group, labels map[string]string) *storagepb.Group {
+	sorted := make([]*storagepb.Group, len(groups))
+	copy(sorted, groups)
+	sort.Sort(sort.Reverse(storagepb.ByReqs(sorted)))
+	for _, group := range sorted {
+		if group.Matches(labels) {
+			return group
+		}
+	}
+	return nil
+}
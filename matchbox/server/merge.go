@@ -0,0 +1,28 @@
+package server
+
+import (
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// mergeGroups returns a copy of the first of the matching Groups, which are
+// ordered from most specific to least, with the metadata of all the Groups
+// merged into its metadata.
+func mergeGroups(groups []*storagepb.Group) (*storagepb.Group, error) {
+	metadata, err := mergeMetadata(groups)
+	if err != nil {
+		return nil, err
+	}
+	merged := *groups[0]
+	merged.Metadata = metadata
+	return &merged, nil
+}
+
+// mergeMetadata returns the metadata of Groups deep merged from the last
+// (least specific) Group to the first (most specific).
+func mergeMetadata(groups []*storagepb.Group) ([]byte, error) {
+	metadata := make([][]byte, len(groups))
+	for i, group := range groups {
+		metadata[len(groups)-1-i] = group.Metadata
+	}
+	return storagepb.MergeMetadata(metadata...)
+}
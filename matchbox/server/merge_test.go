@@ -0,0 +1,71 @@
+package server
+
+import (
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestMergeMetadata(t *testing.T) {
+	groups := []*storagepb.Group{
+		{Id: "node", Metadata: []byte(`{"hostname":"node1","etcd":{"name":"node1"},"token":"plain"}`)},
+		{Id: "none"},
+		{Id: "cluster", Metadata: []byte(`{"domain":"example.com","etcd":{"name":"default","peers":["a","b"]},"token":{"$encrypted":"Y2lwaGVy"}}`)},
+	}
+	// assert that:
+	// - objects are merged member by member
+	// - values of more specific Groups replace values of less specific Groups
+	// - secret values are replaced rather than merged
+	metadata, err := mergeMetadata(groups)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"hostname":"node1","domain":"example.com","etcd":{"name":"node1","peers":["a","b"]},"token":"plain"}`, string(metadata))
+
+	metadata, err = mergeMetadata([]*storagepb.Group{{Id: "none"}})
+	assert.Nil(t, err)
+	assert.Nil(t, metadata)
+}
+
+func TestSelectGroup_MergeMetadata(t *testing.T) {
+	store := newReferencedStore()
+	cluster := &storagepb.Group{
+		Id:       "cluster",
+		Profile:  "cluster-profile",
+		Metadata: []byte(`{"domain":"example.com","ntp":{"servers":["a"]}}`),
+	}
+	node := &storagepb.Group{
+		Id:       "node",
+		Profile:  fake.Profile.Id,
+		Selector: map[string]string{"mac": "52:54:00:a1:9c:ae"},
+		Metadata: []byte(`{"hostname":"node1"}`),
+	}
+	store.Groups[cluster.Id] = cluster
+	store.Groups[node.Id] = node
+	labels := map[string]string{"mac": "52:54:00:a1:9c:ae"}
+
+	// assert that without merging, only the selected Group's metadata is used
+	srv := NewServer(&Config{Store: store})
+	group, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: labels})
+	assert.Nil(t, err)
+	assert.Equal(t, node, group)
+
+	// assert that with merging, the most specific Group chooses the Profile
+	// and the metadata of all matching Groups is merged
+	srv = NewServer(&Config{Store: store, MergeMetadata: true})
+	group, err = srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: labels})
+	assert.Nil(t, err)
+	assert.Equal(t, node.Id, group.Id)
+	assert.Equal(t, fake.Profile.Id, group.Profile)
+	assert.JSONEq(t, `{"domain":"example.com","ntp":{"servers":["a"]},"hostname":"node1"}`, string(group.Metadata))
+	assert.JSONEq(t, `{"hostname":"node1"}`, string(node.Metadata))
+
+	// assert that merging still fails without matching Groups
+	store.Groups = map[string]*storagepb.Group{}
+	srv = NewServer(&Config{Store: store, MergeMetadata: true})
+	_, err = srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: labels})
+	assert.Equal(t, ErrNoMatchingGroup, err)
+}
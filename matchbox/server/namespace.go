@@ -0,0 +1,163 @@
+package server
+
+import (
+	"errors"
+
+	"context"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// DefaultNamespace names the default namespace, which is also selected by
+// the empty namespace name.
+const DefaultNamespace = "default"
+
+// Namespace errors
+var (
+	ErrNamespacesUnsupported = errors.New("matchbox: Store does not support namespaces")
+	ErrInvalidNamespace      = storage.ErrInvalidNamespace
+	ErrNamespaceNotFound     = errors.New("matchbox: Namespace not found")
+)
+
+// namespaceKey is the context key of the namespace of requests.
+type namespaceKey struct{}
+
+// WithNamespace returns a copy of the context which scopes requests without
+// a namespace to the given namespace.
+func WithNamespace(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, name)
+}
+
+// namespaceFromContext returns the namespace of requests made with the
+// context.
+func namespaceFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(namespaceKey{}).(string)
+	return name
+}
+
+// namespace is the Store and Group matcher of a namespace. The name of the
+// default namespace is empty.
+type namespace struct {
+	name    string
+	store   storage.Store
+	matcher *groupMatcher
+}
+
+// newNamespace returns a new namespace for the given Store.
+func newNamespace(name string, store storage.Store) *namespace {
+	return &namespace{
+		name:    name,
+		store:   store,
+		matcher: newGroupMatcher(store),
+	}
+}
+
+// namespace returns the namespace of a read request, named by the request or
+// else by the context, or ErrNamespaceNotFound if the namespace has not been
+// written to, so reads never create namespaces.
+func (s *server) namespace(ctx context.Context, name string) (*namespace, error) {
+	return s.lookupNamespace(ctx, name, false)
+}
+
+// writeNamespace returns the namespace of a write request, named by the
+// request or else by the context, which the write may create.
+func (s *server) writeNamespace(ctx context.Context, name string) (*namespace, error) {
+	return s.lookupNamespace(ctx, name, true)
+}
+
+// lookupNamespace returns a namespace by name. Namespaces which exist in the
+// Store are reused once looked up, so their Group matchers and watchers are
+// shared. Namespaces which do not exist yet are not kept, so requests naming
+// many namespaces cannot grow the server, and are only returned for writes.
+func (s *server) lookupNamespace(ctx context.Context, name string, create bool) (*namespace, error) {
+	if name == "" {
+		name = namespaceFromContext(ctx)
+	}
+	if name == "" || name == DefaultNamespace {
+		return s.root, nil
+	}
+	if !storage.ValidNamespace(name) {
+		return nil, ErrInvalidNamespace
+	}
+	namespacer, ok := s.root.store.(storage.Namespacer)
+	if !ok {
+		return nil, ErrNamespacesUnsupported
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ns, ok := s.namespaces[name]; ok {
+		return ns, nil
+	}
+	exists, err := namespacer.HasNamespace(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists && !create {
+		return nil, ErrNamespaceNotFound
+	}
+	store, err := namespacer.Namespace(name)
+	if err != nil {
+		return nil, err
+	}
+	ns := newNamespace(name, store)
+	if exists {
+		s.namespaces[name] = ns
+	}
+	return ns, nil
+}
+
+// group returns the Group with the namespace set, copying it if needed.
+func (ns *namespace) group(group *storagepb.Group) *storagepb.Group {
+	if ns.name == "" || group == nil {
+		return group
+	}
+	namespaced := *group
+	namespaced.Namespace = ns.name
+	return &namespaced
+}
+
+// profile returns the Profile with the namespace set, copying it if needed.
+func (ns *namespace) profile(profile *storagepb.Profile) *storagepb.Profile {
+	if ns.name == "" || profile == nil {
+		return profile
+	}
+	namespaced := *profile
+	namespaced.Namespace = ns.name
+	return &namespaced
+}
+
+// event returns the Event and its resource with the namespace set, copying
+// them if needed.
+func (ns *namespace) event(event *storagepb.Event) *storagepb.Event {
+	if ns.name == "" {
+		return event
+	}
+	namespaced := *event
+	namespaced.Namespace = ns.name
+	namespaced.Group = ns.group(event.Group)
+	namespaced.Profile = ns.profile(event.Profile)
+	return &namespaced
+}
+
+// unnamespacedGroup returns the Group without its namespace, which is not
+// stored, copying it if needed.
+func unnamespacedGroup(group *storagepb.Group) *storagepb.Group {
+	if group.Namespace == "" {
+		return group
+	}
+	unnamespaced := *group
+	unnamespaced.Namespace = ""
+	return &unnamespaced
+}
+
+// unnamespacedProfile returns the Profile without its namespace, which is
+// not stored, copying it if needed.
+func unnamespacedProfile(profile *storagepb.Profile) *storagepb.Profile {
+	if profile.Namespace == "" {
+		return profile
+	}
+	unnamespaced := *profile
+	unnamespaced.Namespace = ""
+	return &unnamespaced
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestNamespaces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	srv := NewServer(&Config{Store: storage.NewFileStore(&storage.Config{Root: dir})})
+	ctx := context.Background()
+
+	// assert that:
+	// - resources are written to the request's namespace, not the resource's
+	// - returned resources have the namespace they were read from
+	profile := &storagepb.Profile{Id: fake.Profile.Id}
+	_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: profile, Namespace: "team-a"})
+	assert.Nil(t, err)
+	group := &storagepb.Group{Id: fake.Group.Id, Profile: fake.Profile.Id, Selector: fake.Group.Selector, Namespace: "other"}
+	created, err := srv.GroupPut(ctx, &pb.GroupPutRequest{Group: group, Namespace: "team-a"})
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a", created.Namespace)
+
+	_, err = srv.GroupGet(ctx, &pb.GroupGetRequest{Id: fake.Group.Id})
+	assert.True(t, os.IsNotExist(err))
+	_, err = srv.GroupGet(ctx, &pb.GroupGetRequest{Id: fake.Group.Id, Namespace: "other"})
+	assert.Equal(t, ErrNamespaceNotFound, err)
+	got, err := srv.GroupGet(ctx, &pb.GroupGetRequest{Id: fake.Group.Id, Namespace: "team-a"})
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a", got.Namespace)
+
+	// assert that the context namespace applies to requests without one
+	teamA := WithNamespace(ctx, "team-a")
+	groups, err := srv.GroupList(teamA, &pb.GroupListRequest{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(groups)) {
+		assert.Equal(t, "team-a", groups[0].Namespace)
+	}
+	groups, err = srv.GroupList(teamA, &pb.GroupListRequest{Namespace: DefaultNamespace})
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+
+	// assert that selection is scoped to the namespace
+	selected, err := srv.SelectProfile(teamA, &pb.SelectProfileRequest{Labels: map[string]string{"uuid": "a1b2c3d4"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a", selected.Namespace)
+	_, err = srv.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: map[string]string{"uuid": "a1b2c3d4"}})
+	assert.Equal(t, ErrNoMatchingGroup, err)
+
+	// assert that history is scoped to the namespace
+	revisions, err := srv.History(teamA, &pb.HistoryRequest{Kind: "groups", Name: fake.Group.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(revisions))
+	revisions, err = srv.History(ctx, &pb.HistoryRequest{Kind: "groups", Name: fake.Group.Id})
+	assert.Nil(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestNamespaceWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	srv := NewServer(&Config{Store: storage.NewFileStore(&storage.Config{Root: dir})})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// assert that watchers only receive Events of their namespace
+	profile := &storagepb.Profile{Id: fake.Profile.Id}
+	for _, namespace := range []string{"", "team-a"} {
+		_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: profile, Namespace: namespace})
+		assert.Nil(t, err)
+	}
+	events, err := srv.GroupWatch(ctx, &pb.GroupWatchRequest{Namespace: "team-a"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = srv.GroupPut(ctx, &pb.GroupPutRequest{Group: fake.Group})
+	assert.Nil(t, err)
+	_, err = srv.GroupPut(ctx, &pb.GroupPutRequest{Group: fake.Group, Namespace: "team-a"})
+	assert.Nil(t, err)
+	event := <-events
+	assert.Equal(t, fake.Group.Id, event.Name)
+	assert.Equal(t, "team-a", event.Namespace)
+	assert.Equal(t, "team-a", event.Group.Namespace)
+}
+
+func TestNamespace_NotFound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	srv := NewServer(&Config{Store: storage.NewFileStore(&storage.Config{Root: dir})}).(*server)
+	ctx := WithNamespace(context.Background(), "team-a")
+
+	// assert that reads of a namespace which was never written to fail
+	// without creating or keeping the namespace
+	_, err = srv.GroupList(ctx, &pb.GroupListRequest{})
+	assert.Equal(t, ErrNamespaceNotFound, err)
+	_, err = srv.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: map[string]string{"uuid": "a1b2c3d4"}})
+	assert.Equal(t, ErrNamespaceNotFound, err)
+	_, err = srv.GroupWatch(ctx, &pb.GroupWatchRequest{})
+	assert.Equal(t, ErrNamespaceNotFound, err)
+	_, err = os.Stat(dir + "/namespaces")
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, srv.namespaces)
+
+	// assert that writes create the namespace, which reads then find
+	_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: &storagepb.Profile{Id: fake.Profile.Id}})
+	assert.Nil(t, err)
+	profiles, err := srv.ProfileList(ctx, &pb.ProfileListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(profiles))
+	assert.Equal(t, 1, len(srv.namespaces))
+}
+
+func TestNamespace_Errors(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	ctx := context.Background()
+	// assert that the default namespace is supported by all Stores
+	_, err := srv.GroupList(ctx, &pb.GroupListRequest{Namespace: DefaultNamespace})
+	assert.Nil(t, err)
+	_, err = srv.GroupList(ctx, &pb.GroupListRequest{Namespace: "team-a"})
+	assert.Equal(t, ErrNamespacesUnsupported, err)
+	_, err = srv.SelectProfile(ctx, &pb.SelectProfileRequest{Namespace: "team-a"})
+	assert.Equal(t, ErrNamespacesUnsupported, err)
+	_, err = srv.IgnitionGet(WithNamespace(ctx, "Team A"), &pb.IgnitionGetRequest{Name: fake.IgnitionYAMLName})
+	assert.Equal(t, ErrInvalidNamespace, err)
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"sort"
+	"text/template/parse"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+)
+
+// templateKinds are the kinds of templates which may include partial
+// templates.
+var templateKinds = []string{"ignition", "generic", "cloud", "partials"}
+
+// parseTemplate returns the parse trees of a template and the templates it
+// defines, by name. Functions are not checked, since the template function
+// library belongs to the renderer.
+func parseTemplate(name, contents string) (map[string]*parse.Tree, error) {
+	trees := make(map[string]*parse.Tree)
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+	_, err := tree.Parse(contents, "", "", trees)
+	return trees, err
+}
+
+// TemplateIncludes returns the sorted names of the templates which a
+// template includes with template actions, other than those it defines
+// itself. Its own name counts as an include, since a partial template of
+// that name may exist. Templates which do not parse include nothing, since
+// rendering reports their errors.
+func TemplateIncludes(name, contents string) []string {
+	trees, err := parseTemplate(name, contents)
+	if err != nil {
+		return nil
+	}
+	set := make(map[string]bool)
+	for _, tree := range trees {
+		walkTemplateNodes(tree.Root, func(node *parse.TemplateNode) {
+			if node.Name == name || trees[node.Name] == nil {
+				set[node.Name] = true
+			}
+		})
+	}
+	var includes []string
+	for include := range set {
+		includes = append(includes, include)
+	}
+	sort.Strings(includes)
+	return includes
+}
+
+// walkTemplateNodes calls fn for each template action below a node.
+func walkTemplateNodes(node parse.Node, fn func(*parse.TemplateNode)) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, child := range node.Nodes {
+			walkTemplateNodes(child, fn)
+		}
+	case *parse.IfNode:
+		walkTemplateNodes(node.List, fn)
+		walkTemplateNodes(node.ElseList, fn)
+	case *parse.RangeNode:
+		walkTemplateNodes(node.List, fn)
+		walkTemplateNodes(node.ElseList, fn)
+	case *parse.WithNode:
+		walkTemplateNodes(node.List, fn)
+		walkTemplateNodes(node.ElseList, fn)
+	case *parse.TemplateNode:
+		fn(node)
+	}
+}
+
+// partialNames returns the names which templates may include: the names of
+// the partial templates and of the templates they define. Each name maps to
+// the partial template which provides it.
+func partialNames(store storage.Store) (map[string]string, error) {
+	names, err := store.PartialList()
+	if err != nil {
+		return nil, err
+	}
+	provided := make(map[string]string, len(names))
+	for _, name := range names {
+		provided[name] = name
+	}
+	for _, name := range names {
+		contents, err := store.PartialGet(name)
+		if err != nil {
+			return nil, err
+		}
+		trees, _ := parseTemplate(name, contents)
+		for defined := range trees {
+			if _, ok := provided[defined]; !ok {
+				provided[defined] = name
+			}
+		}
+	}
+	return provided, nil
+}
+
+// checkIncludes returns a MissingReferenceError if a template of a kind
+// includes a template which no partial template provides. Partial templates
+// may include themselves.
+func checkIncludes(store storage.Store, kind, name string, contents []byte) error {
+	includes := TemplateIncludes(name, string(contents))
+	if len(includes) == 0 {
+		return nil
+	}
+	provided, err := partialNames(store)
+	if err != nil {
+		return err
+	}
+	for _, include := range includes {
+		if _, ok := provided[include]; !ok && !(kind == "partials" && include == name) {
+			return &MissingReferenceError{Kind: "partials", Name: include}
+		}
+	}
+	return nil
+}
+
+// includeDependents returns the sorted "kind/name" of the templates which
+// include a partial template or a template it defines.
+func includeDependents(store storage.Store, name string) ([]string, error) {
+	provided, err := partialNames(store)
+	if err != nil {
+		return nil, err
+	}
+	var dependents []string
+	for _, kind := range templateKinds {
+		names, err := templateList(store, kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, dependent := range names {
+			if kind == "partials" && dependent == name {
+				continue
+			}
+			contents, err := templateGet(store, kind, dependent)
+			if err != nil {
+				return nil, err
+			}
+			for _, include := range TemplateIncludes(dependent, contents) {
+				if provided[include] == name {
+					dependents = append(dependents, kind+"/"+dependent)
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents, nil
+}
+
+// templateList returns the names of the templates of a kind.
+func templateList(store storage.Store, kind string) ([]string, error) {
+	switch kind {
+	case "ignition":
+		return store.IgnitionList()
+	case "generic":
+		return store.GenericList()
+	case "cloud":
+		return store.CloudList()
+	case "partials":
+		return store.PartialList()
+	}
+	return nil, ErrInvalidKind
+}
+
+// templateGet returns a template of a kind by name.
+func templateGet(store storage.Store, kind, name string) (string, error) {
+	switch kind {
+	case "ignition":
+		return store.IgnitionGet(name)
+	case "generic":
+		return store.GenericGet(name)
+	case "cloud":
+		return store.CloudGet(name)
+	case "partials":
+		return store.PartialGet(name)
+	}
+	return "", ErrInvalidKind
+}
+
+// templateVersion returns the resource version of a template of a kind by
+// name.
+func templateVersion(store storage.Store, kind, name string) (int64, error) {
+	switch kind {
+	case "ignition":
+		return store.IgnitionVersion(name)
+	case "generic":
+		return store.GenericVersion(name)
+	case "cloud":
+		return store.CloudVersion(name)
+	case "partials":
+		return store.PartialVersion(name)
+	}
+	return 0, ErrInvalidKind
+}
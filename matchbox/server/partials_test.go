@@ -0,0 +1,90 @@
+package server
+
+import (
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestTemplateIncludes(t *testing.T) {
+	cases := []struct {
+		contents string
+		includes []string
+	}{
+		{`storage: {}`, nil},
+		{`{{template "users.yaml" .}}{{template "proxy" .}}{{template "users.yaml" .}}`, []string{"proxy", "users.yaml"}},
+		{`{{if .ssh}}{{range .keys}}{{with .}}{{template "key" .}}{{end}}{{end}}{{else}}{{template "nokey"}}{{end}}`, []string{"key", "nokey"}},
+		{`{{define "local"}}a{{end}}{{template "local" .}}{{template "remote" .}}`, []string{"remote"}},
+		{`{{template "self.yaml" .}}`, []string{"self.yaml"}},
+		// functions are not checked and templates which do not parse
+		// include nothing
+		{`{{b64enc .a}}{{template "x" .}}`, []string{"x"}},
+		{`{{template "x" .`, nil},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.includes, TemplateIncludes("self.yaml", c.contents), c.contents)
+	}
+}
+
+func TestPut_MissingPartials(t *testing.T) {
+	store := fake.NewFixedStore()
+	srv := NewServer(&Config{Store: store})
+	ctx := context.Background()
+
+	// assert that templates must include existing partial templates
+	_, err := srv.IgnitionPut(ctx, &pb.IgnitionPutRequest{Name: "a.yaml", Config: []byte(`{{template "users.yaml" .}}`)})
+	assert.Equal(t, &MissingReferenceError{Kind: "partials", Name: "users.yaml"}, err)
+	assert.EqualError(t, err, `matchbox: Partial template "users.yaml" does not exist`)
+	_, err = srv.PartialPut(ctx, &pb.PartialPutRequest{Name: "users.yaml", Config: []byte(`{{template "keys" .}}`)})
+	assert.Equal(t, &MissingReferenceError{Kind: "partials", Name: "keys"}, err)
+	assert.Empty(t, store.IgnitionConfigs)
+	assert.Empty(t, store.Partials)
+
+	// assert that templates may include partial templates, the templates they
+	// define, and partial templates themselves
+	_, err = srv.PartialPut(ctx, &pb.PartialPutRequest{Name: "keys.yaml", Config: []byte(`{{define "keys"}}- {{.}}{{end}}`)})
+	assert.Nil(t, err)
+	_, err = srv.PartialPut(ctx, &pb.PartialPutRequest{Name: "users.yaml", Config: []byte(`{{template "keys" .}}`)})
+	assert.Nil(t, err)
+	_, err = srv.IgnitionPut(ctx, &pb.IgnitionPutRequest{Name: "a.yaml", Config: []byte(`{{template "users.yaml" .}}`)})
+	assert.Nil(t, err)
+	_, err = srv.GenericPut(ctx, &pb.GenericPutRequest{Name: "b.tmpl", Config: []byte(`{{template "keys" .}}`)})
+	assert.Nil(t, err)
+	_, err = srv.CloudPut(ctx, &pb.CloudPutRequest{Name: "c.yaml", Config: []byte(`{{template "missing" .}}`)})
+	assert.Equal(t, &MissingReferenceError{Kind: "partials", Name: "missing"}, err)
+	_, err = srv.PartialPut(ctx, &pb.PartialPutRequest{Name: "tree.yaml", Config: []byte(`{{if .children}}{{template "tree.yaml" .children}}{{end}}`)})
+	assert.Nil(t, err)
+}
+
+func TestPartialDelete_Dependents(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Partials["keys.yaml"] = `{{define "keys"}}- {{.}}{{end}}`
+	store.Partials["users.yaml"] = `{{template "keys" .}}`
+	store.IgnitionConfigs["a.yaml"] = `{{template "users.yaml" .}}`
+	srv := NewServer(&Config{Store: store})
+	ctx := context.Background()
+
+	// assert that included partial templates are not deleted and the
+	// templates which include them are listed
+	err := srv.PartialDelete(ctx, &pb.PartialDeleteRequest{Name: "keys.yaml"})
+	assert.Equal(t, &DependentsError{Kind: "partials", Name: "keys.yaml", Dependents: []string{"partials/users.yaml"}}, err)
+	err = srv.PartialDelete(ctx, &pb.PartialDeleteRequest{Name: "users.yaml"})
+	assert.Equal(t, &DependentsError{Kind: "partials", Name: "users.yaml", Dependents: []string{"ignition/a.yaml"}}, err)
+	assert.Equal(t, 2, len(store.Partials))
+
+	// assert that forced deletes leave includes dangling
+	err = srv.PartialDelete(ctx, &pb.PartialDeleteRequest{Name: "users.yaml", Force: true})
+	assert.Nil(t, err)
+	names, err := srv.PartialList(ctx, &pb.PartialListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"keys.yaml"}, names)
+	problems, err := srv.Validate(ctx, &pb.ValidateRequest{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(problems)) {
+		assert.Equal(t, problem("ignition", "a.yaml", `includes partial template "users.yaml" which does not exist`), problems[0])
+	}
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// kindDescriptions describe the kinds of resources which other resources
+// refer to.
+var kindDescriptions = map[string]string{
+	"profiles": "Profile",
+	"ignition": "Ignition template",
+	"generic":  "Generic template",
+	"cloud":    "Cloud-Config template",
+	"partials": "Partial template",
+}
+
+// A MissingReferenceError is returned by puts of Groups and Profiles which
+// refer to a Profile or template which does not exist, and by puts of
+// templates which include a partial template which does not exist.
+type MissingReferenceError struct {
+	// Kind and Name of the missing resource
+	Kind string
+	Name string
+}
+
+func (e *MissingReferenceError) Error() string {
+	return fmt.Sprintf("matchbox: %s %q does not exist", kindDescriptions[e.Kind], e.Name)
+}
+
+// A DependentsError is returned by deletes of Profiles and templates which
+// other resources refer to, unless the delete is forced.
+type DependentsError struct {
+	// Kind and Name of the resource to be deleted
+	Kind string
+	Name string
+	// Dependents are the sorted "kind/name" of the referring resources
+	Dependents []string
+}
+
+func (e *DependentsError) Error() string {
+	return fmt.Sprintf("matchbox: %s %q is referenced by %s, delete them first or force the delete", kindDescriptions[e.Kind], e.Name, strings.Join(e.Dependents, ", "))
+}
+
+// reference is a reference to a Profile or template by kind and name.
+type reference struct {
+	kind string
+	name string
+}
+
+// profileReferences returns the references of a Profile to its parent
+// Profile and to templates.
+func profileReferences(profile *storagepb.Profile) []reference {
+	candidates := []reference{
+		{"profiles", profile.Parent},
+		{"ignition", profile.IgnitionId},
+		{"generic", profile.GenericId},
+		{"cloud", profile.CloudId},
+	}
+	for _, id := range profile.IgnitionIds {
+		candidates = append(candidates, reference{"ignition", id})
+	}
+	var refs []reference
+	for _, ref := range candidates {
+		if ref.name != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// checkGroupReferences returns a MissingReferenceError if a Group refers to
+// a Profile which does not exist.
+func checkGroupReferences(store storage.Store, group *storagepb.Group) error {
+	if group.Profile == "" {
+		return nil
+	}
+	return checkReferences(store, []reference{{"profiles", group.Profile}})
+}
+
+// checkProfileReferences returns a MissingReferenceError if a Profile refers
+// to a parent Profile or template which does not exist.
+func checkProfileReferences(store storage.Store, profile *storagepb.Profile) error {
+	return checkReferences(store, profileReferences(profile))
+}
+
+// checkReferences returns a MissingReferenceError for the first reference to
+// a resource which does not exist. Each referenced resource is looked up by
+// name, so checks do not depend on the number of stored resources.
+func checkReferences(store storage.Store, refs []reference) error {
+	for _, ref := range refs {
+		var err error
+		switch ref.kind {
+		case "profiles":
+			_, err = store.ProfileGet(ref.name)
+		default:
+			_, err = templateVersion(store, ref.kind, ref.name)
+		}
+		if os.IsNotExist(err) {
+			return &MissingReferenceError{Kind: ref.kind, Name: ref.name}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDelete returns a DependentsError if Groups or child Profiles refer to
+// a Profile, Profiles refer to a template, or templates include a partial
+// template, which is to be deleted, unless the delete is forced.
+func checkDelete(store storage.Store, kind, name string, force bool) error {
+	if force {
+		return nil
+	}
+	var dependents []string
+	if kind == "partials" {
+		dependents, err := includeDependents(store, name)
+		if err != nil || len(dependents) == 0 {
+			return err
+		}
+		return &DependentsError{Kind: kind, Name: name, Dependents: dependents}
+	}
+	if kind == "profiles" {
+		groups, err := store.GroupList()
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			if group.Profile == name {
+				dependents = append(dependents, "groups/"+group.Id)
+			}
+		}
+	}
+	profiles, err := store.ProfileList()
+	if err != nil {
+		return err
+	}
+	for _, profile := range profiles {
+		for _, ref := range profileReferences(profile) {
+			if ref == (reference{kind, name}) {
+				dependents = append(dependents, "profiles/"+profile.Id)
+			}
+		}
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+	sort.Strings(dependents)
+	return &DependentsError{Kind: kind, Name: name, Dependents: dependents}
+}
+
+// resourceNames returns the set of names of the Profiles or templates of a
+// kind.
+func resourceNames(store storage.Store, kind string) (map[string]bool, error) {
+	var names []string
+	var err error
+	switch kind {
+	case "profiles":
+		var profiles []*storagepb.Profile
+		profiles, err = store.ProfileList()
+		for _, profile := range profiles {
+			names = append(names, profile.Id)
+		}
+	default:
+		names, err = templateList(store, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set, nil
+}
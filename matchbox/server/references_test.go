@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestPut_MissingReferences(t *testing.T) {
+	store := fake.NewFixedStore()
+	srv := NewServer(&Config{Store: store})
+
+	// assert that Groups and Profiles must refer to existing resources
+	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	assert.Equal(t, &MissingReferenceError{Kind: "profiles", Name: fake.Profile.Id}, err)
+	store.IgnitionConfigs[fake.Profile.IgnitionId] = fake.IgnitionYAML
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
+	assert.Equal(t, &MissingReferenceError{Kind: "generic", Name: fake.Profile.GenericId}, err)
+	assert.EqualError(t, err, `matchbox: Generic template "generic.tmpl" does not exist`)
+	assert.Empty(t, store.Profiles)
+	assert.Empty(t, store.Groups)
+}
+
+// unlistableStore is a FixedStore whose resources cannot be listed.
+type unlistableStore struct {
+	*fake.FixedStore
+}
+
+func (s unlistableStore) ProfileList() ([]*storagepb.Profile, error) {
+	return nil, errors.New("unlistable")
+}
+
+func (s unlistableStore) IgnitionList() ([]string, error) {
+	return nil, errors.New("unlistable")
+}
+
+func (s unlistableStore) GenericList() ([]string, error) {
+	return nil, errors.New("unlistable")
+}
+
+func (s unlistableStore) CloudList() ([]string, error) {
+	return nil, errors.New("unlistable")
+}
+
+func TestPut_ReferencesLookedUp(t *testing.T) {
+	store := newReferencedStore()
+	srv := NewServer(&Config{Store: unlistableStore{store}})
+
+	// assert that references are checked by looking up each referenced
+	// resource, not by listing all resources of its kind
+	_, err := srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
+	assert.Nil(t, err)
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	assert.Nil(t, err)
+	delete(store.IgnitionConfigs, fake.Profile.IgnitionId)
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
+	assert.Equal(t, &MissingReferenceError{Kind: "ignition", Name: fake.Profile.IgnitionId}, err)
+}
+
+func TestDelete_Dependents(t *testing.T) {
+	store := newReferencedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Profiles["other"] = &storagepb.Profile{Id: "other", IgnitionIds: []string{fake.Profile.IgnitionId}}
+	srv := NewServer(&Config{Store: store})
+	ctx := context.Background()
+
+	// assert that referenced resources are not deleted and their dependents
+	// are listed
+	err := srv.ProfileDelete(ctx, &pb.ProfileDeleteRequest{Id: fake.Profile.Id})
+	assert.Equal(t, &DependentsError{Kind: "profiles", Name: fake.Profile.Id, Dependents: []string{"groups/" + fake.Group.Id}}, err)
+	err = srv.IgnitionDelete(ctx, &pb.IgnitionDeleteRequest{Name: fake.Profile.IgnitionId})
+	assert.Equal(t, &DependentsError{Kind: "ignition", Name: fake.Profile.IgnitionId, Dependents: []string{"profiles/" + fake.Profile.Id, "profiles/other"}}, err)
+	err = srv.GenericDelete(ctx, &pb.GenericDeleteRequest{Name: fake.Profile.GenericId})
+	assert.IsType(t, &DependentsError{}, err)
+	err = srv.CloudDelete(ctx, &pb.CloudDeleteRequest{Name: fake.Profile.CloudId})
+	assert.IsType(t, &DependentsError{}, err)
+	assert.Equal(t, 2, len(store.Profiles))
+	assert.Equal(t, 1, len(store.IgnitionConfigs))
+
+	// assert that forced deletes leave references dangling
+	err = srv.ProfileDelete(ctx, &pb.ProfileDeleteRequest{Id: fake.Profile.Id, Force: true})
+	assert.Nil(t, err)
+	err = srv.CloudDelete(ctx, &pb.CloudDeleteRequest{Name: fake.Profile.CloudId})
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Profile.Id, store.Groups[fake.Group.Id].Profile)
+}
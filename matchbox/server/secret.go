@@ -0,0 +1,61 @@
+package server
+
+import (
+	"github.com/coreos/matchbox/matchbox/secret"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// encryptGroup returns a copy of a Group to be written, with its redacted
+// secret values restored from the stored Group and its plaintext secret
+// values encrypted with the server's secret key.
+func (s *server) encryptGroup(ns *namespace, group *storagepb.Group) (*storagepb.Group, error) {
+	var stored []byte
+	if current, err := ns.store.GroupGet(group.Id); err == nil {
+		stored = current.Metadata
+	}
+	metadata, err := secret.Unredact(group.Metadata, stored)
+	if err != nil {
+		return nil, err
+	}
+	if metadata, err = secret.Encrypt(s.secretKey, metadata); err != nil {
+		return nil, err
+	}
+	encrypted := *group
+	encrypted.Metadata = metadata
+	return &encrypted, nil
+}
+
+// encryptProfile returns a copy of a Profile to be written, with its
+// redacted secret values restored from the stored Profile and its plaintext
+// secret values encrypted with the server's secret key.
+func (s *server) encryptProfile(ns *namespace, profile *storagepb.Profile) (*storagepb.Profile, error) {
+	var stored []byte
+	if current, err := ns.store.ProfileGet(profile.Id); err == nil {
+		stored = current.Metadata
+	}
+	metadata, err := secret.Unredact(profile.Metadata, stored)
+	if err != nil {
+		return nil, err
+	}
+	if metadata, err = secret.Encrypt(s.secretKey, metadata); err != nil {
+		return nil, err
+	}
+	encrypted := *profile
+	encrypted.Metadata = metadata
+	return &encrypted, nil
+}
+
+// secretProblems returns a Problem for each secret value of the metadata of
+// a Group or Profile which is stored in plaintext or cannot be decrypted
+// with the server's secret key.
+func (s *server) secretProblems(kind, name string, metadata []byte) ([]*storagepb.Problem, error) {
+	messages, err := secret.Problems(s.secretKey, metadata)
+	if err != nil {
+		return nil, err
+	}
+	var problems []*storagepb.Problem
+	for _, message := range messages {
+		problems = append(problems, problem(kind, name, "%s", message))
+	}
+	return problems, nil
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/secret"
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestGroupPut_Secrets(t *testing.T) {
+	key, err := secret.NewKey([]byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, err)
+	store := newReferencedStore()
+	srv := NewServer(&Config{Store: store, SecretKey: key})
+	group := &storagepb.Group{
+		Id:       "node1",
+		Profile:  fake.Profile.Id,
+		Metadata: []byte(`{"user":"core","token":{"$secret":"s3cr3t"}}`),
+	}
+
+	// assert that plaintext secret values are stored encrypted
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: group})
+	assert.Nil(t, err)
+	stored := storedMetadata(t, store)
+	token := stored["token"].(map[string]interface{})
+	assert.NotEqual(t, "s3cr3t", token[secret.EncryptedMember])
+	plaintext, err := secret.Decrypt(key, "/token", token)
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cr3t", plaintext)
+
+	// assert that redacted secret values are kept when a Group is written back
+	redacted := &storagepb.Group{
+		Id:       "node1",
+		Profile:  fake.Profile.Id,
+		Metadata: []byte(`{"user":"admin","token":{"$encrypted":"REDACTED"}}`),
+	}
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: redacted})
+	assert.Nil(t, err)
+	expected := map[string]interface{}{"user": "admin", "token": token}
+	assert.Equal(t, expected, storedMetadata(t, store))
+
+	// assert that plaintext secret values cannot be written without a key
+	srv = NewServer(&Config{Store: store})
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: group})
+	assert.Equal(t, secret.ErrNoKey, err)
+}
+
+func TestProfilePut_Secrets(t *testing.T) {
+	key, err := secret.NewKey([]byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, err)
+	store := newReferencedStore()
+	srv := NewServer(&Config{Store: store, SecretKey: key})
+	profile := &storagepb.Profile{
+		Id:       "worker",
+		Metadata: []byte(`{"user":"core","token":{"$secret":"s3cr3t"}}`),
+	}
+
+	// assert that plaintext secret values are stored encrypted
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: profile})
+	assert.Nil(t, err)
+	stored := storedProfileMetadata(t, store)
+	token := stored["token"].(map[string]interface{})
+	assert.NotEqual(t, "s3cr3t", token[secret.EncryptedMember])
+	plaintext, err := secret.Decrypt(key, "/token", token)
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cr3t", plaintext)
+
+	// assert that redacted secret values are kept when a Profile is written
+	// back
+	redacted := &storagepb.Profile{
+		Id:       "worker",
+		Metadata: []byte(`{"user":"admin","token":{"$encrypted":"REDACTED"}}`),
+	}
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: redacted})
+	assert.Nil(t, err)
+	expected := map[string]interface{}{"user": "admin", "token": token}
+	assert.Equal(t, expected, storedProfileMetadata(t, store))
+
+	// assert that plaintext secret values cannot be written without a key
+	srv = NewServer(&Config{Store: store})
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: profile})
+	assert.Equal(t, secret.ErrNoKey, err)
+}
+
+func TestValidate_Secrets(t *testing.T) {
+	group := &storagepb.Group{
+		Id:       "node1",
+		Profile:  fake.Profile.Id,
+		Metadata: []byte(`{"token":{"$secret":"s3cr3t"}}`),
+	}
+	profile := &storagepb.Profile{
+		Id:       "worker",
+		Metadata: []byte(`{"ssh":{"key":{"$secret":"s3cr3t"}}}`),
+	}
+	store := &fake.FixedStore{
+		Groups:          map[string]*storagepb.Group{group.Id: group},
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile, profile.Id: profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML},
+		GenericConfigs:  map[string]string{fake.GenericName: fake.Generic},
+		CloudConfigs:    map[string]string{"cloud-config.tmpl": "#cloud-config"},
+	}
+	srv := NewServer(&Config{Store: store})
+
+	// assert that secret values stored in plaintext are reported
+	problems, err := srv.Validate(context.Background(), &pb.ValidateRequest{})
+	assert.Nil(t, err)
+	expected := []*storagepb.Problem{
+		{Kind: "groups", Name: "node1", Message: "secret value /token is not encrypted"},
+		{Kind: "profiles", Name: "worker", Message: "secret value /ssh/key is not encrypted"},
+	}
+	assert.Equal(t, expected, problems)
+}
+
+// storedMetadata returns the decoded metadata of the stored Group "node1".
+func storedMetadata(t *testing.T, store *fake.FixedStore) map[string]interface{} {
+	var metadata map[string]interface{}
+	err := json.Unmarshal(store.Groups["node1"].Metadata, &metadata)
+	assert.Nil(t, err)
+	return metadata
+}
+
+// storedProfileMetadata returns the decoded metadata of the stored Profile
+// "worker".
+func storedProfileMetadata(t *testing.T, store *fake.FixedStore) map[string]interface{} {
+	var metadata map[string]interface{}
+	err := json.Unmarshal(store.Profiles["worker"].Metadata, &metadata)
+	assert.Nil(t, err)
+	return metadata
+}
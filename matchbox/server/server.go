@@ -2,10 +2,13 @@ package server
 
 import (
 	"errors"
-	"sort"
+	"sync"
 
 	"context"
 
+	"github.com/Sirupsen/logrus"
+
+	"github.com/coreos/matchbox/matchbox/secret"
 	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
 	"github.com/coreos/matchbox/matchbox/storage"
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
@@ -24,7 +27,7 @@ type Server interface {
 	// SelectProfile returns the Profile matching the given labels.
 	SelectProfile(context.Context, *pb.SelectProfileRequest) (*storagepb.Profile, error)
 
-	// Create or update a Group.
+	// Create or update a Group, returning it with its new resource version.
 	GroupPut(context.Context, *pb.GroupPutRequest) (*storagepb.Group, error)
 	// Get a machine Group by id.
 	GroupGet(context.Context, *pb.GroupGetRequest) (*storagepb.Group, error)
@@ -32,8 +35,10 @@ type Server interface {
 	GroupDelete(context.Context, *pb.GroupDeleteRequest) error
 	// List all machine Groups.
 	GroupList(context.Context, *pb.GroupListRequest) ([]*storagepb.Group, error)
+	// Watch machine Groups for changes until the context is done.
+	GroupWatch(context.Context, *pb.GroupWatchRequest) (<-chan *storagepb.Event, error)
 
-	// Create or update a Profile.
+	// Create or update a Profile, returning it with its new resource version.
 	ProfilePut(context.Context, *pb.ProfilePutRequest) (*storagepb.Profile, error)
 	// Get a Profile by id.
 	ProfileGet(context.Context, *pb.ProfileGetRequest) (*storagepb.Profile, error)
@@ -41,39 +46,109 @@ type Server interface {
 	ProfileDelete(context.Context, *pb.ProfileDeleteRequest) error
 	// List all Profiles.
 	ProfileList(context.Context, *pb.ProfileListRequest) ([]*storagepb.Profile, error)
+	// Watch Profiles for changes until the context is done.
+	ProfileWatch(context.Context, *pb.ProfileWatchRequest) (<-chan *storagepb.Event, error)
 
-	// Create or update an Ignition template.
-	IgnitionPut(context.Context, *pb.IgnitionPutRequest) (string, error)
+	// Create or update an Ignition template, returning its new resource
+	// version.
+	IgnitionPut(context.Context, *pb.IgnitionPutRequest) (int64, error)
 	// Get an Ignition template by name.
 	IgnitionGet(context.Context, *pb.IgnitionGetRequest) (string, error)
+	// Get the resource version of an Ignition template by name.
+	IgnitionVersion(context.Context, *pb.IgnitionGetRequest) (int64, error)
 	// Delete an Ignition template by name.
 	IgnitionDelete(context.Context, *pb.IgnitionDeleteRequest) error
+	// List the names of all Ignition templates.
+	IgnitionList(context.Context, *pb.IgnitionListRequest) ([]string, error)
+	// Watch Ignition templates for changes until the context is done.
+	IgnitionWatch(context.Context, *pb.IgnitionWatchRequest) (<-chan *storagepb.Event, error)
 
-	// Create or update an Generic template.
-	GenericPut(context.Context, *pb.GenericPutRequest) (string, error)
+	// Create or update an Generic template, returning its new resource
+	// version.
+	GenericPut(context.Context, *pb.GenericPutRequest) (int64, error)
 	// Get an Generic template by name.
 	GenericGet(context.Context, *pb.GenericGetRequest) (string, error)
+	// Get the resource version of a Generic template by name.
+	GenericVersion(context.Context, *pb.GenericGetRequest) (int64, error)
 	// Delete an Generic template by name.
 	GenericDelete(context.Context, *pb.GenericDeleteRequest) error
+	// List the names of all Generic templates.
+	GenericList(context.Context, *pb.GenericListRequest) ([]string, error)
+	// Watch Generic templates for changes until the context is done.
+	GenericWatch(context.Context, *pb.GenericWatchRequest) (<-chan *storagepb.Event, error)
 
+	// Create or update a Cloud-Config template, returning its new resource
+	// version.
+	CloudPut(context.Context, *pb.CloudPutRequest) (int64, error)
 	// Get a Cloud-Config template by name.
-	CloudGet(ctx context.Context, name string) (string, error)
+	CloudGet(context.Context, *pb.CloudGetRequest) (string, error)
+	// Get the resource version of a Cloud-Config template by name.
+	CloudVersion(context.Context, *pb.CloudGetRequest) (int64, error)
+	// Delete a Cloud-Config template by name.
+	CloudDelete(context.Context, *pb.CloudDeleteRequest) error
+	// List the names of all Cloud-Config templates.
+	CloudList(context.Context, *pb.CloudListRequest) ([]string, error)
+	// Watch Cloud-Config templates for changes until the context is done.
+	CloudWatch(context.Context, *pb.CloudWatchRequest) (<-chan *storagepb.Event, error)
+
+	// Create or update a partial template, returning its new resource
+	// version.
+	PartialPut(context.Context, *pb.PartialPutRequest) (int64, error)
+	// Get a partial template by name.
+	PartialGet(context.Context, *pb.PartialGetRequest) (string, error)
+	// Get the resource version of a partial template by name.
+	PartialVersion(context.Context, *pb.PartialGetRequest) (int64, error)
+	// Delete a partial template by name.
+	PartialDelete(context.Context, *pb.PartialDeleteRequest) error
+	// List the names of all partial templates.
+	PartialList(context.Context, *pb.PartialListRequest) ([]string, error)
+
+	// Get the recorded Revisions of a resource, oldest first.
+	History(context.Context, *pb.HistoryRequest) ([]*storagepb.Revision, error)
+	// Restore a resource to a recorded Revision, returning its new resource
+	// version.
+	Rollback(context.Context, *pb.RollbackRequest) (int64, error)
+
+	// Get the Problems of the stored resources, such as unparseable Groups
+	// or references to missing Profiles and templates.
+	Validate(context.Context, *pb.ValidateRequest) ([]*storagepb.Problem, error)
 }
 
 // Config configures a server implementation.
 type Config struct {
 	Store storage.Store
+	// key of the secret values of Group metadata (optional)
+	SecretKey *secret.Key
+	// merge the metadata of all Groups matching a selection (optional)
+	MergeMetadata bool
+	Logger        *logrus.Logger
 }
 
 // server implements the Server interface.
 type server struct {
-	store storage.Store
+	// default namespace
+	root *namespace
+	// key of the secret values of Group metadata, or nil
+	secretKey *secret.Key
+	// whether to merge the metadata of all matching Groups
+	mergeMetadata bool
+	logger        *logrus.Logger
+
+	mu         sync.Mutex
+	namespaces map[string]*namespace
 }
 
-// NewServer returns a new Server.
+// NewServer returns a new Server. Requests are scoped to the namespace they
+// name, else to the namespace of their context (see WithNamespace), else to
+// the default namespace. Stores which implement storage.Namespacer support
+// namespaces other than the default namespace.
 func NewServer(config *Config) Server {
 	return &server{
-		store: config.Store,
+		root:          newNamespace("", config.Store),
+		secretKey:     config.SecretKey,
+		mergeMetadata: config.MergeMetadata,
+		logger:        config.Logger,
+		namespaces:    make(map[string]*namespace),
 	}
 }
 
@@ -81,89 +156,209 @@ func (s *server) GroupPut(ctx context.Context, req *pb.GroupPutRequest) (*storag
 	if err := req.Group.AssertValid(); err != nil {
 		return nil, err
 	}
-	err := s.store.GroupPut(req.Group)
+	ns, err := s.writeNamespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGroupReferences(ns.store, req.Group); err != nil {
+		return nil, err
+	}
+	if err := checkAmbiguity(ns.store, req.Group); err != nil {
+		return nil, err
+	}
+	group, err := s.encryptGroup(ns, unnamespacedGroup(req.Group))
+	if err != nil {
+		return nil, err
+	}
+	content, err := groupContent(group)
 	if err != nil {
 		return nil, err
 	}
-	return req.Group, nil
+	version, err := s.write(ctx, ns, "groups", group.Id, content, false, func(store storage.Store) (int64, error) {
+		return store.GroupPut(group)
+	})
+	ns.matcher.invalidate()
+	if err != nil {
+		return nil, err
+	}
+	put := *group
+	put.ResourceVersion = version
+	put.Namespace = ns.name
+	return &put, nil
 }
 
 func (s *server) GroupGet(ctx context.Context, req *pb.GroupGetRequest) (*storagepb.Group, error) {
-	group, err := s.store.GroupGet(req.Id)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	group, err := ns.store.GroupGet(req.Id)
 	if err != nil {
 		return nil, err
 	}
-	return group, nil
+	return ns.group(group), nil
 }
 
 func (s *server) GroupDelete(ctx context.Context, req *pb.GroupDeleteRequest) error {
-	return s.store.GroupDelete(req.Id)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	defer ns.matcher.invalidate()
+	_, err = s.write(ctx, ns, "groups", req.Id, nil, true, func(store storage.Store) (int64, error) {
+		return 0, store.GroupDelete(req.Id)
+	})
+	return err
 }
 
 func (s *server) GroupList(ctx context.Context, req *pb.GroupListRequest) ([]*storagepb.Group, error) {
-	groups, err := s.store.GroupList()
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := ns.store.GroupList()
 	if err != nil {
 		return nil, err
 	}
+	for i, group := range groups {
+		groups[i] = ns.group(group)
+	}
 	return groups, nil
 }
 
+func (s *server) GroupWatch(ctx context.Context, req *pb.GroupWatchRequest) (<-chan *storagepb.Event, error) {
+	return s.watch(ctx, req.Namespace, "groups", req.Id)
+}
+
 func (s *server) ProfilePut(ctx context.Context, req *pb.ProfilePutRequest) (*storagepb.Profile, error) {
 	if err := req.Profile.AssertValid(); err != nil {
 		return nil, err
 	}
-	err := s.store.ProfilePut(req.Profile)
+	ns, err := s.writeNamespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProfileReferences(ns.store, req.Profile); err != nil {
+		return nil, err
+	}
+	if err := checkProfileCycle(ns.store, req.Profile); err != nil {
+		return nil, err
+	}
+	profile, err := s.encryptProfile(ns, unnamespacedProfile(req.Profile))
 	if err != nil {
 		return nil, err
 	}
-	return req.Profile, nil
+	content, err := profileContent(profile)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.write(ctx, ns, "profiles", profile.Id, content, false, func(store storage.Store) (int64, error) {
+		return store.ProfilePut(profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+	put := *profile
+	put.ResourceVersion = version
+	put.Namespace = ns.name
+	return &put, nil
 }
 
+// ProfileGet returns a Profile by id. If the request asks to resolve it, the
+// Profile is returned with the settings inherited from its parents.
 func (s *server) ProfileGet(ctx context.Context, req *pb.ProfileGetRequest) (*storagepb.Profile, error) {
-	profile, err := s.store.ProfileGet(req.Id)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := ns.store.ProfileGet(req.Id)
 	if err != nil {
 		return nil, err
 	}
 	if err := profile.AssertValid(); err != nil {
 		return nil, err
 	}
-	return profile, nil
+	if req.Resolve {
+		if profile, err = resolveProfile(ns.store, profile); err != nil {
+			return nil, err
+		}
+	}
+	return ns.profile(profile), nil
 }
 
 func (s *server) ProfileDelete(ctx context.Context, req *pb.ProfileDeleteRequest) error {
-	return s.store.ProfileDelete(req.Id)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := checkDelete(ns.store, "profiles", req.Id, req.Force); err != nil {
+		return err
+	}
+	_, err = s.write(ctx, ns, "profiles", req.Id, nil, true, func(store storage.Store) (int64, error) {
+		return 0, store.ProfileDelete(req.Id)
+	})
+	return err
 }
 
 func (s *server) ProfileList(ctx context.Context, req *pb.ProfileListRequest) ([]*storagepb.Profile, error) {
-	profiles, err := s.store.ProfileList()
+	ns, err := s.namespace(ctx, req.Namespace)
 	if err != nil {
 		return nil, err
 	}
+	profiles, err := ns.store.ProfileList()
+	if err != nil {
+		return nil, err
+	}
+	for i, profile := range profiles {
+		profiles[i] = ns.profile(profile)
+	}
 	return profiles, nil
 }
 
+func (s *server) ProfileWatch(ctx context.Context, req *pb.ProfileWatchRequest) (<-chan *storagepb.Event, error) {
+	return s.watch(ctx, req.Namespace, "profiles", req.Id)
+}
+
 // SelectGroup selects the Group whose selector matches the given labels.
 // Groups are evaluated in sorted order from most selectors to least, using
-// alphabetical order as a deterministic tie-breaker.
+// alphabetical order as a deterministic tie-breaker. Groups are looked up in
+// an index which is rebuilt when Groups change. Only the Groups of the
+// request's namespace are selected. If metadata merging is enabled, the
+// metadata of all matching Groups is deep merged from least to most specific
+// into the metadata of the selected Group.
 func (s *server) SelectGroup(ctx context.Context, req *pb.SelectGroupRequest) (*storagepb.Group, error) {
-	groups, err := s.store.GroupList()
+	ns, err := s.namespace(ctx, req.Namespace)
 	if err != nil {
 		return nil, err
 	}
-	sort.Sort(sort.Reverse(storagepb.ByReqs(groups)))
-	for _, group := range groups {
-		if group.Matches(req.Labels) {
-			return group, nil
+	if s.mergeMetadata {
+		groups, err := ns.matcher.matchAll(req.Labels)
+		if err != nil {
+			return nil, err
+		}
+		group, err := mergeGroups(groups)
+		if err != nil {
+			return nil, err
 		}
+		return ns.group(group), nil
 	}
-	return nil, ErrNoMatchingGroup
+	group, err := ns.matcher.match(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return ns.group(group), nil
 }
 
+// SelectProfile returns the Profile of the Group whose selector matches the
+// given labels, resolved with the settings inherited from its parents.
 func (s *server) SelectProfile(ctx context.Context, req *pb.SelectProfileRequest) (*storagepb.Profile, error) {
-	group, err := s.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: req.Labels})
+	if _, err := s.namespace(ctx, req.Namespace); err != nil {
+		return nil, err
+	}
+	group, err := s.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: req.Labels, Namespace: req.Namespace})
 	if err == nil {
 		// lookup the Profile by id
-		profile, err := s.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile})
+		profile, err := s.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile, Namespace: req.Namespace, Resolve: true})
 		if err == nil {
 			return profile, nil
 		}
@@ -173,44 +368,269 @@ func (s *server) SelectProfile(ctx context.Context, req *pb.SelectProfileRequest
 }
 
 // IgnitionPut creates or updates an Ignition template by name.
-func (s *server) IgnitionPut(ctx context.Context, req *pb.IgnitionPutRequest) (string, error) {
-	err := s.store.IgnitionPut(req.Name, req.Config)
+func (s *server) IgnitionPut(ctx context.Context, req *pb.IgnitionPutRequest) (int64, error) {
+	ns, err := s.writeNamespace(ctx, req.Namespace)
 	if err != nil {
-		return "", err
+		return 0, err
+	}
+	if err := checkIncludes(ns.store, "ignition", req.Name, req.Config); err != nil {
+		return 0, err
 	}
-	return string(req.Config), err
+	return s.write(ctx, ns, "ignition", req.Name, req.Config, false, func(store storage.Store) (int64, error) {
+		return store.IgnitionPut(req.Name, req.Config, req.ResourceVersion)
+	})
 }
 
 // IgnitionGet gets an Ignition template by name.
 func (s *server) IgnitionGet(ctx context.Context, req *pb.IgnitionGetRequest) (string, error) {
-	return s.store.IgnitionGet(req.Name)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return "", err
+	}
+	return ns.store.IgnitionGet(req.Name)
+}
+
+// IgnitionVersion gets the resource version of an Ignition template by name.
+func (s *server) IgnitionVersion(ctx context.Context, req *pb.IgnitionGetRequest) (int64, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	return ns.store.IgnitionVersion(req.Name)
 }
 
 // IgnitionDelete deletes an Ignition template by name.
 func (s *server) IgnitionDelete(ctx context.Context, req *pb.IgnitionDeleteRequest) error {
-	return s.store.IgnitionDelete(req.Name)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := checkDelete(ns.store, "ignition", req.Name, req.Force); err != nil {
+		return err
+	}
+	_, err = s.write(ctx, ns, "ignition", req.Name, nil, true, func(store storage.Store) (int64, error) {
+		return 0, store.IgnitionDelete(req.Name)
+	})
+	return err
+}
+
+// IgnitionList lists the names of all Ignition templates.
+func (s *server) IgnitionList(ctx context.Context, req *pb.IgnitionListRequest) ([]string, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ns.store.IgnitionList()
+}
+
+// IgnitionWatch watches Ignition templates for changes.
+func (s *server) IgnitionWatch(ctx context.Context, req *pb.IgnitionWatchRequest) (<-chan *storagepb.Event, error) {
+	return s.watch(ctx, req.Namespace, "ignition", req.Name)
 }
 
 // GenericPut creates or updates an Generic template by name.
-func (s *server) GenericPut(ctx context.Context, req *pb.GenericPutRequest) (string, error) {
-	err := s.store.GenericPut(req.Name, req.Config)
+func (s *server) GenericPut(ctx context.Context, req *pb.GenericPutRequest) (int64, error) {
+	ns, err := s.writeNamespace(ctx, req.Namespace)
 	if err != nil {
-		return "", err
+		return 0, err
+	}
+	if err := checkIncludes(ns.store, "generic", req.Name, req.Config); err != nil {
+		return 0, err
 	}
-	return string(req.Config), err
+	return s.write(ctx, ns, "generic", req.Name, req.Config, false, func(store storage.Store) (int64, error) {
+		return store.GenericPut(req.Name, req.Config, req.ResourceVersion)
+	})
 }
 
 // GenericGet gets an Generic template by name.
 func (s *server) GenericGet(ctx context.Context, req *pb.GenericGetRequest) (string, error) {
-	return s.store.GenericGet(req.Name)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return "", err
+	}
+	return ns.store.GenericGet(req.Name)
+}
+
+// GenericVersion gets the resource version of a Generic template by name.
+func (s *server) GenericVersion(ctx context.Context, req *pb.GenericGetRequest) (int64, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	return ns.store.GenericVersion(req.Name)
 }
 
 // GenericDelete deletes an Generic template by name.
 func (s *server) GenericDelete(ctx context.Context, req *pb.GenericDeleteRequest) error {
-	return s.store.GenericDelete(req.Name)
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := checkDelete(ns.store, "generic", req.Name, req.Force); err != nil {
+		return err
+	}
+	_, err = s.write(ctx, ns, "generic", req.Name, nil, true, func(store storage.Store) (int64, error) {
+		return 0, store.GenericDelete(req.Name)
+	})
+	return err
+}
+
+// GenericList lists the names of all Generic templates.
+func (s *server) GenericList(ctx context.Context, req *pb.GenericListRequest) ([]string, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ns.store.GenericList()
+}
+
+// GenericWatch watches Generic templates for changes.
+func (s *server) GenericWatch(ctx context.Context, req *pb.GenericWatchRequest) (<-chan *storagepb.Event, error) {
+	return s.watch(ctx, req.Namespace, "generic", req.Name)
+}
+
+// CloudPut creates or updates a Cloud-Config template by name.
+func (s *server) CloudPut(ctx context.Context, req *pb.CloudPutRequest) (int64, error) {
+	ns, err := s.writeNamespace(ctx, req.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkIncludes(ns.store, "cloud", req.Name, req.Config); err != nil {
+		return 0, err
+	}
+	return s.write(ctx, ns, "cloud", req.Name, req.Config, false, func(store storage.Store) (int64, error) {
+		return store.CloudPut(req.Name, req.Config, req.ResourceVersion)
+	})
 }
 
 // CloudGet gets a Cloud-Config template by name.
-func (s *server) CloudGet(ctx context.Context, name string) (string, error) {
-	return s.store.CloudGet(name)
+func (s *server) CloudGet(ctx context.Context, req *pb.CloudGetRequest) (string, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return "", err
+	}
+	return ns.store.CloudGet(req.Name)
+}
+
+// CloudVersion gets the resource version of a Cloud-Config template by name.
+func (s *server) CloudVersion(ctx context.Context, req *pb.CloudGetRequest) (int64, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	return ns.store.CloudVersion(req.Name)
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *server) CloudDelete(ctx context.Context, req *pb.CloudDeleteRequest) error {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := checkDelete(ns.store, "cloud", req.Name, req.Force); err != nil {
+		return err
+	}
+	_, err = s.write(ctx, ns, "cloud", req.Name, nil, true, func(store storage.Store) (int64, error) {
+		return 0, store.CloudDelete(req.Name)
+	})
+	return err
+}
+
+// CloudList lists the names of all Cloud-Config templates.
+func (s *server) CloudList(ctx context.Context, req *pb.CloudListRequest) ([]string, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ns.store.CloudList()
+}
+
+// CloudWatch watches Cloud-Config templates for changes.
+func (s *server) CloudWatch(ctx context.Context, req *pb.CloudWatchRequest) (<-chan *storagepb.Event, error) {
+	return s.watch(ctx, req.Namespace, "cloud", req.Name)
+}
+
+// PartialPut creates or updates a partial template by name.
+func (s *server) PartialPut(ctx context.Context, req *pb.PartialPutRequest) (int64, error) {
+	ns, err := s.writeNamespace(ctx, req.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkIncludes(ns.store, "partials", req.Name, req.Config); err != nil {
+		return 0, err
+	}
+	return s.write(ctx, ns, "partials", req.Name, req.Config, false, func(store storage.Store) (int64, error) {
+		return store.PartialPut(req.Name, req.Config, req.ResourceVersion)
+	})
+}
+
+// PartialGet gets a partial template by name.
+func (s *server) PartialGet(ctx context.Context, req *pb.PartialGetRequest) (string, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return "", err
+	}
+	return ns.store.PartialGet(req.Name)
+}
+
+// PartialVersion gets the resource version of a partial template by name.
+func (s *server) PartialVersion(ctx context.Context, req *pb.PartialGetRequest) (int64, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	return ns.store.PartialVersion(req.Name)
+}
+
+// PartialDelete deletes a partial template by name.
+func (s *server) PartialDelete(ctx context.Context, req *pb.PartialDeleteRequest) error {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := checkDelete(ns.store, "partials", req.Name, req.Force); err != nil {
+		return err
+	}
+	_, err = s.write(ctx, ns, "partials", req.Name, nil, true, func(store storage.Store) (int64, error) {
+		return 0, store.PartialDelete(req.Name)
+	})
+	return err
+}
+
+// PartialList lists the names of all partial templates.
+func (s *server) PartialList(ctx context.Context, req *pb.PartialListRequest) ([]string, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ns.store.PartialList()
+}
+
+// watch returns a channel of the Events of a namespace's Store for resources
+// of the given kind and, if not empty, name. The channel is closed when the
+// context is done or the Store's watch fails.
+func (s *server) watch(ctx context.Context, namespace, kind, name string) (<-chan *storagepb.Event, error) {
+	ns, err := s.namespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	events, err := ns.store.Watch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make(chan *storagepb.Event)
+	go func() {
+		defer close(filtered)
+		for event := range events {
+			if event.Kind != kind || (name != "" && event.Name != name) {
+				continue
+			}
+			select {
+			case filtered <- ns.event(event):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return filtered, nil
 }
@@ -29,7 +29,7 @@ func TestSelectGroup(t *testing.T) {
 		{&fake.EmptyStore{}, map[string]string{"a": "b"}, nil, ErrNoMatchingGroup},
 	}
 	for _, c := range cases {
-		srv := NewServer(&Config{c.store})
+		srv := NewServer(&Config{Store: c.store})
 		group, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: c.labels})
 		if assert.Equal(t, c.err, err) {
 			assert.Equal(t, c.group, group)
@@ -60,7 +60,7 @@ func TestSelectProfile(t *testing.T) {
 		{&fake.EmptyStore{}, map[string]string{"a": "b"}, nil, ErrNoMatchingGroup},
 	}
 	for _, c := range cases {
-		srv := NewServer(&Config{c.store})
+		srv := NewServer(&Config{Store: c.store})
 		profile, err := srv.SelectProfile(context.Background(), &pb.SelectProfileRequest{Labels: c.labels})
 		if assert.Equal(t, c.err, err) {
 			assert.Equal(t, c.profile, profile)
@@ -69,7 +69,7 @@ func TestSelectProfile(t *testing.T) {
 }
 
 func TestGroupCRUD(t *testing.T) {
-	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	srv := NewServer(&Config{Store: newReferencedStore()})
 	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
 	// assert that:
 	// - Group creation is successful
@@ -87,6 +87,23 @@ func TestGroupCRUD(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGroupPut_Version(t *testing.T) {
+	srv := NewServer(&Config{Store: newReferencedStore()})
+	// assert that:
+	// - puts return the Group with its new resource version
+	// - a put with a stale resource version fails with a conflict
+	created, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	assert.Nil(t, err)
+	assert.True(t, created.ResourceVersion > 0)
+	assert.Equal(t, int64(0), fake.Group.ResourceVersion)
+
+	updated, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: created})
+	assert.Nil(t, err)
+	assert.True(t, updated.ResourceVersion > created.ResourceVersion)
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: created})
+	assert.Equal(t, storage.ErrVersionConflict, err)
+}
+
 func TestGroupCreate_Invalid(t *testing.T) {
 	srv := NewServer(&Config{Store: fake.NewFixedStore()})
 	invalid := &storagepb.Group{}
@@ -98,7 +115,7 @@ func TestGroupList(t *testing.T) {
 	store := &fake.FixedStore{
 		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
 	}
-	srv := NewServer(&Config{store})
+	srv := NewServer(&Config{Store: store})
 	groups, err := srv.GroupList(context.Background(), &pb.GroupListRequest{})
 	assert.Nil(t, err)
 	if assert.Equal(t, 1, len(groups)) {
@@ -107,7 +124,7 @@ func TestGroupList(t *testing.T) {
 }
 
 func TestGroup_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
 	assert.Error(t, err)
 	_, err = srv.GroupGet(context.Background(), &pb.GroupGetRequest{Id: fake.Group.Id})
@@ -119,7 +136,7 @@ func TestGroup_BrokenStore(t *testing.T) {
 }
 
 func TestProfileCRUD(t *testing.T) {
-	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	srv := NewServer(&Config{Store: newReferencedStore()})
 	_, err := srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
 	// assert that:
 	// - Profile creation is successful
@@ -155,7 +172,7 @@ func TestProfileGet(t *testing.T) {
 	}{
 		{fake.Profile.Id, fake.Profile, nil},
 	}
-	srv := NewServer(&Config{store})
+	srv := NewServer(&Config{Store: store})
 	for _, c := range cases {
 		profile, err := srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: c.id})
 		assert.Equal(t, c.err, err)
@@ -167,7 +184,7 @@ func TestProfileList(t *testing.T) {
 	store := &fake.FixedStore{
 		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
 	}
-	srv := NewServer(&Config{store})
+	srv := NewServer(&Config{Store: store})
 	profiles, err := srv.ProfileList(context.Background(), &pb.ProfileListRequest{})
 	assert.Nil(t, err)
 	if assert.Equal(t, 1, len(profiles)) {
@@ -176,14 +193,14 @@ func TestProfileList(t *testing.T) {
 }
 
 func TestProfileList_Empty(t *testing.T) {
-	srv := NewServer(&Config{&fake.EmptyStore{}})
+	srv := NewServer(&Config{Store: &fake.EmptyStore{}})
 	profiles, err := srv.ProfileList(context.Background(), &pb.ProfileListRequest{})
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(profiles))
 }
 
 func TestProfiles_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	_, err := srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
 	assert.Error(t, err)
 	_, err = srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: fake.Profile.Id})
@@ -216,8 +233,28 @@ func TestIgnitionCRUD(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIgnitionPut_Version(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	req := &pb.IgnitionPutRequest{
+		Name:   fake.IgnitionYAMLName,
+		Config: []byte(fake.IgnitionYAML),
+	}
+	version, err := srv.IgnitionPut(context.Background(), req)
+	assert.Nil(t, err)
+	current, err := srv.IgnitionVersion(context.Background(), &pb.IgnitionGetRequest{Name: fake.IgnitionYAMLName})
+	assert.Nil(t, err)
+	assert.Equal(t, version, current)
+
+	// assert that a put with a stale resource version fails with a conflict
+	req.ResourceVersion = version
+	_, err = srv.IgnitionPut(context.Background(), req)
+	assert.Nil(t, err)
+	_, err = srv.IgnitionPut(context.Background(), req)
+	assert.Equal(t, storage.ErrVersionConflict, err)
+}
+
 func TestIgnition_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	req := &pb.IgnitionPutRequest{
 		Name:   fake.IgnitionYAMLName,
 		Config: []byte(fake.IgnitionYAML),
@@ -253,7 +290,7 @@ func TestGenericCRUD(t *testing.T) {
 }
 
 func TestGeneric_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	req := &pb.GenericPutRequest{
 		Name:   fake.GenericName,
 		Config: []byte(fake.Generic),
@@ -266,3 +303,135 @@ func TestGeneric_BrokenStore(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestCloudCRUD(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	req := &pb.CloudPutRequest{
+		Name:   "cloud.yaml",
+		Config: []byte("#cloud-config"),
+	}
+	version, err := srv.CloudPut(context.Background(), req)
+	// assert that:
+	// - Cloud-Config template creation is successful
+	// - Cloud-Config template can be retrieved by name
+	// - Cloud-Config template can be deleted by name
+	assert.Nil(t, err)
+	template, err := srv.CloudGet(context.Background(), &pb.CloudGetRequest{Name: "cloud.yaml"})
+	assert.Equal(t, "#cloud-config", template)
+	assert.Nil(t, err)
+	current, err := srv.CloudVersion(context.Background(), &pb.CloudGetRequest{Name: "cloud.yaml"})
+	assert.Nil(t, err)
+	assert.Equal(t, version, current)
+
+	err = srv.CloudDelete(context.Background(), &pb.CloudDeleteRequest{Name: "cloud.yaml"})
+	assert.Nil(t, err)
+	_, err = srv.CloudGet(context.Background(), &pb.CloudGetRequest{Name: "cloud.yaml"})
+	assert.Error(t, err)
+}
+
+func TestCloud_BrokenStore(t *testing.T) {
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
+	_, err := srv.CloudPut(context.Background(), &pb.CloudPutRequest{Name: "cloud.yaml"})
+	assert.Error(t, err)
+	_, err = srv.CloudGet(context.Background(), &pb.CloudGetRequest{Name: "cloud.yaml"})
+	assert.Error(t, err)
+	err = srv.CloudDelete(context.Background(), &pb.CloudDeleteRequest{Name: "cloud.yaml"})
+	assert.Error(t, err)
+	_, err = srv.CloudList(context.Background(), &pb.CloudListRequest{})
+	assert.Error(t, err)
+}
+
+func TestTemplateList(t *testing.T) {
+	store := &fake.FixedStore{
+		IgnitionConfigs: map[string]string{"b.yaml": "", "a.yaml": ""},
+		GenericConfigs:  map[string]string{fake.GenericName: fake.Generic},
+		CloudConfigs:    map[string]string{},
+	}
+	srv := NewServer(&Config{Store: store})
+	// assert that template names are listed in order
+	names, err := srv.IgnitionList(context.Background(), &pb.IgnitionListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a.yaml", "b.yaml"}, names)
+	names, err = srv.GenericList(context.Background(), &pb.GenericListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{fake.GenericName}, names)
+	names, err = srv.CloudList(context.Background(), &pb.CloudListRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+}
+
+func TestGroupWatch(t *testing.T) {
+	srv := NewServer(&Config{Store: newReferencedStore()})
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := srv.GroupWatch(ctx, &pb.GroupWatchRequest{Id: fake.Group.Id})
+	assert.Nil(t, err)
+
+	// assert that only Events for the watched Group are received
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
+	assert.Nil(t, err)
+	other := &storagepb.Group{Id: "other", Profile: fake.Profile.Id}
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: other})
+	assert.Nil(t, err)
+	_, err = srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	assert.Nil(t, err)
+	err = srv.GroupDelete(context.Background(), &pb.GroupDeleteRequest{Id: fake.Group.Id})
+	assert.Nil(t, err)
+
+	event := <-events
+	assert.Equal(t, storagepb.Event_PUT, event.Type)
+	assert.Equal(t, fake.Group, event.Group)
+	event = <-events
+	assert.Equal(t, storagepb.Event_DELETE, event.Type)
+	assert.Equal(t, fake.Group.Id, event.Name)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestCloudWatch(t *testing.T) {
+	srv := NewServer(&Config{Store: newReferencedStore()})
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := srv.CloudWatch(ctx, &pb.CloudWatchRequest{Name: "node.yaml"})
+	assert.Nil(t, err)
+
+	// assert that only Events for the watched Cloud-Config template are
+	// received
+	_, err = srv.GenericPut(context.Background(), &pb.GenericPutRequest{Name: "node.yaml", Config: []byte("generic")})
+	assert.Nil(t, err)
+	_, err = srv.CloudPut(context.Background(), &pb.CloudPutRequest{Name: "other.yaml", Config: []byte("#cloud-config")})
+	assert.Nil(t, err)
+	_, err = srv.CloudPut(context.Background(), &pb.CloudPutRequest{Name: "node.yaml", Config: []byte("#cloud-config")})
+	assert.Nil(t, err)
+
+	event := <-events
+	assert.Equal(t, storagepb.Event_PUT, event.Type)
+	assert.Equal(t, "cloud", event.Kind)
+	assert.Equal(t, "node.yaml", event.Name)
+	assert.Equal(t, []byte("#cloud-config"), event.Template)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatch_BrokenStore(t *testing.T) {
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
+	_, err := srv.GroupWatch(context.Background(), &pb.GroupWatchRequest{})
+	assert.Error(t, err)
+	_, err = srv.ProfileWatch(context.Background(), &pb.ProfileWatchRequest{})
+	assert.Error(t, err)
+	_, err = srv.CloudWatch(context.Background(), &pb.CloudWatchRequest{})
+	assert.Error(t, err)
+}
+
+// newReferencedStore returns a FixedStore with fake.Profile and the
+// templates it refers to, so fake.Group and fake.Profile can be written.
+func newReferencedStore() *fake.FixedStore {
+	store := fake.NewFixedStore()
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	store.IgnitionConfigs[fake.Profile.IgnitionId] = fake.IgnitionYAML
+	store.GenericConfigs[fake.Profile.GenericId] = fake.Generic
+	store.CloudConfigs[fake.Profile.CloudId] = "#cloud-config"
+	return store
+}
@@ -21,6 +21,8 @@ It has these top-level messages:
 	GroupDeleteResponse
 	GroupListRequest
 	GroupListResponse
+	GroupWatchRequest
+	GroupWatchResponse
 	ProfilePutRequest
 	ProfilePutResponse
 	ProfileGetRequest
@@ -29,18 +31,52 @@ It has these top-level messages:
 	ProfileDeleteResponse
 	ProfileListRequest
 	ProfileListResponse
+	ProfileWatchRequest
+	ProfileWatchResponse
 	IgnitionPutRequest
 	IgnitionPutResponse
 	IgnitionGetRequest
 	IgnitionGetResponse
 	IgnitionDeleteRequest
 	IgnitionDeleteResponse
+	IgnitionListRequest
+	IgnitionListResponse
+	IgnitionWatchRequest
+	IgnitionWatchResponse
 	GenericPutRequest
 	GenericPutResponse
 	GenericGetRequest
 	GenericGetResponse
 	GenericDeleteRequest
 	GenericDeleteResponse
+	GenericListRequest
+	GenericListResponse
+	GenericWatchRequest
+	GenericWatchResponse
+	CloudPutRequest
+	CloudPutResponse
+	CloudGetRequest
+	CloudGetResponse
+	CloudDeleteRequest
+	CloudDeleteResponse
+	CloudListRequest
+	CloudListResponse
+	CloudWatchRequest
+	CloudWatchResponse
+	PartialPutRequest
+	PartialPutResponse
+	PartialGetRequest
+	PartialGetResponse
+	PartialDeleteRequest
+	PartialDeleteResponse
+	PartialListRequest
+	PartialListResponse
+	HistoryRequest
+	HistoryResponse
+	RollbackRequest
+	RollbackResponse
+	ValidateRequest
+	ValidateResponse
 */
 package serverpb
 
@@ -62,6 +98,8 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type SelectGroupRequest struct {
 	Labels map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *SelectGroupRequest) Reset()                    { *m = SelectGroupRequest{} }
@@ -76,6 +114,13 @@ func (m *SelectGroupRequest) GetLabels() map[string]string {
 	return nil
 }
 
+func (m *SelectGroupRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type SelectGroupResponse struct {
 	Group *storagepb.Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
 }
@@ -94,6 +139,8 @@ func (m *SelectGroupResponse) GetGroup() *storagepb.Group {
 
 type SelectProfileRequest struct {
 	Labels map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *SelectProfileRequest) Reset()                    { *m = SelectProfileRequest{} }
@@ -108,6 +155,13 @@ func (m *SelectProfileRequest) GetLabels() map[string]string {
 	return nil
 }
 
+func (m *SelectProfileRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type SelectProfileResponse struct {
 	Profile *storagepb.Profile `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
 }
@@ -125,7 +179,11 @@ func (m *SelectProfileResponse) GetProfile() *storagepb.Profile {
 }
 
 type GroupPutRequest struct {
+	// Group to write. A non-zero resource_version only updates the Group if
+	// it has this resource version.
 	Group *storagepb.Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *GroupPutRequest) Reset()                    { *m = GroupPutRequest{} }
@@ -140,7 +198,16 @@ func (m *GroupPutRequest) GetGroup() *storagepb.Group {
 	return nil
 }
 
+func (m *GroupPutRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type GroupPutResponse struct {
+	// written Group with its new resource version
+	Group *storagepb.Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
 }
 
 func (m *GroupPutResponse) Reset()                    { *m = GroupPutResponse{} }
@@ -148,8 +215,17 @@ func (m *GroupPutResponse) String() string            { return proto.CompactText
 func (*GroupPutResponse) ProtoMessage()               {}
 func (*GroupPutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
+func (m *GroupPutResponse) GetGroup() *storagepb.Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
 type GroupGetRequest struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *GroupGetRequest) Reset()                    { *m = GroupGetRequest{} }
@@ -164,6 +240,13 @@ func (m *GroupGetRequest) GetId() string {
 	return ""
 }
 
+func (m *GroupGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type GroupGetResponse struct {
 	Group *storagepb.Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
 }
@@ -182,6 +265,8 @@ func (m *GroupGetResponse) GetGroup() *storagepb.Group {
 
 type GroupDeleteRequest struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *GroupDeleteRequest) Reset()                    { *m = GroupDeleteRequest{} }
@@ -196,6 +281,13 @@ func (m *GroupDeleteRequest) GetId() string {
 	return ""
 }
 
+func (m *GroupDeleteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type GroupDeleteResponse struct {
 }
 
@@ -205,6 +297,8 @@ func (*GroupDeleteResponse) ProtoMessage()               {}
 func (*GroupDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 type GroupListRequest struct {
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *GroupListRequest) Reset()                    { *m = GroupListRequest{} }
@@ -212,6 +306,13 @@ func (m *GroupListRequest) String() string            { return proto.CompactText
 func (*GroupListRequest) ProtoMessage()               {}
 func (*GroupListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
+func (m *GroupListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type GroupListResponse struct {
 	Groups []*storagepb.Group `protobuf:"bytes,1,rep,name=groups" json:"groups,omitempty"`
 }
@@ -228,14 +329,60 @@ func (m *GroupListResponse) GetGroups() []*storagepb.Group {
 	return nil
 }
 
+type GroupWatchRequest struct {
+	// watch a single Group by id (optional)
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *GroupWatchRequest) Reset()                    { *m = GroupWatchRequest{} }
+func (m *GroupWatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*GroupWatchRequest) ProtoMessage()               {}
+func (*GroupWatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *GroupWatchRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GroupWatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type GroupWatchResponse struct {
+	Event *storagepb.Event `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *GroupWatchResponse) Reset()                    { *m = GroupWatchResponse{} }
+func (m *GroupWatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*GroupWatchResponse) ProtoMessage()               {}
+func (*GroupWatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *GroupWatchResponse) GetEvent() *storagepb.Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
 type ProfilePutRequest struct {
+	// Profile to write. A non-zero resource_version only updates the Profile
+	// if it has this resource version.
 	Profile *storagepb.Profile `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *ProfilePutRequest) Reset()                    { *m = ProfilePutRequest{} }
 func (m *ProfilePutRequest) String() string            { return proto.CompactTextString(m) }
 func (*ProfilePutRequest) ProtoMessage()               {}
-func (*ProfilePutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*ProfilePutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *ProfilePutRequest) GetProfile() *storagepb.Profile {
 	if m != nil {
@@ -244,22 +391,42 @@ func (m *ProfilePutRequest) GetProfile() *storagepb.Profile {
 	return nil
 }
 
+func (m *ProfilePutRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type ProfilePutResponse struct {
+	// written Profile with its new resource version
+	Profile *storagepb.Profile `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
 }
 
 func (m *ProfilePutResponse) Reset()                    { *m = ProfilePutResponse{} }
 func (m *ProfilePutResponse) String() string            { return proto.CompactTextString(m) }
 func (*ProfilePutResponse) ProtoMessage()               {}
-func (*ProfilePutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*ProfilePutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *ProfilePutResponse) GetProfile() *storagepb.Profile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
 
 type ProfileGetRequest struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// resolve the Profile with the settings inherited from its parents
+	Resolve bool `protobuf:"varint,3,opt,name=resolve" json:"resolve,omitempty"`
 }
 
 func (m *ProfileGetRequest) Reset()                    { *m = ProfileGetRequest{} }
 func (m *ProfileGetRequest) String() string            { return proto.CompactTextString(m) }
 func (*ProfileGetRequest) ProtoMessage()               {}
-func (*ProfileGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*ProfileGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *ProfileGetRequest) GetId() string {
 	if m != nil {
@@ -268,6 +435,20 @@ func (m *ProfileGetRequest) GetId() string {
 	return ""
 }
 
+func (m *ProfileGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ProfileGetRequest) GetResolve() bool {
+	if m != nil {
+		return m.Resolve
+	}
+	return false
+}
+
 type ProfileGetResponse struct {
 	Profile *storagepb.Profile `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
 }
@@ -275,7 +456,7 @@ type ProfileGetResponse struct {
 func (m *ProfileGetResponse) Reset()                    { *m = ProfileGetResponse{} }
 func (m *ProfileGetResponse) String() string            { return proto.CompactTextString(m) }
 func (*ProfileGetResponse) ProtoMessage()               {}
-func (*ProfileGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*ProfileGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *ProfileGetResponse) GetProfile() *storagepb.Profile {
 	if m != nil {
@@ -286,12 +467,16 @@ func (m *ProfileGetResponse) GetProfile() *storagepb.Profile {
 
 type ProfileDeleteRequest struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// delete even if Groups refer to it, leaving their references dangling
+	Force bool `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
 }
 
 func (m *ProfileDeleteRequest) Reset()                    { *m = ProfileDeleteRequest{} }
 func (m *ProfileDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*ProfileDeleteRequest) ProtoMessage()               {}
-func (*ProfileDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*ProfileDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *ProfileDeleteRequest) GetId() string {
 	if m != nil {
@@ -300,21 +485,44 @@ func (m *ProfileDeleteRequest) GetId() string {
 	return ""
 }
 
+func (m *ProfileDeleteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ProfileDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type ProfileDeleteResponse struct {
 }
 
 func (m *ProfileDeleteResponse) Reset()                    { *m = ProfileDeleteResponse{} }
 func (m *ProfileDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*ProfileDeleteResponse) ProtoMessage()               {}
-func (*ProfileDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*ProfileDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 type ProfileListRequest struct {
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *ProfileListRequest) Reset()                    { *m = ProfileListRequest{} }
 func (m *ProfileListRequest) String() string            { return proto.CompactTextString(m) }
 func (*ProfileListRequest) ProtoMessage()               {}
-func (*ProfileListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*ProfileListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ProfileListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
 
 type ProfileListResponse struct {
 	Profiles []*storagepb.Profile `protobuf:"bytes,1,rep,name=profiles" json:"profiles,omitempty"`
@@ -323,7 +531,7 @@ type ProfileListResponse struct {
 func (m *ProfileListResponse) Reset()                    { *m = ProfileListResponse{} }
 func (m *ProfileListResponse) String() string            { return proto.CompactTextString(m) }
 func (*ProfileListResponse) ProtoMessage()               {}
-func (*ProfileListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*ProfileListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *ProfileListResponse) GetProfiles() []*storagepb.Profile {
 	if m != nil {
@@ -332,15 +540,61 @@ func (m *ProfileListResponse) GetProfiles() []*storagepb.Profile {
 	return nil
 }
 
+type ProfileWatchRequest struct {
+	// watch a single Profile by id (optional)
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *ProfileWatchRequest) Reset()                    { *m = ProfileWatchRequest{} }
+func (m *ProfileWatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProfileWatchRequest) ProtoMessage()               {}
+func (*ProfileWatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ProfileWatchRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ProfileWatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ProfileWatchResponse struct {
+	Event *storagepb.Event `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *ProfileWatchResponse) Reset()                    { *m = ProfileWatchResponse{} }
+func (m *ProfileWatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProfileWatchResponse) ProtoMessage()               {}
+func (*ProfileWatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ProfileWatchResponse) GetEvent() *storagepb.Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
 type IgnitionPutRequest struct {
 	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// only update the template if it has this resource version (optional)
+	ResourceVersion int64 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *IgnitionPutRequest) Reset()                    { *m = IgnitionPutRequest{} }
 func (m *IgnitionPutRequest) String() string            { return proto.CompactTextString(m) }
 func (*IgnitionPutRequest) ProtoMessage()               {}
-func (*IgnitionPutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*IgnitionPutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *IgnitionPutRequest) GetName() string {
 	if m != nil {
@@ -356,22 +610,47 @@ func (m *IgnitionPutRequest) GetConfig() []byte {
 	return nil
 }
 
+func (m *IgnitionPutRequest) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *IgnitionPutRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type IgnitionPutResponse struct {
+	// new resource version of the template
+	ResourceVersion int64 `protobuf:"varint,1,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
 }
 
 func (m *IgnitionPutResponse) Reset()                    { *m = IgnitionPutResponse{} }
 func (m *IgnitionPutResponse) String() string            { return proto.CompactTextString(m) }
 func (*IgnitionPutResponse) ProtoMessage()               {}
-func (*IgnitionPutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*IgnitionPutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *IgnitionPutResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
 
 type IgnitionGetRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *IgnitionGetRequest) Reset()                    { *m = IgnitionGetRequest{} }
 func (m *IgnitionGetRequest) String() string            { return proto.CompactTextString(m) }
 func (*IgnitionGetRequest) ProtoMessage()               {}
-func (*IgnitionGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*IgnitionGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *IgnitionGetRequest) GetName() string {
 	if m != nil {
@@ -380,14 +659,22 @@ func (m *IgnitionGetRequest) GetName() string {
 	return ""
 }
 
+func (m *IgnitionGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type IgnitionGetResponse struct {
-	Config []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Config          []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion int64  `protobuf:"varint,2,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
 }
 
 func (m *IgnitionGetResponse) Reset()                    { *m = IgnitionGetResponse{} }
 func (m *IgnitionGetResponse) String() string            { return proto.CompactTextString(m) }
 func (*IgnitionGetResponse) ProtoMessage()               {}
-func (*IgnitionGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*IgnitionGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *IgnitionGetResponse) GetConfig() []byte {
 	if m != nil {
@@ -396,14 +683,25 @@ func (m *IgnitionGetResponse) GetConfig() []byte {
 	return nil
 }
 
+func (m *IgnitionGetResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
 type IgnitionDeleteRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// delete even if Profiles refer to it, leaving their references dangling
+	Force bool `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
 }
 
 func (m *IgnitionDeleteRequest) Reset()                    { *m = IgnitionDeleteRequest{} }
 func (m *IgnitionDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*IgnitionDeleteRequest) ProtoMessage()               {}
-func (*IgnitionDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*IgnitionDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *IgnitionDeleteRequest) GetName() string {
 	if m != nil {
@@ -412,23 +710,117 @@ func (m *IgnitionDeleteRequest) GetName() string {
 	return ""
 }
 
+func (m *IgnitionDeleteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *IgnitionDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type IgnitionDeleteResponse struct {
 }
 
 func (m *IgnitionDeleteResponse) Reset()                    { *m = IgnitionDeleteResponse{} }
 func (m *IgnitionDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*IgnitionDeleteResponse) ProtoMessage()               {}
-func (*IgnitionDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*IgnitionDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+type IgnitionListRequest struct {
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *IgnitionListRequest) Reset()                    { *m = IgnitionListRequest{} }
+func (m *IgnitionListRequest) String() string            { return proto.CompactTextString(m) }
+func (*IgnitionListRequest) ProtoMessage()               {}
+func (*IgnitionListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *IgnitionListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type IgnitionListResponse struct {
+	// sorted template names
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+}
+
+func (m *IgnitionListResponse) Reset()                    { *m = IgnitionListResponse{} }
+func (m *IgnitionListResponse) String() string            { return proto.CompactTextString(m) }
+func (*IgnitionListResponse) ProtoMessage()               {}
+func (*IgnitionListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *IgnitionListResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type IgnitionWatchRequest struct {
+	// watch a single template by name (optional)
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *IgnitionWatchRequest) Reset()                    { *m = IgnitionWatchRequest{} }
+func (m *IgnitionWatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*IgnitionWatchRequest) ProtoMessage()               {}
+func (*IgnitionWatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *IgnitionWatchRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *IgnitionWatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type IgnitionWatchResponse struct {
+	Event *storagepb.Event `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *IgnitionWatchResponse) Reset()                    { *m = IgnitionWatchResponse{} }
+func (m *IgnitionWatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*IgnitionWatchResponse) ProtoMessage()               {}
+func (*IgnitionWatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *IgnitionWatchResponse) GetEvent() *storagepb.Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
 
 type GenericPutRequest struct {
 	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// only update the template if it has this resource version (optional)
+	ResourceVersion int64 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *GenericPutRequest) Reset()                    { *m = GenericPutRequest{} }
 func (m *GenericPutRequest) String() string            { return proto.CompactTextString(m) }
 func (*GenericPutRequest) ProtoMessage()               {}
-func (*GenericPutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*GenericPutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *GenericPutRequest) GetName() string {
 	if m != nil {
@@ -444,22 +836,47 @@ func (m *GenericPutRequest) GetConfig() []byte {
 	return nil
 }
 
+func (m *GenericPutRequest) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *GenericPutRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type GenericPutResponse struct {
+	// new resource version of the template
+	ResourceVersion int64 `protobuf:"varint,1,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
 }
 
 func (m *GenericPutResponse) Reset()                    { *m = GenericPutResponse{} }
 func (m *GenericPutResponse) String() string            { return proto.CompactTextString(m) }
 func (*GenericPutResponse) ProtoMessage()               {}
-func (*GenericPutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*GenericPutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *GenericPutResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
 
 type GenericGetRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 }
 
 func (m *GenericGetRequest) Reset()                    { *m = GenericGetRequest{} }
 func (m *GenericGetRequest) String() string            { return proto.CompactTextString(m) }
 func (*GenericGetRequest) ProtoMessage()               {}
-func (*GenericGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*GenericGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *GenericGetRequest) GetName() string {
 	if m != nil {
@@ -468,14 +885,22 @@ func (m *GenericGetRequest) GetName() string {
 	return ""
 }
 
+func (m *GenericGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type GenericGetResponse struct {
-	Config []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Config          []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion int64  `protobuf:"varint,2,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
 }
 
 func (m *GenericGetResponse) Reset()                    { *m = GenericGetResponse{} }
 func (m *GenericGetResponse) String() string            { return proto.CompactTextString(m) }
 func (*GenericGetResponse) ProtoMessage()               {}
-func (*GenericGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*GenericGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *GenericGetResponse) GetConfig() []byte {
 	if m != nil {
@@ -484,14 +909,25 @@ func (m *GenericGetResponse) GetConfig() []byte {
 	return nil
 }
 
+func (m *GenericGetResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
 type GenericDeleteRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// delete even if Profiles refer to it, leaving their references dangling
+	Force bool `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
 }
 
 func (m *GenericDeleteRequest) Reset()                    { *m = GenericDeleteRequest{} }
 func (m *GenericDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*GenericDeleteRequest) ProtoMessage()               {}
-func (*GenericDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*GenericDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *GenericDeleteRequest) GetName() string {
 	if m != nil {
@@ -500,13 +936,661 @@ func (m *GenericDeleteRequest) GetName() string {
 	return ""
 }
 
+func (m *GenericDeleteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GenericDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type GenericDeleteResponse struct {
 }
 
 func (m *GenericDeleteResponse) Reset()                    { *m = GenericDeleteResponse{} }
 func (m *GenericDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*GenericDeleteResponse) ProtoMessage()               {}
-func (*GenericDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*GenericDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+type GenericListRequest struct {
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *GenericListRequest) Reset()                    { *m = GenericListRequest{} }
+func (m *GenericListRequest) String() string            { return proto.CompactTextString(m) }
+func (*GenericListRequest) ProtoMessage()               {}
+func (*GenericListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *GenericListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type GenericListResponse struct {
+	// sorted template names
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+}
+
+func (m *GenericListResponse) Reset()                    { *m = GenericListResponse{} }
+func (m *GenericListResponse) String() string            { return proto.CompactTextString(m) }
+func (*GenericListResponse) ProtoMessage()               {}
+func (*GenericListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *GenericListResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type GenericWatchRequest struct {
+	// watch a single template by name (optional)
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *GenericWatchRequest) Reset()                    { *m = GenericWatchRequest{} }
+func (m *GenericWatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*GenericWatchRequest) ProtoMessage()               {}
+func (*GenericWatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *GenericWatchRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GenericWatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type GenericWatchResponse struct {
+	Event *storagepb.Event `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *GenericWatchResponse) Reset()                    { *m = GenericWatchResponse{} }
+func (m *GenericWatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*GenericWatchResponse) ProtoMessage()               {}
+func (*GenericWatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *GenericWatchResponse) GetEvent() *storagepb.Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type CloudPutRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// only update the template if it has this resource version (optional)
+	ResourceVersion int64 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *CloudPutRequest) Reset()                    { *m = CloudPutRequest{} }
+func (m *CloudPutRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloudPutRequest) ProtoMessage()               {}
+func (*CloudPutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *CloudPutRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CloudPutRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *CloudPutRequest) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *CloudPutRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type CloudPutResponse struct {
+	// new resource version of the template
+	ResourceVersion int64 `protobuf:"varint,1,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+}
+
+func (m *CloudPutResponse) Reset()                    { *m = CloudPutResponse{} }
+func (m *CloudPutResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloudPutResponse) ProtoMessage()               {}
+func (*CloudPutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *CloudPutResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+type CloudGetRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *CloudGetRequest) Reset()                    { *m = CloudGetRequest{} }
+func (m *CloudGetRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloudGetRequest) ProtoMessage()               {}
+func (*CloudGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *CloudGetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CloudGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type CloudGetResponse struct {
+	Config          []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion int64  `protobuf:"varint,2,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+}
+
+func (m *CloudGetResponse) Reset()                    { *m = CloudGetResponse{} }
+func (m *CloudGetResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloudGetResponse) ProtoMessage()               {}
+func (*CloudGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *CloudGetResponse) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *CloudGetResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+type CloudDeleteRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// delete even if Profiles refer to it, leaving their references dangling
+	Force bool `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *CloudDeleteRequest) Reset()                    { *m = CloudDeleteRequest{} }
+func (m *CloudDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloudDeleteRequest) ProtoMessage()               {}
+func (*CloudDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *CloudDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CloudDeleteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *CloudDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type CloudDeleteResponse struct {
+}
+
+func (m *CloudDeleteResponse) Reset()                    { *m = CloudDeleteResponse{} }
+func (m *CloudDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloudDeleteResponse) ProtoMessage()               {}
+func (*CloudDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+type CloudListRequest struct {
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *CloudListRequest) Reset()                    { *m = CloudListRequest{} }
+func (m *CloudListRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloudListRequest) ProtoMessage()               {}
+func (*CloudListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *CloudListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type CloudListResponse struct {
+	// sorted template names
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+}
+
+func (m *CloudListResponse) Reset()                    { *m = CloudListResponse{} }
+func (m *CloudListResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloudListResponse) ProtoMessage()               {}
+func (*CloudListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *CloudListResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type CloudWatchRequest struct {
+	// watch a single template by name (optional)
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *CloudWatchRequest) Reset()                    { *m = CloudWatchRequest{} }
+func (m *CloudWatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloudWatchRequest) ProtoMessage()               {}
+func (*CloudWatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *CloudWatchRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CloudWatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type CloudWatchResponse struct {
+	Event *storagepb.Event `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *CloudWatchResponse) Reset()                    { *m = CloudWatchResponse{} }
+func (m *CloudWatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloudWatchResponse) ProtoMessage()               {}
+func (*CloudWatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *CloudWatchResponse) GetEvent() *storagepb.Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type PartialPutRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// only update the template if it has this resource version (optional)
+	ResourceVersion int64 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *PartialPutRequest) Reset()                    { *m = PartialPutRequest{} }
+func (m *PartialPutRequest) String() string            { return proto.CompactTextString(m) }
+func (*PartialPutRequest) ProtoMessage()               {}
+func (*PartialPutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *PartialPutRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PartialPutRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *PartialPutRequest) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *PartialPutRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type PartialPutResponse struct {
+	// new resource version of the template
+	ResourceVersion int64 `protobuf:"varint,1,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+}
+
+func (m *PartialPutResponse) Reset()                    { *m = PartialPutResponse{} }
+func (m *PartialPutResponse) String() string            { return proto.CompactTextString(m) }
+func (*PartialPutResponse) ProtoMessage()               {}
+func (*PartialPutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *PartialPutResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+type PartialGetRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *PartialGetRequest) Reset()                    { *m = PartialGetRequest{} }
+func (m *PartialGetRequest) String() string            { return proto.CompactTextString(m) }
+func (*PartialGetRequest) ProtoMessage()               {}
+func (*PartialGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *PartialGetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PartialGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type PartialGetResponse struct {
+	Config          []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion int64  `protobuf:"varint,2,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+}
+
+func (m *PartialGetResponse) Reset()                    { *m = PartialGetResponse{} }
+func (m *PartialGetResponse) String() string            { return proto.CompactTextString(m) }
+func (*PartialGetResponse) ProtoMessage()               {}
+func (*PartialGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *PartialGetResponse) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *PartialGetResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+type PartialDeleteRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// delete even if templates include it, leaving their includes dangling
+	Force bool `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *PartialDeleteRequest) Reset()                    { *m = PartialDeleteRequest{} }
+func (m *PartialDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*PartialDeleteRequest) ProtoMessage()               {}
+func (*PartialDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *PartialDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PartialDeleteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PartialDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type PartialDeleteResponse struct {
+}
+
+func (m *PartialDeleteResponse) Reset()                    { *m = PartialDeleteResponse{} }
+func (m *PartialDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*PartialDeleteResponse) ProtoMessage()               {}
+func (*PartialDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+type PartialListRequest struct {
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *PartialListRequest) Reset()                    { *m = PartialListRequest{} }
+func (m *PartialListRequest) String() string            { return proto.CompactTextString(m) }
+func (*PartialListRequest) ProtoMessage()               {}
+func (*PartialListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *PartialListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type PartialListResponse struct {
+	// sorted template names
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+}
+
+func (m *PartialListResponse) Reset()                    { *m = PartialListResponse{} }
+func (m *PartialListResponse) String() string            { return proto.CompactTextString(m) }
+func (*PartialListResponse) ProtoMessage()               {}
+func (*PartialListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *PartialListResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type HistoryRequest struct {
+	// resource kind (groups, profiles, ignition, generic, cloud, or partials)
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *HistoryRequest) Reset()                    { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()               {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *HistoryRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *HistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HistoryRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type HistoryResponse struct {
+	// recorded revisions, oldest first
+	Revisions []*storagepb.Revision `protobuf:"bytes,1,rep,name=revisions" json:"revisions,omitempty"`
+}
+
+func (m *HistoryResponse) Reset()                    { *m = HistoryResponse{} }
+func (m *HistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*HistoryResponse) ProtoMessage()               {}
+func (*HistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *HistoryResponse) GetRevisions() []*storagepb.Revision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type RollbackRequest struct {
+	// resource kind (groups, profiles, ignition, generic, cloud, or partials)
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// resource version of the revision to restore (optional, defaults to the
+	// revision before the latest one)
+	ResourceVersion int64 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *RollbackRequest) Reset()                    { *m = RollbackRequest{} }
+func (m *RollbackRequest) String() string            { return proto.CompactTextString(m) }
+func (*RollbackRequest) ProtoMessage()               {}
+func (*RollbackRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *RollbackRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *RollbackRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RollbackRequest) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *RollbackRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type RollbackResponse struct {
+	// new resource version of the restored resource
+	ResourceVersion int64 `protobuf:"varint,1,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+}
+
+func (m *RollbackResponse) Reset()                    { *m = RollbackResponse{} }
+func (m *RollbackResponse) String() string            { return proto.CompactTextString(m) }
+func (*RollbackResponse) ProtoMessage()               {}
+func (*RollbackResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *RollbackResponse) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+type ValidateRequest struct {
+	// namespace of the resources, empty for the default namespace
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+}
+
+func (m *ValidateRequest) Reset()                    { *m = ValidateRequest{} }
+func (m *ValidateRequest) String() string            { return proto.CompactTextString(m) }
+func (*ValidateRequest) ProtoMessage()               {}
+func (*ValidateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *ValidateRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type ValidateResponse struct {
+	// problems found, ordered by kind and name
+	Problems []*storagepb.Problem `protobuf:"bytes,1,rep,name=problems" json:"problems,omitempty"`
+}
+
+func (m *ValidateResponse) Reset()                    { *m = ValidateResponse{} }
+func (m *ValidateResponse) String() string            { return proto.CompactTextString(m) }
+func (*ValidateResponse) ProtoMessage()               {}
+func (*ValidateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *ValidateResponse) GetProblems() []*storagepb.Problem {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
 
 func init() {
 	proto.RegisterType((*SelectGroupRequest)(nil), "serverpb.SelectGroupRequest")
@@ -521,6 +1605,8 @@ func init() {
 	proto.RegisterType((*GroupDeleteResponse)(nil), "serverpb.GroupDeleteResponse")
 	proto.RegisterType((*GroupListRequest)(nil), "serverpb.GroupListRequest")
 	proto.RegisterType((*GroupListResponse)(nil), "serverpb.GroupListResponse")
+	proto.RegisterType((*GroupWatchRequest)(nil), "serverpb.GroupWatchRequest")
+	proto.RegisterType((*GroupWatchResponse)(nil), "serverpb.GroupWatchResponse")
 	proto.RegisterType((*ProfilePutRequest)(nil), "serverpb.ProfilePutRequest")
 	proto.RegisterType((*ProfilePutResponse)(nil), "serverpb.ProfilePutResponse")
 	proto.RegisterType((*ProfileGetRequest)(nil), "serverpb.ProfileGetRequest")
@@ -529,56 +1615,117 @@ func init() {
 	proto.RegisterType((*ProfileDeleteResponse)(nil), "serverpb.ProfileDeleteResponse")
 	proto.RegisterType((*ProfileListRequest)(nil), "serverpb.ProfileListRequest")
 	proto.RegisterType((*ProfileListResponse)(nil), "serverpb.ProfileListResponse")
+	proto.RegisterType((*ProfileWatchRequest)(nil), "serverpb.ProfileWatchRequest")
+	proto.RegisterType((*ProfileWatchResponse)(nil), "serverpb.ProfileWatchResponse")
 	proto.RegisterType((*IgnitionPutRequest)(nil), "serverpb.IgnitionPutRequest")
 	proto.RegisterType((*IgnitionPutResponse)(nil), "serverpb.IgnitionPutResponse")
 	proto.RegisterType((*IgnitionGetRequest)(nil), "serverpb.IgnitionGetRequest")
 	proto.RegisterType((*IgnitionGetResponse)(nil), "serverpb.IgnitionGetResponse")
 	proto.RegisterType((*IgnitionDeleteRequest)(nil), "serverpb.IgnitionDeleteRequest")
 	proto.RegisterType((*IgnitionDeleteResponse)(nil), "serverpb.IgnitionDeleteResponse")
+	proto.RegisterType((*IgnitionListRequest)(nil), "serverpb.IgnitionListRequest")
+	proto.RegisterType((*IgnitionListResponse)(nil), "serverpb.IgnitionListResponse")
+	proto.RegisterType((*IgnitionWatchRequest)(nil), "serverpb.IgnitionWatchRequest")
+	proto.RegisterType((*IgnitionWatchResponse)(nil), "serverpb.IgnitionWatchResponse")
 	proto.RegisterType((*GenericPutRequest)(nil), "serverpb.GenericPutRequest")
 	proto.RegisterType((*GenericPutResponse)(nil), "serverpb.GenericPutResponse")
 	proto.RegisterType((*GenericGetRequest)(nil), "serverpb.GenericGetRequest")
 	proto.RegisterType((*GenericGetResponse)(nil), "serverpb.GenericGetResponse")
 	proto.RegisterType((*GenericDeleteRequest)(nil), "serverpb.GenericDeleteRequest")
 	proto.RegisterType((*GenericDeleteResponse)(nil), "serverpb.GenericDeleteResponse")
+	proto.RegisterType((*GenericListRequest)(nil), "serverpb.GenericListRequest")
+	proto.RegisterType((*GenericListResponse)(nil), "serverpb.GenericListResponse")
+	proto.RegisterType((*GenericWatchRequest)(nil), "serverpb.GenericWatchRequest")
+	proto.RegisterType((*GenericWatchResponse)(nil), "serverpb.GenericWatchResponse")
+	proto.RegisterType((*CloudPutRequest)(nil), "serverpb.CloudPutRequest")
+	proto.RegisterType((*CloudPutResponse)(nil), "serverpb.CloudPutResponse")
+	proto.RegisterType((*CloudGetRequest)(nil), "serverpb.CloudGetRequest")
+	proto.RegisterType((*CloudGetResponse)(nil), "serverpb.CloudGetResponse")
+	proto.RegisterType((*CloudDeleteRequest)(nil), "serverpb.CloudDeleteRequest")
+	proto.RegisterType((*CloudDeleteResponse)(nil), "serverpb.CloudDeleteResponse")
+	proto.RegisterType((*CloudListRequest)(nil), "serverpb.CloudListRequest")
+	proto.RegisterType((*CloudListResponse)(nil), "serverpb.CloudListResponse")
+	proto.RegisterType((*CloudWatchRequest)(nil), "serverpb.CloudWatchRequest")
+	proto.RegisterType((*CloudWatchResponse)(nil), "serverpb.CloudWatchResponse")
+	proto.RegisterType((*PartialPutRequest)(nil), "serverpb.PartialPutRequest")
+	proto.RegisterType((*PartialPutResponse)(nil), "serverpb.PartialPutResponse")
+	proto.RegisterType((*PartialGetRequest)(nil), "serverpb.PartialGetRequest")
+	proto.RegisterType((*PartialGetResponse)(nil), "serverpb.PartialGetResponse")
+	proto.RegisterType((*PartialDeleteRequest)(nil), "serverpb.PartialDeleteRequest")
+	proto.RegisterType((*PartialDeleteResponse)(nil), "serverpb.PartialDeleteResponse")
+	proto.RegisterType((*PartialListRequest)(nil), "serverpb.PartialListRequest")
+	proto.RegisterType((*PartialListResponse)(nil), "serverpb.PartialListResponse")
+	proto.RegisterType((*HistoryRequest)(nil), "serverpb.HistoryRequest")
+	proto.RegisterType((*HistoryResponse)(nil), "serverpb.HistoryResponse")
+	proto.RegisterType((*RollbackRequest)(nil), "serverpb.RollbackRequest")
+	proto.RegisterType((*RollbackResponse)(nil), "serverpb.RollbackResponse")
+	proto.RegisterType((*ValidateRequest)(nil), "serverpb.ValidateRequest")
+	proto.RegisterType((*ValidateResponse)(nil), "serverpb.ValidateResponse")
 }
 
 func init() { proto.RegisterFile("messages.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 538 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x55, 0x6f, 0x4f, 0xd3, 0x40,
-	0x18, 0x4f, 0x87, 0x4c, 0x7c, 0x30, 0xb8, 0xdd, 0x3a, 0x58, 0x78, 0x85, 0xa7, 0xc1, 0x05, 0xb1,
-	0x24, 0xf8, 0x46, 0x48, 0x88, 0x88, 0x2e, 0x8b, 0x09, 0x2f, 0xc8, 0xfc, 0x04, 0x6d, 0x79, 0x28,
-	0x8d, 0x5d, 0xaf, 0xf6, 0x6e, 0x44, 0x3e, 0x86, 0x2f, 0xfc, 0xbe, 0x66, 0xd7, 0xe7, 0xda, 0x6b,
-	0x99, 0xc0, 0x8c, 0xaf, 0x76, 0x7d, 0xfa, 0x7b, 0x7e, 0xff, 0x6e, 0xcb, 0x60, 0x63, 0x8a, 0x52,
-	0xfa, 0x11, 0x4a, 0x2f, 0xcb, 0x85, 0x12, 0x6c, 0x4d, 0x62, 0x7e, 0x83, 0x79, 0x16, 0x6c, 0x7f,
-	0x8e, 0x62, 0x75, 0x3d, 0x0b, 0xbc, 0x50, 0x4c, 0x0f, 0x42, 0x91, 0xa3, 0x90, 0x07, 0x53, 0x5f,
-	0x85, 0xd7, 0x81, 0xf8, 0x59, 0x1d, 0xa4, 0x12, 0xb9, 0x1f, 0xa1, 0xf9, 0xcc, 0x02, 0x73, 0x2a,
-	0xe8, 0xf8, 0x2f, 0x07, 0xd8, 0x37, 0x4c, 0x30, 0x54, 0xe3, 0x5c, 0xcc, 0xb2, 0x09, 0xfe, 0x98,
-	0xa1, 0x54, 0xec, 0x14, 0xda, 0x89, 0x1f, 0x60, 0x22, 0x07, 0xce, 0xce, 0xca, 0x70, 0xfd, 0x70,
-	0xe8, 0x19, 0x59, 0xef, 0x2e, 0xda, 0x3b, 0xd7, 0xd0, 0x51, 0xaa, 0xf2, 0xdb, 0x09, 0xed, 0x6d,
-	0x1f, 0xc1, 0xba, 0x35, 0x66, 0x1d, 0x58, 0xf9, 0x8e, 0xb7, 0x03, 0x67, 0xc7, 0x19, 0x3e, 0x9b,
-	0xcc, 0x8f, 0xcc, 0x85, 0xd5, 0x1b, 0x3f, 0x99, 0xe1, 0xa0, 0xa5, 0x67, 0xc5, 0xc3, 0x71, 0xeb,
-	0x83, 0xc3, 0x4f, 0xa0, 0x57, 0x13, 0x91, 0x99, 0x48, 0x25, 0xb2, 0x5d, 0x58, 0x8d, 0xe6, 0x03,
-	0x4d, 0xb2, 0x7e, 0xd8, 0xf1, 0xca, 0x4c, 0x5e, 0x01, 0x2c, 0x5e, 0xf3, 0xdf, 0x0e, 0xb8, 0xc5,
-	0xfe, 0x45, 0x2e, 0xae, 0xe2, 0x04, 0x4d, 0xa8, 0xb3, 0x46, 0xa8, 0xbd, 0x66, 0xa8, 0x3a, 0xfe,
-	0x7f, 0xc7, 0x1a, 0x41, 0xbf, 0x21, 0x43, 0xc1, 0xf6, 0xe1, 0x69, 0x56, 0x8c, 0x28, 0x1a, 0xb3,
-	0xa2, 0x19, 0xb0, 0x81, 0xf0, 0x23, 0x78, 0xa1, 0xe3, 0x5e, 0xcc, 0x94, 0x09, 0xf6, 0xd8, 0x66,
-	0x18, 0x74, 0xaa, 0xd5, 0x42, 0x9c, 0xbf, 0x24, 0xba, 0x31, 0x96, 0x74, 0x1b, 0xd0, 0x8a, 0x2f,
-	0x29, 0x53, 0x2b, 0xbe, 0xe4, 0xc7, 0xb4, 0xa6, 0x21, 0x4b, 0x5e, 0xc6, 0x6b, 0x60, 0xfa, 0xf9,
-	0x0b, 0x26, 0xa8, 0xf0, 0x6f, 0x0a, 0x7d, 0xe8, 0xd5, 0x50, 0xe4, 0xcd, 0xf8, 0x3d, 0x8f, 0xa5,
-	0x31, 0xc7, 0x4f, 0xa0, 0x6b, 0xcd, 0xc8, 0xcd, 0x10, 0xda, 0x5a, 0xce, 0xdc, 0xec, 0x5d, 0x3b,
-	0xf4, 0x9e, 0x7f, 0x82, 0x2e, 0x35, 0x6a, 0xf5, 0xb7, 0xdc, 0x05, 0xb8, 0xc0, 0x6c, 0x0a, 0xf2,
-	0xfa, 0xaa, 0x24, 0xbe, 0xa7, 0xc9, 0xb3, 0x72, 0xd5, 0xee, 0x72, 0x39, 0xf9, 0x5d, 0x70, 0x69,
-	0x76, 0x7f, 0xa7, 0x5b, 0xd0, 0x6f, 0xe0, 0xc8, 0x69, 0xe5, 0xdf, 0xee, 0x75, 0x04, 0xbd, 0xda,
-	0x94, 0xbc, 0x79, 0xb0, 0x46, 0xc2, 0xa6, 0xdb, 0x45, 0xe6, 0x4a, 0x0c, 0x3f, 0x05, 0xf6, 0x35,
-	0x4a, 0x63, 0x15, 0x8b, 0xd4, 0x2a, 0x98, 0xc1, 0x93, 0xd4, 0x9f, 0x22, 0xb9, 0xd3, 0x67, 0xb6,
-	0x09, 0xed, 0x50, 0xa4, 0x57, 0x71, 0xa4, 0x7f, 0x29, 0xcf, 0x27, 0xf4, 0x34, 0xff, 0x2e, 0xd4,
-	0x18, 0xc8, 0xf5, 0xb0, 0x22, 0xb6, 0x0a, 0x5e, 0x40, 0xcc, 0xdf, 0x55, 0x04, 0x76, 0xcb, 0x95,
-	0x9e, 0x53, 0xd3, 0x7b, 0x0b, 0x7d, 0x03, 0xaf, 0x17, 0xba, 0x88, 0x7b, 0x00, 0x9b, 0x4d, 0x30,
-	0xf9, 0xfb, 0x08, 0xdd, 0x31, 0xa6, 0x98, 0xc7, 0xe1, 0x3f, 0xe6, 0x76, 0x81, 0xd9, 0x04, 0x44,
-	0xfb, 0xa6, 0xa4, 0x7d, 0x20, 0xf5, 0x7e, 0xb9, 0xfe, 0x98, 0xd0, 0x7b, 0xe0, 0x12, 0xfa, 0xe1,
-	0xcc, 0x5b, 0xd0, 0x6f, 0x60, 0x0b, 0xf2, 0xa0, 0xad, 0xff, 0x42, 0xde, 0xff, 0x09, 0x00, 0x00,
-	0xff, 0xff, 0x8d, 0x61, 0x17, 0x92, 0xa3, 0x06, 0x00, 0x00,
+	// 965 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x98, 0xc1, 0x6f, 0xdb, 0x36,
+	0x14, 0xc6, 0x21, 0xbb, 0x49, 0x93, 0x97, 0x21, 0x76, 0x28, 0xb9, 0x35, 0x8a, 0x1d, 0x02, 0x1d,
+	0x06, 0x77, 0x2b, 0x94, 0x2d, 0xbd, 0x6c, 0x45, 0xd3, 0x74, 0x76, 0x3d, 0x77, 0x40, 0x0f, 0x81,
+	0x86, 0x25, 0xc3, 0x36, 0x2c, 0x90, 0xe4, 0x17, 0x57, 0x88, 0x2c, 0x7a, 0x92, 0x6c, 0x2c, 0xd7,
+	0x1d, 0x86, 0xed, 0xaf, 0xd9, 0x61, 0xff, 0xe0, 0x20, 0x89, 0x14, 0x49, 0xd5, 0x71, 0x2d, 0xdb,
+	0x59, 0x77, 0x8a, 0x48, 0xbe, 0xf7, 0xf1, 0xfb, 0xe8, 0x9f, 0x09, 0x39, 0xb0, 0x3f, 0xc6, 0x38,
+	0x76, 0x46, 0x18, 0x5b, 0x93, 0x88, 0x26, 0x94, 0xec, 0xc4, 0x18, 0xcd, 0x30, 0x9a, 0xb8, 0x8f,
+	0x7a, 0x23, 0x3f, 0x79, 0x3b, 0x75, 0x2d, 0x8f, 0x8e, 0x8f, 0x3c, 0x1a, 0x21, 0x8d, 0x8f, 0xc6,
+	0x4e, 0xe2, 0xbd, 0x75, 0xe9, 0x6f, 0xe2, 0x21, 0x4e, 0x68, 0xe4, 0x8c, 0x90, 0xff, 0x9d, 0xb8,
+	0xfc, 0x29, 0x97, 0x33, 0xff, 0xd6, 0x80, 0x7c, 0x87, 0x01, 0x7a, 0xc9, 0x20, 0xa2, 0xd3, 0x89,
+	0x8d, 0xbf, 0x4e, 0x31, 0x4e, 0xc8, 0x4b, 0xd8, 0x0e, 0x1c, 0x17, 0x83, 0xb8, 0xad, 0x1d, 0xd6,
+	0x3b, 0x7b, 0xc7, 0x1d, 0x8b, 0x6f, 0x6b, 0xbd, 0x5b, 0x6d, 0xbd, 0xc9, 0x4a, 0xfb, 0x61, 0x12,
+	0xdd, 0xd8, 0xac, 0x8f, 0x7c, 0x0c, 0xbb, 0xa1, 0x33, 0xc6, 0x78, 0xe2, 0x78, 0xd8, 0xae, 0x1d,
+	0x6a, 0x9d, 0x5d, 0x5b, 0x4c, 0x3c, 0xfa, 0x0a, 0xf6, 0xa4, 0x26, 0xd2, 0x84, 0xfa, 0x35, 0xde,
+	0xb4, 0xb5, 0xac, 0x2c, 0x7d, 0x24, 0x06, 0x6c, 0xcd, 0x9c, 0x60, 0xca, 0x5b, 0xf3, 0xc1, 0xb3,
+	0xda, 0x97, 0x9a, 0x79, 0x02, 0xba, 0x62, 0x21, 0x9e, 0xd0, 0x30, 0x46, 0xf2, 0x09, 0x6c, 0x8d,
+	0xd2, 0x89, 0x4c, 0x64, 0xef, 0xb8, 0x69, 0x15, 0x89, 0xad, 0xbc, 0x30, 0x5f, 0x36, 0xff, 0xd1,
+	0xc0, 0xc8, 0xfb, 0xcf, 0x22, 0x7a, 0xe5, 0x07, 0xc8, 0x23, 0x77, 0x4b, 0x91, 0x3f, 0x2d, 0x47,
+	0x56, 0xeb, 0xff, 0xdb, 0xd0, 0x7d, 0x68, 0x95, 0x4c, 0xb0, 0xd8, 0x4f, 0xe0, 0xfe, 0x24, 0x9f,
+	0x62, 0xc1, 0x89, 0x14, 0x9c, 0x17, 0xf3, 0x12, 0xf3, 0x02, 0x1a, 0xd9, 0x61, 0x9c, 0x4d, 0x13,
+	0x1e, 0x7b, 0xc9, 0x73, 0x5b, 0x1c, 0xcd, 0x7c, 0x06, 0x4d, 0x21, 0x5c, 0xf1, 0x13, 0x39, 0x65,
+	0xa6, 0x06, 0x58, 0x98, 0xda, 0x87, 0x9a, 0x3f, 0x64, 0x27, 0x53, 0xf3, 0x87, 0x4b, 0x6e, 0x3e,
+	0xc0, 0xea, 0x9b, 0x77, 0x81, 0x64, 0xe3, 0x57, 0x18, 0x60, 0x82, 0xab, 0xed, 0xdf, 0x02, 0x5d,
+	0xd1, 0xc8, 0x2d, 0x98, 0x9f, 0x33, 0x5b, 0x6f, 0xfc, 0xb8, 0x08, 0xa6, 0x08, 0x69, 0x65, 0xa1,
+	0x13, 0x38, 0x90, 0x3a, 0x58, 0x92, 0x0e, 0x6c, 0x67, 0x56, 0x39, 0x97, 0xef, 0x46, 0x61, 0xeb,
+	0xe6, 0xd7, 0xac, 0xfd, 0x22, 0xfd, 0xf2, 0xaf, 0x16, 0xe5, 0x39, 0x10, 0x59, 0x42, 0x1c, 0x26,
+	0xce, 0x30, 0x4c, 0xe6, 0x1c, 0x66, 0x3f, 0x9d, 0xb7, 0xf3, 0x65, 0xf3, 0x12, 0x0e, 0x18, 0x72,
+	0x12, 0x60, 0x95, 0x08, 0x7d, 0x8f, 0xbd, 0x2e, 0x10, 0x79, 0x83, 0x95, 0xbe, 0x03, 0x3f, 0x15,
+	0x26, 0x57, 0x05, 0x8e, 0xb4, 0xe1, 0x7e, 0x84, 0x31, 0x0d, 0x66, 0xd8, 0xae, 0x1f, 0x6a, 0x9d,
+	0x1d, 0x9b, 0x0f, 0x25, 0x83, 0x03, 0x5c, 0xd5, 0xe0, 0x8f, 0x60, 0xb0, 0xb9, 0x35, 0xa0, 0x4c,
+	0xef, 0x92, 0x2b, 0x1a, 0x79, 0xdc, 0x61, 0x3e, 0x30, 0x1f, 0x42, 0xab, 0xa4, 0xcd, 0x60, 0x3d,
+	0x2e, 0x8c, 0x2f, 0x8f, 0x6b, 0x1f, 0x74, 0xa5, 0x87, 0xa5, 0xb5, 0x60, 0x87, 0x45, 0xe1, 0xc8,
+	0xce, 0x8b, 0x5b, 0xd4, 0x98, 0xbd, 0x42, 0x66, 0x0d, 0x70, 0x5f, 0x80, 0xa1, 0x8a, 0x54, 0x44,
+	0xf7, 0x2f, 0x0d, 0xc8, 0xb7, 0xa3, 0xd0, 0x4f, 0x7c, 0x1a, 0x4a, 0xf0, 0x12, 0xb8, 0x97, 0xee,
+	0xc1, 0x6c, 0x64, 0xcf, 0xe4, 0x01, 0x6c, 0x7b, 0x34, 0xbc, 0xf2, 0x47, 0x99, 0x8b, 0x8f, 0x6c,
+	0x36, 0x22, 0x8f, 0xa1, 0x99, 0x62, 0x30, 0x8d, 0x3c, 0xbc, 0x9c, 0x61, 0x14, 0xfb, 0x34, 0xcc,
+	0x0e, 0xbf, 0x6e, 0x37, 0xf8, 0xfc, 0x79, 0x3e, 0xad, 0x66, 0xb9, 0x57, 0xce, 0xf2, 0x12, 0x74,
+	0xc5, 0x0a, 0x8b, 0x32, 0x4f, 0x5f, 0x9b, 0xab, 0x6f, 0x7e, 0x23, 0xc2, 0x0c, 0x70, 0x61, 0x98,
+	0xc5, 0xa7, 0xfa, 0x03, 0xe8, 0x8a, 0x0e, 0x73, 0x22, 0x4e, 0x40, 0x7b, 0xef, 0x09, 0xd4, 0xe6,
+	0x3b, 0xbc, 0x84, 0x16, 0x57, 0x56, 0x29, 0xaf, 0x6c, 0xf2, 0x16, 0xd2, 0xdb, 0xf0, 0xa0, 0xbc,
+	0x01, 0x43, 0xfd, 0xa9, 0x08, 0xb5, 0x3c, 0xeb, 0x4f, 0xc0, 0x50, 0x9b, 0xd8, 0x51, 0x18, 0xb0,
+	0x95, 0x15, 0x65, 0xa4, 0xef, 0xda, 0xf9, 0xc0, 0x7c, 0x2d, 0xaa, 0x15, 0xa6, 0xab, 0x7f, 0x02,
+	0xa7, 0xd0, 0x2a, 0x29, 0x55, 0x04, 0xfb, 0x4f, 0x0d, 0x0e, 0x06, 0x18, 0x62, 0xe4, 0x7b, 0x1f,
+	0x9a, 0xeb, 0x53, 0x20, 0xb2, 0x93, 0xea, 0x58, 0xf7, 0x8b, 0x28, 0x6b, 0x51, 0x7d, 0x01, 0x44,
+	0x96, 0xd9, 0x1c, 0xd4, 0xbf, 0x80, 0xc1, 0x84, 0xef, 0x86, 0xe9, 0x87, 0xd0, 0x2a, 0xe9, 0x8b,
+	0xdb, 0x9b, 0x2d, 0x2c, 0x4f, 0xf4, 0x67, 0xa0, 0x2b, 0x3d, 0x0b, 0x81, 0x1e, 0x14, 0xc5, 0x6b,
+	0xf2, 0xfc, 0x02, 0x0c, 0x55, 0xa8, 0x22, 0xce, 0x7f, 0x68, 0xd0, 0xe8, 0x05, 0x74, 0x3a, 0xfc,
+	0xd0, 0x30, 0x9f, 0x40, 0x53, 0xf8, 0xa8, 0x8e, 0x72, 0x8f, 0xc5, 0x58, 0x0b, 0xe4, 0xef, 0xa1,
+	0x29, 0x44, 0x36, 0x87, 0xf1, 0xcf, 0x40, 0x32, 0xd9, 0xbb, 0x81, 0xb8, 0x05, 0xba, 0xa2, 0x2e,
+	0xde, 0x96, 0xb3, 0xe9, 0xe5, 0x01, 0x7e, 0x0c, 0x07, 0x52, 0xc7, 0x42, 0x7c, 0xfb, 0xac, 0x74,
+	0x4d, 0x78, 0x9f, 0x03, 0x91, 0x65, 0x56, 0xb8, 0x89, 0xcf, 0x9c, 0x28, 0xf1, 0x9d, 0xe0, 0x7f,
+	0x70, 0x13, 0xcb, 0x4e, 0x56, 0xba, 0x89, 0x99, 0xc0, 0xba, 0x37, 0xb1, 0x2c, 0xb3, 0xd1, 0x9b,
+	0x98, 0x09, 0xdf, 0xd9, 0x4d, 0x5c, 0xd2, 0x97, 0xde, 0xa3, 0xf3, 0x85, 0x4a, 0x37, 0xb1, 0xd2,
+	0xb3, 0x10, 0xe5, 0x73, 0xd8, 0x7f, 0xed, 0xa7, 0x84, 0xdd, 0x48, 0x99, 0xae, 0xfd, 0x90, 0xbf,
+	0x2a, 0x67, 0xcf, 0x45, 0xce, 0xda, 0x6d, 0x39, 0xeb, 0x65, 0x13, 0xaf, 0xa0, 0x51, 0xe8, 0x32,
+	0x03, 0x5f, 0xc0, 0x6e, 0x84, 0x33, 0x3f, 0x3d, 0x50, 0xfe, 0x26, 0xaf, 0x4b, 0x70, 0xdb, 0x6c,
+	0xcd, 0x16, 0x55, 0xe6, 0xef, 0x1a, 0x34, 0x6c, 0x1a, 0x04, 0xae, 0xe3, 0x5d, 0x57, 0xf5, 0xb7,
+	0xc9, 0xab, 0x59, 0x78, 0xa8, 0xce, 0xf6, 0x11, 0x34, 0xce, 0x9d, 0xc0, 0x1f, 0x3a, 0x02, 0x9b,
+	0xc5, 0x9f, 0x5f, 0x17, 0x9a, 0xa2, 0x41, 0xf9, 0x11, 0xe4, 0x06, 0x38, 0xbe, 0xe5, 0x47, 0x50,
+	0xba, 0x64, 0x17, 0x35, 0xee, 0x76, 0xf6, 0xef, 0xb8, 0xa7, 0xff, 0x0e, 0x00, 0x48, 0xaf, 0x64,
+	0x36, 0xef, 0x13, 0x00, 0x00,
 }
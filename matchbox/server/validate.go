@@ -0,0 +1,137 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"context"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// Validate returns the Problems of the resources of a namespace, ordered by
+// kind and name. Problems are Groups and Profiles which cannot be parsed
+// (such as Groups with invalid MAC selectors) if the Store is a
+// storage.Checker, Groups without a Profile or whose Profile does not exist,
+// Groups and Profiles with secret values which are not encrypted or cannot
+// be decrypted, Groups which are ambiguous with other Groups, Profiles which
+// refer to a
+// parent Profile or templates which do not exist, Profiles which inherit
+// from themselves, and templates which include partial templates which do
+// not exist.
+func (s *server) Validate(ctx context.Context, req *pb.ValidateRequest) ([]*storagepb.Problem, error) {
+	ns, err := s.namespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var problems []*storagepb.Problem
+	if checker, ok := ns.store.(storage.Checker); ok {
+		if problems, err = checker.Check(); err != nil {
+			return nil, err
+		}
+	}
+
+	profiles, err := ns.store.ProfileList()
+	if err != nil {
+		return nil, err
+	}
+	profileIDs := make(map[string]bool)
+	for _, profile := range profiles {
+		profileIDs[profile.Id] = true
+	}
+	groups, err := ns.store.GroupList()
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.Profile == "" {
+			problems = append(problems, problem("groups", group.Id, "no profile"))
+		} else if !profileIDs[group.Profile] {
+			problems = append(problems, problem("groups", group.Id, "profile %q does not exist", group.Profile))
+		}
+		if ambiguous := ambiguousGroups(group, groups); len(ambiguous) > 0 {
+			problems = append(problems, problem("groups", group.Id, "may match the same machines as groups %s with the same rank", strings.Join(ambiguous, ", ")))
+		}
+		secretProblems, err := s.secretProblems("groups", group.Id, group.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, secretProblems...)
+	}
+
+	names := map[string]map[string]bool{"profiles": profileIDs}
+	for _, kind := range []string{"ignition", "generic", "cloud"} {
+		if names[kind], err = resourceNames(ns.store, kind); err != nil {
+			return nil, err
+		}
+	}
+	for _, profile := range profiles {
+		for _, ref := range profileReferences(profile) {
+			if !names[ref.kind][ref.name] {
+				problems = append(problems, problem("profiles", profile.Id, "%s %q does not exist", kindDescriptions[ref.kind], ref.name))
+			}
+		}
+		if cycle, ok := checkProfileCycle(ns.store, profile).(*ProfileCycleError); ok {
+			problems = append(problems, problem("profiles", profile.Id, "inherits from itself through %s", strings.Join(cycle.Profiles, " -> ")))
+		}
+		secretProblems, err := s.secretProblems("profiles", profile.Id, profile.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, secretProblems...)
+	}
+
+	includeProblems, err := includeProblems(ns.store)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, includeProblems...)
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Kind != problems[j].Kind {
+			return problems[i].Kind < problems[j].Kind
+		}
+		return problems[i].Name < problems[j].Name
+	})
+	return problems, nil
+}
+
+// includeProblems returns a Problem for each template which includes a
+// template which no partial template provides.
+func includeProblems(store storage.Store) ([]*storagepb.Problem, error) {
+	provided, err := partialNames(store)
+	if err != nil {
+		return nil, err
+	}
+	var problems []*storagepb.Problem
+	for _, kind := range templateKinds {
+		names, err := templateList(store, kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			contents, err := templateGet(store, kind, name)
+			if err != nil {
+				return nil, err
+			}
+			for _, include := range TemplateIncludes(name, contents) {
+				if _, ok := provided[include]; !ok {
+					problems = append(problems, problem(kind, name, "includes partial template %q which does not exist", include))
+				}
+			}
+		}
+	}
+	return problems, nil
+}
+
+// problem returns a Problem of a resource with a formatted message.
+func problem(kind, name, format string, args ...interface{}) *storagepb.Problem {
+	return &storagepb.Problem{
+		Kind:    kind,
+		Name:    name,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
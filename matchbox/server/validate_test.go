@@ -0,0 +1,46 @@
+package server
+
+import (
+	"testing"
+
+	"context"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/coreos/matchbox/matchbox/server/serverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestValidate(t *testing.T) {
+	dangling := &storagepb.Group{Id: "dangling", Profile: "missing"}
+	store := &fake.FixedStore{
+		Groups: map[string]*storagepb.Group{
+			fake.Group.Id:           fake.Group,
+			fake.GroupNoMetadata.Id: fake.GroupNoMetadata,
+			dangling.Id:             dangling,
+		},
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML},
+	}
+	srv := NewServer(&Config{Store: store})
+
+	// assert that dangling Profile and template references and ambiguous
+	// Groups are reported
+	problems, err := srv.Validate(context.Background(), &pb.ValidateRequest{})
+	assert.Nil(t, err)
+	expected := []*storagepb.Problem{
+		{Kind: "groups", Name: "dangling", Message: `profile "missing" does not exist`},
+		{Kind: "groups", Name: fake.GroupNoMetadata.Id, Message: "no profile"},
+		{Kind: "groups", Name: fake.GroupNoMetadata.Id, Message: "may match the same machines as groups test-group with the same rank"},
+		{Kind: "groups", Name: fake.Group.Id, Message: "may match the same machines as groups group-no-metadata with the same rank"},
+		{Kind: "profiles", Name: fake.Profile.Id, Message: `Generic template "generic.tmpl" does not exist`},
+		{Kind: "profiles", Name: fake.Profile.Id, Message: `Cloud-Config template "cloud-config.tmpl" does not exist`},
+	}
+	assert.Equal(t, expected, problems)
+}
+
+func TestValidate_BrokenStore(t *testing.T) {
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
+	_, err := srv.Validate(context.Background(), &pb.ValidateRequest{})
+	assert.Error(t, err)
+}
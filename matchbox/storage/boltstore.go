@@ -0,0 +1,772 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	bolt "github.com/coreos/bbolt"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+const defaultBoltTimeout = 1 * time.Second
+
+var (
+	errNoBoltPath = errors.New("storage: No bolt database path provided")
+	// resource kinds, each stored in a top-level bucket named after the
+	// corresponding -data-path directory
+	boltBuckets = []string{"groups", "profiles", "ignition", "generic", "cloud", "partials"}
+	// bucket of database metadata, such as the revision of the last write
+	boltMetaBucket  = []byte("meta")
+	boltRevisionKey = []byte("revision")
+	// bucket of the resource version of each resource, keyed by kind/name
+	boltVersionsBucket = []byte("versions")
+	// bucket of recorded Revisions, keyed by kind/name, a 0 byte, and a
+	// sequence number, so a resource's Revisions are adjacent and ordered
+	boltHistoryBucket = []byte("history")
+	// bucket of a nested bucket per namespace, which holds buckets of the
+	// above kinds, versions, and history for the namespace's resources
+	boltNamespacesBucket = []byte(namespacesDir)
+)
+
+// BoltConfig initializes a boltStore.
+type BoltConfig struct {
+	// Path to the database file, created if it does not exist
+	Path string
+	// Import resources from a -data-path directory tree into the database
+	// when it contains no resources yet (optional)
+	ImportRoot string
+	Logger     *logrus.Logger
+}
+
+// boltStore implements the Store interface using an embedded bolt database.
+// Each resource kind has its own bucket keyed by resource name and every
+// write is performed in its own transaction, which also increments the
+// database revision reported in watch Events. A resource's version is the
+// database revision of its last write.
+type boltStore struct {
+	db       *bolt.DB
+	logger   *logrus.Logger
+	watchers *watchHub
+	// name of the namespace's bucket, nil for the default namespace
+	namespace []byte
+	// Revision appended by writes, or nil (see Recording)
+	revision *storagepb.Revision
+}
+
+// NewBoltStore opens (or creates) the bolt database at the configured path
+// and returns a new bolt-backed Store.
+func NewBoltStore(config *BoltConfig) (Store, error) {
+	if config.Path == "" {
+		return nil, errNoBoltPath
+	}
+	db, err := bolt.Open(config.Path, 0600, &bolt.Options{Timeout: defaultBoltTimeout})
+	if err != nil {
+		return nil, err
+	}
+	s := &boltStore{
+		db:       db,
+		logger:   config.Logger,
+		watchers: &watchHub{},
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range boltBuckets {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		for _, name := range [][]byte{boltMetaBucket, boltVersionsBucket, boltHistoryBucket, boltNamespacesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		if config.ImportRoot == "" {
+			return nil
+		}
+		if !isEmpty(tx) {
+			if s.logger != nil {
+				s.logger.Infof("Skipping import of %s, bolt database %s is not empty", config.ImportRoot, config.Path)
+			}
+			return nil
+		}
+		return s.importDir(tx, Dir(config.ImportRoot))
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Namespace returns a boltStore of the resources of a namespace, stored in
+// nested buckets of the namespaces bucket, which are created by the first
+// write. The database revision is shared by all namespaces.
+func (s *boltStore) Namespace(name string) (Store, error) {
+	if !ValidNamespace(name) {
+		return nil, ErrInvalidNamespace
+	}
+	return &boltStore{
+		db:        s.db,
+		logger:    s.logger,
+		watchers:  &watchHub{},
+		namespace: []byte(name),
+	}, nil
+}
+
+// HasNamespace returns true if a namespace has been written to.
+func (s *boltStore) HasNamespace(name string) (bool, error) {
+	var ok bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		ok = tx.Bucket(boltNamespacesBucket).Bucket([]byte(name)) != nil
+		return nil
+	})
+	return ok, err
+}
+
+// GroupPut writes the given Group.
+func (s *boltStore) GroupPut(group *storagepb.Group) (int64, error) {
+	data, err := marshalGroup(group)
+	if err != nil {
+		return 0, err
+	}
+	event := putEvent("groups", group.Id)
+	event.Group = group
+	return s.put(event, data, group.ResourceVersion)
+}
+
+// GroupGet returns a machine Group by id.
+func (s *boltStore) GroupGet(id string) (*storagepb.Group, error) {
+	data, version, err := s.get("groups", id)
+	if err != nil {
+		return nil, err
+	}
+	group, err := storagepb.ParseGroup(data)
+	if err != nil {
+		return nil, err
+	}
+	group.ResourceVersion = version
+	return group, nil
+}
+
+// GroupDelete deletes a machine Group by id.
+func (s *boltStore) GroupDelete(id string) error {
+	return s.delete("groups", id)
+}
+
+// GroupList lists all machine Groups.
+func (s *boltStore) GroupList() ([]*storagepb.Group, error) {
+	var groups []*storagepb.Group
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return s.forEach(tx, []byte("groups"), func(k, v []byte) error {
+			group, err := storagepb.ParseGroup(v)
+			if err == nil {
+				group.ResourceVersion = s.version(tx, "groups", string(k))
+				groups = append(groups, group)
+			} else if s.logger != nil {
+				s.logger.Warningf("Group %q: %v", k, err)
+			}
+			return nil
+		})
+	})
+	return groups, err
+}
+
+// ProfilePut writes the given Profile.
+func (s *boltStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	data, err := marshalProfile(profile)
+	if err != nil {
+		return 0, err
+	}
+	event := putEvent("profiles", profile.Id)
+	event.Profile = profile
+	return s.put(event, data, profile.ResourceVersion)
+}
+
+// ProfileGet gets a profile by id.
+func (s *boltStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	data, version, err := s.get("profiles", id)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := parseValidProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	profile.ResourceVersion = version
+	return profile, nil
+}
+
+// ProfileDelete deletes a profile by id.
+func (s *boltStore) ProfileDelete(id string) error {
+	return s.delete("profiles", id)
+}
+
+// ProfileList lists all profiles.
+func (s *boltStore) ProfileList() ([]*storagepb.Profile, error) {
+	var profiles []*storagepb.Profile
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return s.forEach(tx, []byte("profiles"), func(k, v []byte) error {
+			profile, err := parseValidProfile(v)
+			if err == nil {
+				profile.ResourceVersion = s.version(tx, "profiles", string(k))
+				profiles = append(profiles, profile)
+			} else if s.logger != nil {
+				s.logger.Warningf("Profile %q: %v", k, err)
+			}
+			return nil
+		})
+	})
+	return profiles, err
+}
+
+// Check returns a Problem for each group or profile whose value cannot be
+// parsed.
+func (s *boltStore) Check() ([]*storagepb.Problem, error) {
+	var problems []*storagepb.Problem
+	for _, kind := range checkKinds {
+		names, err := s.names(kind)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, checkNames(kind, names, getter(s, kind))...)
+	}
+	return problems, nil
+}
+
+// IgnitionPut creates or updates an Ignition template.
+func (s *boltStore) IgnitionPut(name string, config []byte, version int64) (int64, error) {
+	event := putEvent("ignition", name)
+	event.Template = config
+	return s.put(event, config, version)
+}
+
+// IgnitionGet gets an Ignition template by name.
+func (s *boltStore) IgnitionGet(name string) (string, error) {
+	data, _, err := s.get("ignition", name)
+	return string(data), err
+}
+
+// IgnitionVersion gets the resource version of an Ignition template.
+func (s *boltStore) IgnitionVersion(name string) (int64, error) {
+	_, version, err := s.get("ignition", name)
+	return version, err
+}
+
+// IgnitionDelete deletes an Ignition template by name.
+func (s *boltStore) IgnitionDelete(name string) error {
+	return s.delete("ignition", name)
+}
+
+// IgnitionList lists the names of all Ignition templates.
+func (s *boltStore) IgnitionList() ([]string, error) {
+	return s.names("ignition")
+}
+
+// GenericPut creates or updates an Generic template.
+func (s *boltStore) GenericPut(name string, config []byte, version int64) (int64, error) {
+	event := putEvent("generic", name)
+	event.Template = config
+	return s.put(event, config, version)
+}
+
+// GenericGet gets an Generic template by name.
+func (s *boltStore) GenericGet(name string) (string, error) {
+	data, _, err := s.get("generic", name)
+	return string(data), err
+}
+
+// GenericVersion gets the resource version of a Generic template.
+func (s *boltStore) GenericVersion(name string) (int64, error) {
+	_, version, err := s.get("generic", name)
+	return version, err
+}
+
+// GenericDelete deletes an Generic template by name.
+func (s *boltStore) GenericDelete(name string) error {
+	return s.delete("generic", name)
+}
+
+// GenericList lists the names of all Generic templates.
+func (s *boltStore) GenericList() ([]string, error) {
+	return s.names("generic")
+}
+
+// CloudPut creates or updates a Cloud-Config template.
+func (s *boltStore) CloudPut(name string, config []byte, version int64) (int64, error) {
+	event := putEvent("cloud", name)
+	event.Template = config
+	return s.put(event, config, version)
+}
+
+// CloudGet gets a Cloud-Config template by name.
+func (s *boltStore) CloudGet(name string) (string, error) {
+	data, _, err := s.get("cloud", name)
+	return string(data), err
+}
+
+// CloudVersion gets the resource version of a Cloud-Config template.
+func (s *boltStore) CloudVersion(name string) (int64, error) {
+	_, version, err := s.get("cloud", name)
+	return version, err
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *boltStore) CloudDelete(name string) error {
+	return s.delete("cloud", name)
+}
+
+// CloudList lists the names of all Cloud-Config templates.
+func (s *boltStore) CloudList() ([]string, error) {
+	return s.names("cloud")
+}
+
+// PartialPut creates or updates a partial template.
+func (s *boltStore) PartialPut(name string, config []byte, version int64) (int64, error) {
+	event := putEvent("partials", name)
+	event.Template = config
+	return s.put(event, config, version)
+}
+
+// PartialGet gets a partial template by name.
+func (s *boltStore) PartialGet(name string) (string, error) {
+	data, _, err := s.get("partials", name)
+	return string(data), err
+}
+
+// PartialVersion gets the resource version of a partial template.
+func (s *boltStore) PartialVersion(name string) (int64, error) {
+	_, version, err := s.get("partials", name)
+	return version, err
+}
+
+// PartialDelete deletes a partial template by name.
+func (s *boltStore) PartialDelete(name string) error {
+	return s.delete("partials", name)
+}
+
+// PartialList lists the names of all partial templates.
+func (s *boltStore) PartialList() ([]string, error) {
+	return s.names("partials")
+}
+
+// Watch returns a channel of Events for writes to the database.
+func (s *boltStore) Watch(ctx context.Context) (<-chan *storagepb.Event, error) {
+	return s.watchers.watch(ctx), nil
+}
+
+// HistoryAppend records a Revision of a resource.
+func (s *boltStore) HistoryAppend(revision *storagepb.Revision) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := s.createBuckets(tx); err != nil {
+			return err
+		}
+		return s.appendHistory(tx, revision)
+	})
+}
+
+// Recording returns a boltStore of the same resources whose writes append a
+// Revision in their transaction.
+func (s *boltStore) Recording(revision *storagepb.Revision) Store {
+	recording := *s
+	recording.revision = revision
+	return &recording
+}
+
+// History returns the recorded Revisions of a resource, oldest first.
+func (s *boltStore) History(kind, name string) ([]*storagepb.Revision, error) {
+	var revisions []*storagepb.Revision
+	prefix := boltHistoryPrefix(kind, name)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := s.bucket(tx, boltHistoryBucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			revision := new(storagepb.Revision)
+			if err := json.Unmarshal(v, revision); err != nil {
+				return err
+			}
+			revisions = append(revisions, revision)
+		}
+		return nil
+	})
+	return revisions, err
+}
+
+// Close releases the database file.
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+// get returns a copy of the named value in a bucket and its resource version
+// or an error satisfying os.IsNotExist if there is no such value.
+func (s *boltStore) get(bucket, name string) ([]byte, int64, error) {
+	var data []byte
+	var version int64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var value []byte
+		if b := s.bucket(tx, []byte(bucket)); b != nil {
+			value = b.Get(boltKey(name))
+		}
+		if value == nil {
+			return notExist("get", path.Join(bucket, name))
+		}
+		// values are only valid for the life of the transaction
+		data = append([]byte{}, value...)
+		version = s.version(tx, bucket, name)
+		return nil
+	})
+	return data, version, err
+}
+
+// names returns the keys of a bucket, in byte order.
+func (s *boltStore) names(bucket string) ([]string, error) {
+	var names []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return s.forEach(tx, []byte(bucket), func(k, v []byte) error {
+			names = append(names, string(k))
+			return nil
+		})
+	})
+	return names, err
+}
+
+// put writes the value of the resource a put Event describes, unless a
+// non-zero expected version differs from the resource's version, publishes
+// the Event, and returns the new version.
+func (s *boltStore) put(event *storagepb.Event, value []byte, expected int64) (int64, error) {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if err := s.createBuckets(tx); err != nil {
+			return err
+		}
+		if expected != 0 {
+			var current int64
+			if s.bucket(tx, []byte(event.Kind)).Get(boltKey(event.Name)) != nil {
+				current = s.version(tx, event.Kind, event.Name)
+			}
+			if current != expected {
+				return ErrVersionConflict
+			}
+		}
+		var err error
+		if event.Revision, err = incrementRevision(tx); err != nil {
+			return err
+		}
+		if err := s.putVersioned(tx, event.Kind, event.Name, value, event.Revision); err != nil {
+			return err
+		}
+		if s.revision != nil {
+			s.revision.ResourceVersion = event.Revision
+			return s.appendHistory(tx, s.revision)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	// events carry the written resource with its new version
+	if event.Group != nil {
+		event.Group = withGroupVersion(event.Group, event.Revision)
+	}
+	if event.Profile != nil {
+		event.Profile = withProfileVersion(event.Profile, event.Revision)
+	}
+	s.watchers.publish(event)
+	return event.Revision, nil
+}
+
+// delete removes the named value from a bucket or returns an error
+// satisfying os.IsNotExist if there is no such value.
+func (s *boltStore) delete(bucket, name string) error {
+	event := deleteEvent(bucket, name)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := s.bucket(tx, []byte(bucket))
+		if b == nil || b.Get(boltKey(name)) == nil {
+			return notExist("delete", path.Join(bucket, name))
+		}
+		if err := b.Delete(boltKey(name)); err != nil {
+			return err
+		}
+		if err := s.bucket(tx, boltVersionsBucket).Delete(boltVersionKey(bucket, name)); err != nil {
+			return err
+		}
+		var err error
+		if event.Revision, err = incrementRevision(tx); err != nil {
+			return err
+		}
+		if s.revision != nil {
+			return s.appendHistory(tx, s.revision)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.watchers.publish(event)
+	return nil
+}
+
+// importDir copies the resources of a -data-path directory tree into the
+// buckets of the given transaction. Groups and profiles are validated, so an
+// invalid file aborts the whole import. Group and profile files may be JSON
+// or YAML, but are stored as JSON. All imported resources have the same
+// resource version.
+func (s *boltStore) importDir(tx *bolt.Tx, dir Dir) error {
+	revision, err := incrementRevision(tx)
+	if err != nil {
+		return err
+	}
+	groups, err := dir.readDir("groups")
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, finfo := range groups {
+		if _, ok := resourceName(finfo); !ok {
+			continue
+		}
+		data, err := dir.readFile(filepath.Join("groups", finfo.Name()))
+		if err != nil {
+			return err
+		}
+		group, err := storagepb.ParseGroupFile(finfo.Name(), data)
+		if err != nil {
+			return &os.PathError{Op: "import", Path: filepath.Join(string(dir), "groups", finfo.Name()), Err: err}
+		}
+		if data, err = marshalGroup(group); err != nil {
+			return err
+		}
+		if err := s.putVersioned(tx, "groups", group.Id, data, revision); err != nil {
+			return err
+		}
+	}
+	profiles, err := dir.readDir("profiles")
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, finfo := range profiles {
+		if _, ok := resourceName(finfo); !ok {
+			continue
+		}
+		data, err := dir.readFile(filepath.Join("profiles", finfo.Name()))
+		if err != nil {
+			return err
+		}
+		profile, err := storagepb.ParseProfileFile(finfo.Name(), data)
+		if err == nil {
+			err = profile.AssertValid()
+		}
+		if err != nil {
+			return &os.PathError{Op: "import", Path: filepath.Join(string(dir), "profiles", finfo.Name()), Err: err}
+		}
+		if data, err = marshalProfile(profile); err != nil {
+			return err
+		}
+		if err := s.putVersioned(tx, "profiles", profile.Id, data, revision); err != nil {
+			return err
+		}
+	}
+	// templates may be nested in subdirectories, named by relative path, and
+	// are found like the fileStore finds them
+	for _, kind := range []string{"ignition", "generic", "cloud", "partials"} {
+		names, err := dir.templateList(kind)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			data, err := dir.readFile(filepath.Join(kind, filepath.FromSlash(name)))
+			if err != nil {
+				return err
+			}
+			if err := s.putVersioned(tx, kind, name, data, revision); err != nil {
+				return err
+			}
+		}
+	}
+	if s.logger != nil {
+		s.logger.Infof("Imported %d groups and %d profiles from %s", len(groups), len(profiles), dir)
+	}
+	return nil
+}
+
+// incrementRevision increments and returns the database revision.
+func incrementRevision(tx *bolt.Tx) (int64, error) {
+	meta := tx.Bucket(boltMetaBucket)
+	var revision int64
+	if value := meta.Get(boltRevisionKey); len(value) == 8 {
+		revision = int64(binary.BigEndian.Uint64(value))
+	}
+	revision++
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(revision))
+	return revision, meta.Put(boltRevisionKey, value)
+}
+
+// bucket returns the named bucket of the store's namespace, or nil if the
+// namespace has not been written to yet.
+func (s *boltStore) bucket(tx *bolt.Tx, name []byte) *bolt.Bucket {
+	if s.namespace == nil {
+		return tx.Bucket(name)
+	}
+	namespace := tx.Bucket(boltNamespacesBucket).Bucket(s.namespace)
+	if namespace == nil {
+		return nil
+	}
+	return namespace.Bucket(name)
+}
+
+// createBuckets creates the buckets of the store's namespace if needed, so
+// namespaces are only created by writes.
+func (s *boltStore) createBuckets(tx *bolt.Tx) error {
+	if s.namespace == nil {
+		return nil
+	}
+	b, err := tx.Bucket(boltNamespacesBucket).CreateBucketIfNotExists(s.namespace)
+	if err != nil {
+		return err
+	}
+	for _, kind := range boltBuckets {
+		if _, err := b.CreateBucketIfNotExists([]byte(kind)); err != nil {
+			return err
+		}
+	}
+	for _, name := range [][]byte{boltVersionsBucket, boltHistoryBucket} {
+		if _, err := b.CreateBucketIfNotExists(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEach calls fn for each key and value of the named bucket of the store's
+// namespace, if the bucket exists.
+func (s *boltStore) forEach(tx *bolt.Tx, name []byte, fn func(k, v []byte) error) error {
+	b := s.bucket(tx, name)
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(fn)
+}
+
+// appendHistory appends a Revision to the history bucket of the given
+// transaction, whose buckets must exist.
+func (s *boltStore) appendHistory(tx *bolt.Tx, revision *storagepb.Revision) error {
+	data, err := json.Marshal(revision)
+	if err != nil {
+		return err
+	}
+	b := s.bucket(tx, boltHistoryBucket)
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	key := boltHistoryPrefix(revision.Kind, revision.Name)
+	key = append(key, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(key[len(key)-8:], seq)
+	return b.Put(key, data)
+}
+
+// putVersioned writes a resource value and its resource version.
+func (s *boltStore) putVersioned(tx *bolt.Tx, kind, name string, value []byte, version int64) error {
+	if err := s.bucket(tx, []byte(kind)).Put(boltKey(name), value); err != nil {
+		return err
+	}
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(version))
+	return s.bucket(tx, boltVersionsBucket).Put(boltVersionKey(kind, name), data)
+}
+
+// version returns the resource version of a resource, or 0 if it has none.
+func (s *boltStore) version(tx *bolt.Tx, kind, name string) int64 {
+	b := s.bucket(tx, boltVersionsBucket)
+	if b == nil {
+		return 0
+	}
+	if value := b.Get(boltVersionKey(kind, name)); len(value) == 8 {
+		return int64(binary.BigEndian.Uint64(value))
+	}
+	return 0
+}
+
+// boltVersionKey returns the versions bucket key for a resource.
+func boltVersionKey(kind, name string) []byte {
+	return append([]byte(kind+"/"), boltKey(name)...)
+}
+
+// boltHistoryPrefix returns the history bucket key prefix of a resource.
+func boltHistoryPrefix(kind, name string) []byte {
+	return append(boltVersionKey(kind, name), 0)
+}
+
+// isEmpty returns true if none of the resource buckets contain any values.
+func isEmpty(tx *bolt.Tx) bool {
+	for _, name := range boltBuckets {
+		if k, _ := tx.Bucket([]byte(name)).Cursor().First(); k != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// boltKey returns the bucket key for a resource name. Names are cleaned the
+// same way as fileStore paths so both stores address resources identically.
+func boltKey(name string) []byte {
+	return []byte(strings.TrimPrefix(path.Clean("/"+name), "/"))
+}
+
+// marshalGroup returns the JSON encoding of a Group in the format of a
+// -data-path group file.
+func marshalGroup(group *storagepb.Group) ([]byte, error) {
+	richGroup, err := group.ToRichGroup()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(richGroup, "", "\t")
+}
+
+// marshalProfile returns the JSON encoding of a Profile in the format of a
+// -data-path profile file, without its resource version.
+func marshalProfile(profile *storagepb.Profile) ([]byte, error) {
+	richProfile, err := profile.ToRichProfile()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(richProfile, "", "\t")
+}
+
+// withGroupVersion returns a shallow copy of a Group with the given resource
+// version, leaving the caller's Group unmodified.
+func withGroupVersion(group *storagepb.Group, version int64) *storagepb.Group {
+	copied := *group
+	copied.ResourceVersion = version
+	return &copied
+}
+
+// withProfileVersion returns a shallow copy of a Profile with the given
+// resource version, leaving the caller's Profile unmodified.
+func withProfileVersion(profile *storagepb.Profile, version int64) *storagepb.Profile {
+	copied := *profile
+	copied.ResourceVersion = version
+	return &copied
+}
+
+// parseValidProfile parses and validates a Profile.
+func parseValidProfile(data []byte) (*storagepb.Profile, error) {
+	profile, err := storagepb.ParseProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.AssertValid(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
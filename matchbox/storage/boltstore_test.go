@@ -0,0 +1,330 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestBoltGroupCRUD(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Group creation was successful
+	// - Group can be retrieved by id
+	// - Group can be deleted by id
+	version, err := store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withGroupVersion(fake.Group, version), group)
+
+	err = store.GroupDelete(fake.Group.Id)
+	assert.Nil(t, err)
+	_, err = store.GroupGet(fake.Group.Id)
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+		assert.True(t, os.IsNotExist(err))
+	}
+	err = store.GroupDelete(fake.Group.Id)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBoltGroupList(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{
+			fake.Group.Id:           fake.Group,
+			fake.GroupNoMetadata.Id: fake.GroupNoMetadata,
+		},
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(groups)) {
+		assert.Contains(t, groups, withGroupVersion(fake.Group, 1))
+		assert.Contains(t, groups, withGroupVersion(fake.GroupNoMetadata, 1))
+	}
+}
+
+func TestBoltProfileCRUD(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Profile creation was successful
+	// - Profile can be retrieved by id
+	// - Profile can be deleted by id
+	version, err := store.ProfilePut(fake.Profile)
+	assert.Nil(t, err)
+
+	profile, err := store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withProfileVersion(fake.Profile, version), profile)
+
+	err = store.ProfileDelete(fake.Profile.Id)
+	assert.Nil(t, err)
+	_, err = store.ProfileGet(fake.Profile.Id)
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+	}
+}
+
+func TestBoltProfileList(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	profiles, err := store.ProfileList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(profiles)) {
+		assert.Equal(t, withProfileVersion(fake.Profile, 1), profiles[0])
+	}
+}
+
+func TestBoltTemplateCRUD(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Ignition and Generic templates can be created, retrieved, and deleted
+	_, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), 0)
+	assert.Nil(t, err)
+	template, err := store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+	err = store.IgnitionDelete(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	_, err = store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = store.GenericPut(fake.GenericName, []byte(fake.Generic), 0)
+	assert.Nil(t, err)
+	template, err = store.GenericGet(fake.GenericName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Generic, template)
+	err = store.GenericDelete(fake.GenericName)
+	assert.Nil(t, err)
+	_, err = store.GenericGet(fake.GenericName)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBoltTemplates(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testTemplates(t, store)
+}
+
+func TestBoltConditionalPut(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testConditionalPut(t, store)
+}
+
+func TestBoltHistory(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testHistory(t, store)
+}
+
+func TestBoltRecording(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testRecording(t, store)
+
+	// assert that writes of a Recording are reported to the Store's watchers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := store.Watch(ctx)
+	assert.Nil(t, err)
+	revision := &storagepb.Revision{Kind: "groups", Name: fake.Group.Id}
+	version, err := store.(Recorder).Recording(revision).GroupPut(fake.Group)
+	assert.Nil(t, err)
+	event := <-events
+	assert.Equal(t, version, event.Revision)
+}
+
+func TestBoltNamespaces(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testNamespaces(t, store)
+}
+
+func TestBoltWatch(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testWatch(t, store)
+}
+
+func TestBoltImport(t *testing.T) {
+	store, cleanup, err := setupBolt(&fake.FixedStore{
+		Groups:          map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML},
+		GenericConfigs:  map[string]string{fake.GenericName: fake.Generic},
+		CloudConfigs:    map[string]string{"cloudcfg.yaml": "#cloud-config"},
+		Partials:        map[string]string{"users.yaml": "passwd: {}"},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that all resources of the -data-path tree were imported
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withGroupVersion(fake.Group, 1), group)
+	profile, err := store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withProfileVersion(fake.Profile, 1), profile)
+	template, err := store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+	template, err = store.GenericGet(fake.GenericName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Generic, template)
+	cfg, err := store.CloudGet("cloudcfg.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "#cloud-config", cfg)
+	partial, err := store.PartialGet("users.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "passwd: {}", partial)
+}
+
+func TestBoltImport_YAML(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "groups", "node1.yaml"), []byte("id: node1\nprofile: p\nmetadata:\n  pod_network: 10.2.0.0/16\n"), defaultFileMode)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "groups", "README.md"), []byte("# Groups"), defaultFileMode)
+	assert.Nil(t, err)
+	store, err := NewBoltStore(&BoltConfig{Path: filepath.Join(dir, "matchbox.db"), ImportRoot: dir})
+	assert.Nil(t, err)
+	defer store.(*boltStore).Close()
+
+	// assert that YAML groups are imported and other files skipped
+	group, err := store.GroupGet("node1")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"pod_network":"10.2.0.0/16"}`, string(group.Metadata))
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+}
+
+func TestBoltImport_Symlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// ignition is a symlinked directory laid out like a mounted ConfigMap
+	configMap := filepath.Join(dir, "configmap")
+	assert.Nil(t, os.MkdirAll(filepath.Join(configMap, "..2018_04_01"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(configMap, "..2018_04_01", "etcd.yaml"), []byte(fake.IgnitionYAML), defaultFileMode))
+	assert.Nil(t, os.Symlink("..2018_04_01", filepath.Join(configMap, "..data")))
+	assert.Nil(t, os.Symlink(filepath.Join("..data", "etcd.yaml"), filepath.Join(configMap, "etcd.yaml")))
+	assert.Nil(t, os.Symlink(configMap, filepath.Join(dir, "ignition")))
+	store, err := NewBoltStore(&BoltConfig{Path: filepath.Join(dir, "matchbox.db"), ImportRoot: dir})
+	assert.Nil(t, err)
+	defer store.(*boltStore).Close()
+
+	// assert that the templates of a symlinked directory are imported and its
+	// hidden entries skipped
+	names, err := store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"etcd.yaml"}, names)
+	template, err := store.IgnitionGet("etcd.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+}
+
+func TestBoltImport_NotEmpty(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "matchbox.db")
+
+	store, err := NewBoltStore(&BoltConfig{Path: dbPath})
+	assert.Nil(t, err)
+	_, err = store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	assert.Nil(t, store.(*boltStore).Close())
+
+	// assert that the import is skipped once the database has resources
+	store, err = NewBoltStore(&BoltConfig{Path: dbPath, ImportRoot: dir})
+	assert.Nil(t, err)
+	defer store.(*boltStore).Close()
+	_, err = store.ProfileGet(fake.Profile.Id)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBoltImport_Invalid(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "profiles", "bad.json"), []byte("{"), defaultFileMode)
+	assert.Nil(t, err)
+	dbPath := filepath.Join(dir, "matchbox.db")
+
+	// assert that an invalid file aborts the import and nothing is written
+	_, err = NewBoltStore(&BoltConfig{Path: dbPath, ImportRoot: dir})
+	assert.Error(t, err)
+	store, err := NewBoltStore(&BoltConfig{Path: dbPath})
+	assert.Nil(t, err)
+	defer store.(*boltStore).Close()
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+}
+
+func TestBoltKey(t *testing.T) {
+	assert.Equal(t, []byte("node1"), boltKey("node1"))
+	assert.Equal(t, []byte("profiles/p"), boltKey("../profiles/p"))
+	assert.Equal(t, []byte("b"), boltKey("/a/../b"))
+}
+
+// setupBolt writes a -data-path tree mirroring a given fixedStore, imports it
+// into a new bolt database, and returns a bolt Store for the database. The
+// caller must call the returned cleanup function when finished.
+func setupBolt(fixedStore *fake.FixedStore) (Store, func(), error) {
+	dir, err := setup(fixedStore)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := NewBoltStore(&BoltConfig{
+		Path:       filepath.Join(dir, "matchbox.db"),
+		ImportRoot: dir,
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, err
+	}
+	cleanup := func() {
+		store.(*boltStore).Close()
+		os.RemoveAll(dir)
+	}
+	return store, cleanup, nil
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"os"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// checkKinds are the resource kinds whose stored contents are parsed.
+var checkKinds = []string{"groups", "profiles"}
+
+// checkNames returns a Problem for each named resource of a kind which
+// cannot be read, given a function which reads a resource. Resources which
+// do not exist, such as names of directories, are ignored.
+func checkNames(kind string, names []string, get func(name string) error) []*storagepb.Problem {
+	var problems []*storagepb.Problem
+	for _, name := range names {
+		if err := get(name); err != nil && !os.IsNotExist(err) {
+			problems = append(problems, &storagepb.Problem{
+				Kind:    kind,
+				Name:    name,
+				Message: err.Error(),
+			})
+		}
+	}
+	return problems
+}
+
+// getter returns a function which reads a Group or Profile of a Store.
+func getter(store Store, kind string) func(name string) error {
+	if kind == "groups" {
+		return func(name string) error {
+			_, err := store.GroupGet(name)
+			return err
+		}
+	}
+	return func(name string) error {
+		_, err := store.ProfileGet(name)
+		return err
+	}
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestFileCheck(t *testing.T) {
+	dir, err := setupBroken()
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	store := NewFileStore(&Config{Root: dir})
+
+	// assert that every unparseable group and profile file is reported
+	problems, err := store.(Checker).Check()
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(problems)) {
+		assert.Equal(t, "groups", problems[0].Kind)
+		assert.Equal(t, "bad-json", problems[0].Name)
+		assert.Equal(t, "groups", problems[1].Kind)
+		assert.Equal(t, "bad-mac", problems[1].Name)
+		assert.Contains(t, problems[1].Message, "invalid MAC address")
+		assert.Equal(t, "profiles", problems[2].Kind)
+		assert.Equal(t, "bad-json", problems[2].Name)
+	}
+	// assert that lists skip them
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+}
+
+func TestOverlayCheck(t *testing.T) {
+	dir, err := setupBroken()
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	top, err := ioutil.TempDir("", "top")
+	assert.Nil(t, err)
+	defer os.RemoveAll(top)
+	err = os.MkdirAll(filepath.Join(top, "profiles"), defaultDirectoryMode)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(top, "profiles", "top.json"), []byte("{"), defaultFileMode)
+	assert.Nil(t, err)
+	store, err := NewOverlayStore(&OverlayConfig{
+		Layers: []Store{NewFileStore(&Config{Root: dir}), NewFileStore(&Config{Root: top})},
+	})
+	assert.Nil(t, err)
+
+	// assert that the Problems of all layers are ordered by kind and name
+	problems, err := store.(Checker).Check()
+	assert.Nil(t, err)
+	var names []string
+	for _, problem := range problems {
+		names = append(names, problem.Kind+"/"+problem.Name)
+	}
+	assert.Equal(t, []string{"groups/bad-json", "groups/bad-mac", "profiles/bad-json", "profiles/top"}, names)
+}
+
+// setupBroken writes a -data-path tree with a valid group and profile,
+// unparseable group and profile files, and a nested directory of groups.
+// The caller must remove the returned directory when finished.
+func setupBroken() (string, error) {
+	dir, err := setup(&fake.FixedStore{
+		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	if err != nil {
+		return "", err
+	}
+	files := map[string]string{
+		"groups/bad-json.json":       "{",
+		"groups/bad-mac.json":        `{"id": "bad-mac", "profile": "g1h2i3j4", "selector": {"mac": "aa"}}`,
+		"groups/nested/example.json": `{"id": "example", "profile": "g1h2i3j4"}`,
+		"profiles/bad-json.json":     "{",
+	}
+	for name, data := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), defaultDirectoryMode); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), defaultFileMode); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+	return dir, nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	pb "github.com/coreos/matchbox/matchbox/storage/etcdserverpb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestEtcdFailover(t *testing.T) {
+	kv := fake.NewEtcdKV()
+	first, err := serveEtcdKV(kv)
+	assert.Nil(t, err)
+	defer first.Stop()
+	second, err := serveEtcdKV(kv)
+	assert.Nil(t, err)
+	defer second.Stop()
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	down.Close()
+
+	// assert that:
+	// - the Store opens while some endpoints are down
+	// - requests fail over to the endpoints which are up
+	store, err := NewEtcdStore(&EtcdConfig{
+		Endpoints: []string{down.Addr().String(), first.addr, second.addr},
+	})
+	assert.Nil(t, err)
+	_, err = store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	first.Stop()
+	var group interface{}
+	for i := 0; i < 50; i++ {
+		if group, err = store.GroupGet(fake.Group.Id); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	assert.Nil(t, err)
+	assert.NotNil(t, group)
+}
+
+func TestEtcdUnreachable(t *testing.T) {
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	down.Close()
+	// assert that a Store without reachable endpoints fails to open
+	_, err = NewEtcdStore(&EtcdConfig{
+		Endpoints:   []string{down.Addr().String()},
+		DialTimeout: 200 * time.Millisecond,
+	})
+	assert.Error(t, err)
+}
+
+// etcdKVServer is a gRPC server of an in-memory etcd KV.
+type etcdKVServer struct {
+	*grpc.Server
+	addr string
+}
+
+// serveEtcdKV serves an in-memory etcd KV over a local gRPC listener.
+func serveEtcdKV(kv *fake.EtcdKV) (*etcdKVServer, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterKVServer(grpcServer, kv)
+	pb.RegisterWatchServer(grpcServer, kv)
+	go grpcServer.Serve(lis)
+	return &etcdKVServer{Server: grpcServer, addr: lis.Addr().String()}, nil
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+// testEtcdEndpoints are the endpoints of the real etcd the etcd Store tests
+// run against, or empty if they run against the in-memory fake.
+var testEtcdEndpoints []string
+
+// TestMain runs the tests against the etcd at MATCHBOX_TEST_ETCD_ENDPOINTS or,
+// by default, against an etcd started from the etcd binary at
+// MATCHBOX_TEST_ETCD_BINARY or in the PATH. Only if no etcd is installed do
+// the etcd Store tests fall back to an in-memory fake of the etcd API.
+func TestMain(m *testing.M) {
+	stop := func() {}
+	if endpoints := os.Getenv("MATCHBOX_TEST_ETCD_ENDPOINTS"); endpoints != "" {
+		testEtcdEndpoints = strings.Split(endpoints, ",")
+	} else if binary, err := etcdBinary(); err == nil {
+		var endpoint string
+		endpoint, stop, err = startEtcd(binary)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "storage: starting etcd: %v\n", err)
+			os.Exit(1)
+		}
+		testEtcdEndpoints = []string{endpoint}
+	} else {
+		fmt.Fprintln(os.Stderr, "storage: etcd is not installed, the etcd Store tests use an in-memory fake")
+	}
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// testEtcdConfig returns the EtcdConfig of a Store connected to the test etcd,
+// with a key prefix of its own, so tests do not see each other's keys, and a
+// function which stops the in-memory fake, if one is used.
+func testEtcdConfig() (*EtcdConfig, func(), error) {
+	if len(testEtcdEndpoints) > 0 {
+		config := &EtcdConfig{
+			Endpoints: testEtcdEndpoints,
+			Prefix:    fmt.Sprintf("/matchbox-test/%d", time.Now().UnixNano()),
+		}
+		return config, func() {}, nil
+	}
+	server, err := serveEtcdKV(fake.NewEtcdKV())
+	if err != nil {
+		return nil, nil, err
+	}
+	config := &EtcdConfig{
+		Endpoints: []string{server.addr},
+	}
+	return config, server.Stop, nil
+}
+
+// etcdBinary returns the path of the etcd binary.
+func etcdBinary() (string, error) {
+	if binary := os.Getenv("MATCHBOX_TEST_ETCD_BINARY"); binary != "" {
+		return binary, nil
+	}
+	return exec.LookPath("etcd")
+}
+
+// startEtcd starts a single member etcd cluster with a temporary data
+// directory and returns its client endpoint and a function which stops it.
+func startEtcd(binary string) (string, func(), error) {
+	dir, err := ioutil.TempDir("", "matchbox-etcd")
+	if err != nil {
+		return "", nil, err
+	}
+	clientURL, err := freeURL()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	peerURL, err := freeURL()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	cmd := exec.Command(binary,
+		"--name", "matchbox-test",
+		"--data-dir", dir,
+		"--listen-client-urls", clientURL,
+		"--advertise-client-urls", clientURL,
+		"--listen-peer-urls", peerURL,
+		"--initial-advertise-peer-urls", peerURL,
+		"--initial-cluster", "matchbox-test="+peerURL,
+	)
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	stop := func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+		os.RemoveAll(dir)
+	}
+	endpoint := strings.TrimPrefix(clientURL, "http://")
+	// wait until etcd serves requests
+	for i := 0; i < 10; i++ {
+		if _, err = NewEtcdStore(&EtcdConfig{Endpoints: []string{endpoint}, DialTimeout: time.Second}); err == nil {
+			return endpoint, stop, nil
+		}
+	}
+	stop()
+	return "", nil, err
+}
+
+// freeURL returns the URL of a free local TCP port.
+func freeURL() (string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer lis.Close()
+	return "http://" + lis.Addr().String(), nil
+}
@@ -0,0 +1,3 @@
+// Package etcdserverpb provides a wire compatible subset of the etcd v3 gRPC
+// API used by the etcd-backed Store.
+package etcdserverpb
@@ -0,0 +1,1289 @@
+// Code generated by protoc-gen-go.
+// source: kv.proto
+// DO NOT EDIT!
+
+/*
+Package etcdserverpb is a generated protocol buffer package.
+
+It is generated from these files:
+	kv.proto
+
+It has these top-level messages:
+	ResponseHeader
+	KeyValue
+	RangeRequest
+	RangeResponse
+	PutRequest
+	PutResponse
+	DeleteRangeRequest
+	DeleteRangeResponse
+	RequestOp
+	ResponseOp
+	Compare
+	TxnRequest
+	TxnResponse
+	WatchRequest
+	WatchCreateRequest
+	WatchCancelRequest
+	WatchResponse
+	Event
+*/
+package etcdserverpb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Compare_CompareResult int32
+
+const (
+	Compare_EQUAL     Compare_CompareResult = 0
+	Compare_GREATER   Compare_CompareResult = 1
+	Compare_LESS      Compare_CompareResult = 2
+	Compare_NOT_EQUAL Compare_CompareResult = 3
+)
+
+var Compare_CompareResult_name = map[int32]string{
+	0: "EQUAL",
+	1: "GREATER",
+	2: "LESS",
+	3: "NOT_EQUAL",
+}
+var Compare_CompareResult_value = map[string]int32{
+	"EQUAL":     0,
+	"GREATER":   1,
+	"LESS":      2,
+	"NOT_EQUAL": 3,
+}
+
+func (x Compare_CompareResult) String() string {
+	return proto.EnumName(Compare_CompareResult_name, int32(x))
+}
+func (Compare_CompareResult) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{10, 0} }
+
+type Compare_CompareTarget int32
+
+const (
+	Compare_VERSION Compare_CompareTarget = 0
+	Compare_CREATE  Compare_CompareTarget = 1
+	Compare_MOD     Compare_CompareTarget = 2
+	Compare_VALUE   Compare_CompareTarget = 3
+	Compare_LEASE   Compare_CompareTarget = 4
+)
+
+var Compare_CompareTarget_name = map[int32]string{
+	0: "VERSION",
+	1: "CREATE",
+	2: "MOD",
+	3: "VALUE",
+	4: "LEASE",
+}
+var Compare_CompareTarget_value = map[string]int32{
+	"VERSION": 0,
+	"CREATE":  1,
+	"MOD":     2,
+	"VALUE":   3,
+	"LEASE":   4,
+}
+
+func (x Compare_CompareTarget) String() string {
+	return proto.EnumName(Compare_CompareTarget_name, int32(x))
+}
+func (Compare_CompareTarget) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{10, 1} }
+
+type Event_EventType int32
+
+const (
+	Event_PUT    Event_EventType = 0
+	Event_DELETE Event_EventType = 1
+)
+
+var Event_EventType_name = map[int32]string{
+	0: "PUT",
+	1: "DELETE",
+}
+var Event_EventType_value = map[string]int32{
+	"PUT":    0,
+	"DELETE": 1,
+}
+
+func (x Event_EventType) String() string {
+	return proto.EnumName(Event_EventType_name, int32(x))
+}
+func (Event_EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{17, 0} }
+
+type ResponseHeader struct {
+	// cluster_id is the ID of the cluster which sent the response.
+	ClusterId uint64 `protobuf:"varint,1,opt,name=cluster_id,json=clusterId" json:"cluster_id,omitempty"`
+	// member_id is the ID of the member which sent the response.
+	MemberId uint64 `protobuf:"varint,2,opt,name=member_id,json=memberId" json:"member_id,omitempty"`
+	// revision is the key-value store revision when the request was applied.
+	Revision int64 `protobuf:"varint,3,opt,name=revision" json:"revision,omitempty"`
+	// raft_term is the raft term when the request was applied.
+	RaftTerm uint64 `protobuf:"varint,4,opt,name=raft_term,json=raftTerm" json:"raft_term,omitempty"`
+}
+
+func (m *ResponseHeader) Reset()                    { *m = ResponseHeader{} }
+func (m *ResponseHeader) String() string            { return proto.CompactTextString(m) }
+func (*ResponseHeader) ProtoMessage()               {}
+func (*ResponseHeader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ResponseHeader) GetClusterId() uint64 {
+	if m != nil {
+		return m.ClusterId
+	}
+	return 0
+}
+
+func (m *ResponseHeader) GetMemberId() uint64 {
+	if m != nil {
+		return m.MemberId
+	}
+	return 0
+}
+
+func (m *ResponseHeader) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *ResponseHeader) GetRaftTerm() uint64 {
+	if m != nil {
+		return m.RaftTerm
+	}
+	return 0
+}
+
+type KeyValue struct {
+	// key in bytes. An empty key is not allowed.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// create_revision is the revision of last creation on this key.
+	CreateRevision int64 `protobuf:"varint,2,opt,name=create_revision,json=createRevision" json:"create_revision,omitempty"`
+	// mod_revision is the revision of last modification on this key.
+	ModRevision int64 `protobuf:"varint,3,opt,name=mod_revision,json=modRevision" json:"mod_revision,omitempty"`
+	// version is the version of the key. A deletion resets the version to
+	// zero and any modification of the key increases its version.
+	Version int64 `protobuf:"varint,4,opt,name=version" json:"version,omitempty"`
+	// value is the value held by the key, in bytes.
+	Value []byte `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// lease is the ID of the lease attached to the key.
+	Lease int64 `protobuf:"varint,6,opt,name=lease" json:"lease,omitempty"`
+}
+
+func (m *KeyValue) Reset()                    { *m = KeyValue{} }
+func (m *KeyValue) String() string            { return proto.CompactTextString(m) }
+func (*KeyValue) ProtoMessage()               {}
+func (*KeyValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *KeyValue) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *KeyValue) GetCreateRevision() int64 {
+	if m != nil {
+		return m.CreateRevision
+	}
+	return 0
+}
+
+func (m *KeyValue) GetModRevision() int64 {
+	if m != nil {
+		return m.ModRevision
+	}
+	return 0
+}
+
+func (m *KeyValue) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *KeyValue) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *KeyValue) GetLease() int64 {
+	if m != nil {
+		return m.Lease
+	}
+	return 0
+}
+
+type RangeRequest struct {
+	// key is the first key for the range.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// range_end is the upper bound on the requested range [key, range_end).
+	RangeEnd []byte `protobuf:"bytes,2,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
+	// limit is a limit on the number of keys returned for the request.
+	Limit int64 `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+	// revision is the point-in-time of the key-value store to use for the
+	// range. If revision is less or equal to zero, the latest is used.
+	Revision int64 `protobuf:"varint,4,opt,name=revision" json:"revision,omitempty"`
+	// serializable sets the range request to use serializable member-local reads.
+	Serializable bool `protobuf:"varint,7,opt,name=serializable" json:"serializable,omitempty"`
+	// keys_only when set returns only the keys and not the values.
+	KeysOnly bool `protobuf:"varint,8,opt,name=keys_only,json=keysOnly" json:"keys_only,omitempty"`
+	// count_only when set returns only the count of the keys in the range.
+	CountOnly bool `protobuf:"varint,9,opt,name=count_only,json=countOnly" json:"count_only,omitempty"`
+}
+
+func (m *RangeRequest) Reset()                    { *m = RangeRequest{} }
+func (m *RangeRequest) String() string            { return proto.CompactTextString(m) }
+func (*RangeRequest) ProtoMessage()               {}
+func (*RangeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RangeRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *RangeRequest) GetRangeEnd() []byte {
+	if m != nil {
+		return m.RangeEnd
+	}
+	return nil
+}
+
+func (m *RangeRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *RangeRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *RangeRequest) GetSerializable() bool {
+	if m != nil {
+		return m.Serializable
+	}
+	return false
+}
+
+func (m *RangeRequest) GetKeysOnly() bool {
+	if m != nil {
+		return m.KeysOnly
+	}
+	return false
+}
+
+func (m *RangeRequest) GetCountOnly() bool {
+	if m != nil {
+		return m.CountOnly
+	}
+	return false
+}
+
+type RangeResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// kvs is the list of key-value pairs matched by the range request.
+	Kvs []*KeyValue `protobuf:"bytes,2,rep,name=kvs" json:"kvs,omitempty"`
+	// more indicates if there are more keys to return in the requested range.
+	More bool `protobuf:"varint,3,opt,name=more" json:"more,omitempty"`
+	// count is set to the number of keys within the range when requested.
+	Count int64 `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *RangeResponse) Reset()                    { *m = RangeResponse{} }
+func (m *RangeResponse) String() string            { return proto.CompactTextString(m) }
+func (*RangeResponse) ProtoMessage()               {}
+func (*RangeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *RangeResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *RangeResponse) GetKvs() []*KeyValue {
+	if m != nil {
+		return m.Kvs
+	}
+	return nil
+}
+
+func (m *RangeResponse) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+func (m *RangeResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type PutRequest struct {
+	// key is the key, in bytes, to put into the key-value store.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// value is the value, in bytes, to associate with the key.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// lease is the lease ID to associate with the key.
+	Lease int64 `protobuf:"varint,3,opt,name=lease" json:"lease,omitempty"`
+	// prev_kv when set returns the previous key-value pair.
+	PrevKv bool `protobuf:"varint,4,opt,name=prev_kv,json=prevKv" json:"prev_kv,omitempty"`
+}
+
+func (m *PutRequest) Reset()                    { *m = PutRequest{} }
+func (m *PutRequest) String() string            { return proto.CompactTextString(m) }
+func (*PutRequest) ProtoMessage()               {}
+func (*PutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *PutRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *PutRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *PutRequest) GetLease() int64 {
+	if m != nil {
+		return m.Lease
+	}
+	return 0
+}
+
+func (m *PutRequest) GetPrevKv() bool {
+	if m != nil {
+		return m.PrevKv
+	}
+	return false
+}
+
+type PutResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// prev_kv is the key-value pair overwritten by the put, if requested.
+	PrevKv *KeyValue `protobuf:"bytes,2,opt,name=prev_kv,json=prevKv" json:"prev_kv,omitempty"`
+}
+
+func (m *PutResponse) Reset()                    { *m = PutResponse{} }
+func (m *PutResponse) String() string            { return proto.CompactTextString(m) }
+func (*PutResponse) ProtoMessage()               {}
+func (*PutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PutResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *PutResponse) GetPrevKv() *KeyValue {
+	if m != nil {
+		return m.PrevKv
+	}
+	return nil
+}
+
+type DeleteRangeRequest struct {
+	// key is the first key to delete in the range.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// range_end is the key following the last key to delete.
+	RangeEnd []byte `protobuf:"bytes,2,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
+	// prev_kv when set returns the deleted key-value pairs.
+	PrevKv bool `protobuf:"varint,3,opt,name=prev_kv,json=prevKv" json:"prev_kv,omitempty"`
+}
+
+func (m *DeleteRangeRequest) Reset()                    { *m = DeleteRangeRequest{} }
+func (m *DeleteRangeRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteRangeRequest) ProtoMessage()               {}
+func (*DeleteRangeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *DeleteRangeRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *DeleteRangeRequest) GetRangeEnd() []byte {
+	if m != nil {
+		return m.RangeEnd
+	}
+	return nil
+}
+
+func (m *DeleteRangeRequest) GetPrevKv() bool {
+	if m != nil {
+		return m.PrevKv
+	}
+	return false
+}
+
+type DeleteRangeResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// deleted is the number of keys deleted by the delete range request.
+	Deleted int64 `protobuf:"varint,2,opt,name=deleted" json:"deleted,omitempty"`
+	// prev_kvs holds the deleted key-value pairs, if requested.
+	PrevKvs []*KeyValue `protobuf:"bytes,3,rep,name=prev_kvs,json=prevKvs" json:"prev_kvs,omitempty"`
+}
+
+func (m *DeleteRangeResponse) Reset()                    { *m = DeleteRangeResponse{} }
+func (m *DeleteRangeResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteRangeResponse) ProtoMessage()               {}
+func (*DeleteRangeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DeleteRangeResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *DeleteRangeResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+func (m *DeleteRangeResponse) GetPrevKvs() []*KeyValue {
+	if m != nil {
+		return m.PrevKvs
+	}
+	return nil
+}
+
+type RequestOp struct {
+	// request_range, request_put, and request_delete_range form the request
+	// oneof, which is wire compatible with plain message fields.
+	RequestRange       *RangeRequest       `protobuf:"bytes,1,opt,name=request_range,json=requestRange" json:"request_range,omitempty"`
+	RequestPut         *PutRequest         `protobuf:"bytes,2,opt,name=request_put,json=requestPut" json:"request_put,omitempty"`
+	RequestDeleteRange *DeleteRangeRequest `protobuf:"bytes,3,opt,name=request_delete_range,json=requestDeleteRange" json:"request_delete_range,omitempty"`
+}
+
+func (m *RequestOp) Reset()                    { *m = RequestOp{} }
+func (m *RequestOp) String() string            { return proto.CompactTextString(m) }
+func (*RequestOp) ProtoMessage()               {}
+func (*RequestOp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *RequestOp) GetRequestRange() *RangeRequest {
+	if m != nil {
+		return m.RequestRange
+	}
+	return nil
+}
+
+func (m *RequestOp) GetRequestPut() *PutRequest {
+	if m != nil {
+		return m.RequestPut
+	}
+	return nil
+}
+
+func (m *RequestOp) GetRequestDeleteRange() *DeleteRangeRequest {
+	if m != nil {
+		return m.RequestDeleteRange
+	}
+	return nil
+}
+
+type ResponseOp struct {
+	// response_range, response_put, and response_delete_range form the
+	// response oneof.
+	ResponseRange       *RangeResponse       `protobuf:"bytes,1,opt,name=response_range,json=responseRange" json:"response_range,omitempty"`
+	ResponsePut         *PutResponse         `protobuf:"bytes,2,opt,name=response_put,json=responsePut" json:"response_put,omitempty"`
+	ResponseDeleteRange *DeleteRangeResponse `protobuf:"bytes,3,opt,name=response_delete_range,json=responseDeleteRange" json:"response_delete_range,omitempty"`
+}
+
+func (m *ResponseOp) Reset()                    { *m = ResponseOp{} }
+func (m *ResponseOp) String() string            { return proto.CompactTextString(m) }
+func (*ResponseOp) ProtoMessage()               {}
+func (*ResponseOp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ResponseOp) GetResponseRange() *RangeResponse {
+	if m != nil {
+		return m.ResponseRange
+	}
+	return nil
+}
+
+func (m *ResponseOp) GetResponsePut() *PutResponse {
+	if m != nil {
+		return m.ResponsePut
+	}
+	return nil
+}
+
+func (m *ResponseOp) GetResponseDeleteRange() *DeleteRangeResponse {
+	if m != nil {
+		return m.ResponseDeleteRange
+	}
+	return nil
+}
+
+type Compare struct {
+	// result is logical comparison operation for this comparison.
+	Result Compare_CompareResult `protobuf:"varint,1,opt,name=result,enum=etcdserverpb.Compare.CompareResult" json:"result,omitempty"`
+	// target is the key-value field to inspect for the comparison.
+	Target Compare_CompareTarget `protobuf:"varint,2,opt,name=target,enum=etcdserverpb.Compare.CompareTarget" json:"target,omitempty"`
+	// key is the subject key for the comparison operation.
+	Key []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// version, create_revision, mod_revision, and value form the target_union
+	// oneof. Plain fields omit zero values, which etcd compares as zero.
+	Version        int64  `protobuf:"varint,4,opt,name=version" json:"version,omitempty"`
+	CreateRevision int64  `protobuf:"varint,5,opt,name=create_revision,json=createRevision" json:"create_revision,omitempty"`
+	ModRevision    int64  `protobuf:"varint,6,opt,name=mod_revision,json=modRevision" json:"mod_revision,omitempty"`
+	Value          []byte `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Compare) Reset()                    { *m = Compare{} }
+func (m *Compare) String() string            { return proto.CompactTextString(m) }
+func (*Compare) ProtoMessage()               {}
+func (*Compare) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Compare) GetResult() Compare_CompareResult {
+	if m != nil {
+		return m.Result
+	}
+	return Compare_EQUAL
+}
+
+func (m *Compare) GetTarget() Compare_CompareTarget {
+	if m != nil {
+		return m.Target
+	}
+	return Compare_VERSION
+}
+
+func (m *Compare) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *Compare) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Compare) GetCreateRevision() int64 {
+	if m != nil {
+		return m.CreateRevision
+	}
+	return 0
+}
+
+func (m *Compare) GetModRevision() int64 {
+	if m != nil {
+		return m.ModRevision
+	}
+	return 0
+}
+
+func (m *Compare) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type TxnRequest struct {
+	// compare is a list of predicates representing a conjunction of terms.
+	Compare []*Compare `protobuf:"bytes,1,rep,name=compare" json:"compare,omitempty"`
+	// success is a list of requests applied when all compare tests succeed.
+	Success []*RequestOp `protobuf:"bytes,2,rep,name=success" json:"success,omitempty"`
+	// failure is a list of requests applied when any compare test fails.
+	Failure []*RequestOp `protobuf:"bytes,3,rep,name=failure" json:"failure,omitempty"`
+}
+
+func (m *TxnRequest) Reset()                    { *m = TxnRequest{} }
+func (m *TxnRequest) String() string            { return proto.CompactTextString(m) }
+func (*TxnRequest) ProtoMessage()               {}
+func (*TxnRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *TxnRequest) GetCompare() []*Compare {
+	if m != nil {
+		return m.Compare
+	}
+	return nil
+}
+
+func (m *TxnRequest) GetSuccess() []*RequestOp {
+	if m != nil {
+		return m.Success
+	}
+	return nil
+}
+
+func (m *TxnRequest) GetFailure() []*RequestOp {
+	if m != nil {
+		return m.Failure
+	}
+	return nil
+}
+
+type TxnResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// succeeded is set to true if the compare evaluated to true.
+	Succeeded bool `protobuf:"varint,2,opt,name=succeeded" json:"succeeded,omitempty"`
+	// responses is a list of responses to the applied requests.
+	Responses []*ResponseOp `protobuf:"bytes,3,rep,name=responses" json:"responses,omitempty"`
+}
+
+func (m *TxnResponse) Reset()                    { *m = TxnResponse{} }
+func (m *TxnResponse) String() string            { return proto.CompactTextString(m) }
+func (*TxnResponse) ProtoMessage()               {}
+func (*TxnResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *TxnResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *TxnResponse) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
+}
+
+func (m *TxnResponse) GetResponses() []*ResponseOp {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	// create_request and cancel_request form the request_union oneof, which is
+	// wire compatible with two plain message fields.
+	CreateRequest *WatchCreateRequest `protobuf:"bytes,1,opt,name=create_request,json=createRequest" json:"create_request,omitempty"`
+	CancelRequest *WatchCancelRequest `protobuf:"bytes,2,opt,name=cancel_request,json=cancelRequest" json:"cancel_request,omitempty"`
+}
+
+func (m *WatchRequest) Reset()                    { *m = WatchRequest{} }
+func (m *WatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()               {}
+func (*WatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *WatchRequest) GetCreateRequest() *WatchCreateRequest {
+	if m != nil {
+		return m.CreateRequest
+	}
+	return nil
+}
+
+func (m *WatchRequest) GetCancelRequest() *WatchCancelRequest {
+	if m != nil {
+		return m.CancelRequest
+	}
+	return nil
+}
+
+type WatchCreateRequest struct {
+	// key is the key to register for watching.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// range_end is the end of the range [key, range_end) to watch.
+	RangeEnd []byte `protobuf:"bytes,2,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
+	// start_revision is an optional revision to watch from (inclusive).
+	StartRevision int64 `protobuf:"varint,3,opt,name=start_revision,json=startRevision" json:"start_revision,omitempty"`
+	// progress_notify is set so that the etcd server will periodically send a
+	// WatchResponse with no events to the new watcher.
+	ProgressNotify bool `protobuf:"varint,4,opt,name=progress_notify,json=progressNotify" json:"progress_notify,omitempty"`
+	// prev_kv when set makes the watcher receive the key-value pair before the
+	// event happens.
+	PrevKv bool `protobuf:"varint,6,opt,name=prev_kv,json=prevKv" json:"prev_kv,omitempty"`
+}
+
+func (m *WatchCreateRequest) Reset()                    { *m = WatchCreateRequest{} }
+func (m *WatchCreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchCreateRequest) ProtoMessage()               {}
+func (*WatchCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *WatchCreateRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *WatchCreateRequest) GetRangeEnd() []byte {
+	if m != nil {
+		return m.RangeEnd
+	}
+	return nil
+}
+
+func (m *WatchCreateRequest) GetStartRevision() int64 {
+	if m != nil {
+		return m.StartRevision
+	}
+	return 0
+}
+
+func (m *WatchCreateRequest) GetProgressNotify() bool {
+	if m != nil {
+		return m.ProgressNotify
+	}
+	return false
+}
+
+func (m *WatchCreateRequest) GetPrevKv() bool {
+	if m != nil {
+		return m.PrevKv
+	}
+	return false
+}
+
+type WatchCancelRequest struct {
+	// watch_id is the watcher id to cancel.
+	WatchId int64 `protobuf:"varint,1,opt,name=watch_id,json=watchId" json:"watch_id,omitempty"`
+}
+
+func (m *WatchCancelRequest) Reset()                    { *m = WatchCancelRequest{} }
+func (m *WatchCancelRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchCancelRequest) ProtoMessage()               {}
+func (*WatchCancelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *WatchCancelRequest) GetWatchId() int64 {
+	if m != nil {
+		return m.WatchId
+	}
+	return 0
+}
+
+type WatchResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// watch_id is the ID of the watcher that corresponds to the response.
+	WatchId int64 `protobuf:"varint,2,opt,name=watch_id,json=watchId" json:"watch_id,omitempty"`
+	// created is set to true if the response is for a create watch request.
+	Created bool `protobuf:"varint,3,opt,name=created" json:"created,omitempty"`
+	// canceled is set to true if the response is for a cancel watch request
+	// or the watcher was canceled by the server.
+	Canceled bool `protobuf:"varint,4,opt,name=canceled" json:"canceled,omitempty"`
+	// compact_revision is set to the minimum revision the watcher may receive
+	// if the watcher tried to watch a compacted revision.
+	CompactRevision int64 `protobuf:"varint,5,opt,name=compact_revision,json=compactRevision" json:"compact_revision,omitempty"`
+	// cancel_reason indicates the reason for canceling the watcher.
+	CancelReason string `protobuf:"bytes,6,opt,name=cancel_reason,json=cancelReason" json:"cancel_reason,omitempty"`
+	// events is the list of new events for the watched keys.
+	Events []*Event `protobuf:"bytes,11,rep,name=events" json:"events,omitempty"`
+}
+
+func (m *WatchResponse) Reset()                    { *m = WatchResponse{} }
+func (m *WatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*WatchResponse) ProtoMessage()               {}
+func (*WatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *WatchResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *WatchResponse) GetWatchId() int64 {
+	if m != nil {
+		return m.WatchId
+	}
+	return 0
+}
+
+func (m *WatchResponse) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+func (m *WatchResponse) GetCanceled() bool {
+	if m != nil {
+		return m.Canceled
+	}
+	return false
+}
+
+func (m *WatchResponse) GetCompactRevision() int64 {
+	if m != nil {
+		return m.CompactRevision
+	}
+	return 0
+}
+
+func (m *WatchResponse) GetCancelReason() string {
+	if m != nil {
+		return m.CancelReason
+	}
+	return ""
+}
+
+func (m *WatchResponse) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type Event struct {
+	// type is the kind of event.
+	Type Event_EventType `protobuf:"varint,1,opt,name=type,enum=etcdserverpb.Event.EventType" json:"type,omitempty"`
+	// kv holds the KeyValue for the event. A DELETE event contains only the
+	// key and the mod_revision of the deletion.
+	Kv *KeyValue `protobuf:"bytes,2,opt,name=kv" json:"kv,omitempty"`
+	// prev_kv holds the key-value pair before the event happens.
+	PrevKv *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv" json:"prev_kv,omitempty"`
+}
+
+func (m *Event) Reset()                    { *m = Event{} }
+func (m *Event) String() string            { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()               {}
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *Event) GetType() Event_EventType {
+	if m != nil {
+		return m.Type
+	}
+	return Event_PUT
+}
+
+func (m *Event) GetKv() *KeyValue {
+	if m != nil {
+		return m.Kv
+	}
+	return nil
+}
+
+func (m *Event) GetPrevKv() *KeyValue {
+	if m != nil {
+		return m.PrevKv
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ResponseHeader)(nil), "etcdserverpb.ResponseHeader")
+	proto.RegisterType((*KeyValue)(nil), "etcdserverpb.KeyValue")
+	proto.RegisterType((*RangeRequest)(nil), "etcdserverpb.RangeRequest")
+	proto.RegisterType((*RangeResponse)(nil), "etcdserverpb.RangeResponse")
+	proto.RegisterType((*PutRequest)(nil), "etcdserverpb.PutRequest")
+	proto.RegisterType((*PutResponse)(nil), "etcdserverpb.PutResponse")
+	proto.RegisterType((*DeleteRangeRequest)(nil), "etcdserverpb.DeleteRangeRequest")
+	proto.RegisterType((*DeleteRangeResponse)(nil), "etcdserverpb.DeleteRangeResponse")
+	proto.RegisterType((*RequestOp)(nil), "etcdserverpb.RequestOp")
+	proto.RegisterType((*ResponseOp)(nil), "etcdserverpb.ResponseOp")
+	proto.RegisterType((*Compare)(nil), "etcdserverpb.Compare")
+	proto.RegisterType((*TxnRequest)(nil), "etcdserverpb.TxnRequest")
+	proto.RegisterType((*TxnResponse)(nil), "etcdserverpb.TxnResponse")
+	proto.RegisterType((*WatchRequest)(nil), "etcdserverpb.WatchRequest")
+	proto.RegisterType((*WatchCreateRequest)(nil), "etcdserverpb.WatchCreateRequest")
+	proto.RegisterType((*WatchCancelRequest)(nil), "etcdserverpb.WatchCancelRequest")
+	proto.RegisterType((*WatchResponse)(nil), "etcdserverpb.WatchResponse")
+	proto.RegisterType((*Event)(nil), "etcdserverpb.Event")
+	proto.RegisterEnum("etcdserverpb.Compare.CompareResult", Compare_CompareResult_name, Compare_CompareResult_value)
+	proto.RegisterEnum("etcdserverpb.Compare.CompareTarget", Compare_CompareTarget_name, Compare_CompareTarget_value)
+	proto.RegisterEnum("etcdserverpb.Event.EventType", Event_EventType_name, Event_EventType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for KV service
+
+type KVClient interface {
+	// Range gets the keys in the range from the key-value store.
+	Range(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (*RangeResponse, error)
+	// Put puts the given key into the key-value store.
+	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
+	// DeleteRange deletes the given range from the key-value store.
+	DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error)
+	// Txn processes multiple requests in a single transaction.
+	Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error)
+}
+
+type kVClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewKVClient(cc *grpc.ClientConn) KVClient {
+	return &kVClient{cc}
+}
+
+func (c *kVClient) Range(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (*RangeResponse, error) {
+	out := new(RangeResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.KV/Range", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
+	out := new(PutResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.KV/Put", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVClient) DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error) {
+	out := new(DeleteRangeResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.KV/DeleteRange", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVClient) Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error) {
+	out := new(TxnResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.KV/Txn", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for KV service
+
+type KVServer interface {
+	// Range gets the keys in the range from the key-value store.
+	Range(context.Context, *RangeRequest) (*RangeResponse, error)
+	// Put puts the given key into the key-value store.
+	Put(context.Context, *PutRequest) (*PutResponse, error)
+	// DeleteRange deletes the given range from the key-value store.
+	DeleteRange(context.Context, *DeleteRangeRequest) (*DeleteRangeResponse, error)
+	// Txn processes multiple requests in a single transaction.
+	Txn(context.Context, *TxnRequest) (*TxnResponse, error)
+}
+
+func RegisterKVServer(s *grpc.Server, srv KVServer) {
+	s.RegisterService(&_KV_serviceDesc, srv)
+}
+
+func _KV_Range_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).Range(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.KV/Range",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).Range(ctx, req.(*RangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KV_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).Put(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.KV/Put",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).Put(ctx, req.(*PutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KV_DeleteRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).DeleteRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.KV/DeleteRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).DeleteRange(ctx, req.(*DeleteRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KV_Txn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).Txn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.KV/Txn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).Txn(ctx, req.(*TxnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _KV_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "etcdserverpb.KV",
+	HandlerType: (*KVServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Range",
+			Handler:    _KV_Range_Handler,
+		},
+		{
+			MethodName: "Put",
+			Handler:    _KV_Put_Handler,
+		},
+		{
+			MethodName: "DeleteRange",
+			Handler:    _KV_DeleteRange_Handler,
+		},
+		{
+			MethodName: "Txn",
+			Handler:    _KV_Txn_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kv.proto",
+}
+
+// Client API for Watch service
+
+type WatchClient interface {
+	// Watch watches for events happening or that have happened. Both input and
+	// output are streams.
+	Watch(ctx context.Context, opts ...grpc.CallOption) (Watch_WatchClient, error)
+}
+
+type watchClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWatchClient(cc *grpc.ClientConn) WatchClient {
+	return &watchClient{cc}
+}
+
+func (c *watchClient) Watch(ctx context.Context, opts ...grpc.CallOption) (Watch_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Watch_serviceDesc.Streams[0], c.cc, "/etcdserverpb.Watch/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &watchWatchClient{stream}
+	return x, nil
+}
+
+type Watch_WatchClient interface {
+	Send(*WatchRequest) error
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type watchWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *watchWatchClient) Send(m *WatchRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *watchWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Watch service
+
+type WatchServer interface {
+	// Watch watches for events happening or that have happened. Both input and
+	// output are streams.
+	Watch(Watch_WatchServer) error
+}
+
+func RegisterWatchServer(s *grpc.Server, srv WatchServer) {
+	s.RegisterService(&_Watch_serviceDesc, srv)
+}
+
+func _Watch_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WatchServer).Watch(&watchWatchServer{stream})
+}
+
+type Watch_WatchServer interface {
+	Send(*WatchResponse) error
+	Recv() (*WatchRequest, error)
+	grpc.ServerStream
+}
+
+type watchWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *watchWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *watchWatchServer) Recv() (*WatchRequest, error) {
+	m := new(WatchRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Watch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "etcdserverpb.Watch",
+	HandlerType: (*WatchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Watch_Watch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "kv.proto",
+}
+
+func init() { proto.RegisterFile("kv.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 1252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x57, 0x4f, 0x6f, 0xe3, 0x54,
+	0x10, 0xaf, 0xed, 0x24, 0x76, 0x26, 0x7f, 0x36, 0x7a, 0xdd, 0x65, 0xdd, 0xec, 0xae, 0x94, 0xf5,
+	0x0a, 0x36, 0x08, 0xa9, 0xa5, 0x05, 0x21, 0x21, 0x56, 0x82, 0x6e, 0x6b, 0x4a, 0xd5, 0xd2, 0x94,
+	0xd7, 0xb4, 0x5c, 0x90, 0x22, 0x37, 0x7e, 0xed, 0x46, 0x49, 0x6c, 0x63, 0x3b, 0x61, 0xc3, 0x9d,
+	0x4f, 0xc0, 0x05, 0x10, 0x07, 0x24, 0xae, 0x88, 0x1b, 0x9f, 0x83, 0x0f, 0xc0, 0x99, 0xef, 0x81,
+	0xde, 0x3f, 0xc7, 0x4e, 0x9d, 0x74, 0x51, 0x2f, 0xad, 0x67, 0xde, 0xfc, 0xfb, 0xcd, 0xcc, 0x9b,
+	0x79, 0x01, 0x63, 0x38, 0xdd, 0x0c, 0x42, 0x3f, 0xf6, 0x51, 0x95, 0xc4, 0x7d, 0x37, 0x22, 0xe1,
+	0x94, 0x84, 0xc1, 0xa5, 0xf5, 0x83, 0x02, 0x75, 0x4c, 0xa2, 0xc0, 0xf7, 0x22, 0xf2, 0x05, 0x71,
+	0x5c, 0x12, 0xa2, 0x27, 0x00, 0xfd, 0xd1, 0x24, 0x8a, 0x49, 0xd8, 0x1b, 0xb8, 0xa6, 0xd2, 0x52,
+	0xda, 0x05, 0x5c, 0x16, 0x9c, 0x43, 0x17, 0x3d, 0x82, 0xf2, 0x98, 0x8c, 0x2f, 0xf9, 0xa9, 0xca,
+	0x4e, 0x0d, 0xce, 0x38, 0x74, 0x51, 0x13, 0x8c, 0x90, 0x4c, 0x07, 0xd1, 0xc0, 0xf7, 0x4c, 0xad,
+	0xa5, 0xb4, 0x35, 0x9c, 0xd0, 0x54, 0x31, 0x74, 0xae, 0xe2, 0x5e, 0x4c, 0xc2, 0xb1, 0x59, 0xe0,
+	0x8a, 0x94, 0xd1, 0x25, 0xe1, 0xd8, 0xfa, 0x53, 0x01, 0xe3, 0x88, 0xcc, 0x2e, 0x9c, 0xd1, 0x84,
+	0xa0, 0x06, 0x68, 0x43, 0x32, 0x63, 0xae, 0xab, 0x98, 0x7e, 0xa2, 0xe7, 0x70, 0xaf, 0x1f, 0x12,
+	0x27, 0x26, 0xbd, 0xc4, 0xbc, 0xca, 0xcc, 0xd7, 0x39, 0x1b, 0x4b, 0x27, 0x4f, 0xa1, 0x3a, 0xf6,
+	0xdd, 0xde, 0x42, 0x10, 0x95, 0xb1, 0xef, 0x26, 0x22, 0x26, 0xe8, 0x53, 0x12, 0xb2, 0xd3, 0x02,
+	0x3b, 0x95, 0x24, 0xba, 0x0f, 0xc5, 0x29, 0x0d, 0xc0, 0x2c, 0x32, 0xcf, 0x9c, 0xa0, 0xdc, 0x11,
+	0x71, 0x22, 0x62, 0x96, 0x98, 0x34, 0x27, 0xac, 0xbf, 0x15, 0xa8, 0x62, 0xc7, 0xbb, 0x26, 0x98,
+	0x7c, 0x3b, 0x21, 0x51, 0x9c, 0x13, 0x34, 0x03, 0xec, 0x5d, 0x93, 0x1e, 0xf1, 0x78, 0xa6, 0xaa,
+	0x14, 0xb0, 0x77, 0x4d, 0x6c, 0xcf, 0x65, 0x56, 0x07, 0xe3, 0x41, 0x2c, 0x22, 0xe4, 0x44, 0x26,
+	0x7f, 0x85, 0x85, 0xfc, 0x59, 0x50, 0x8d, 0x48, 0x38, 0x70, 0x46, 0x83, 0xef, 0x9d, 0xcb, 0x11,
+	0x31, 0xf5, 0x96, 0xd2, 0x36, 0x70, 0x86, 0x47, 0x5d, 0x0e, 0xc9, 0x2c, 0xea, 0xf9, 0xde, 0x68,
+	0x66, 0x1a, 0x4c, 0xc0, 0xa0, 0x8c, 0x8e, 0x37, 0x9a, 0xb1, 0xc2, 0xfa, 0x13, 0x2f, 0xe6, 0xa7,
+	0x65, 0x76, 0x5a, 0x66, 0x1c, 0x7a, 0x6c, 0xfd, 0xac, 0x40, 0x4d, 0x20, 0xe2, 0xfd, 0x80, 0x3e,
+	0x84, 0xd2, 0x2b, 0xd6, 0x13, 0x0c, 0x55, 0x65, 0xe7, 0xf1, 0x66, 0xba, 0x77, 0x36, 0xb3, 0x7d,
+	0x83, 0x85, 0x2c, 0x6a, 0x83, 0x36, 0x9c, 0x46, 0xa6, 0xda, 0xd2, 0xda, 0x95, 0x9d, 0xb7, 0xb2,
+	0x2a, 0xb2, 0xc4, 0x98, 0x8a, 0x20, 0x04, 0x85, 0xb1, 0x1f, 0x12, 0x96, 0x02, 0x03, 0xb3, 0x6f,
+	0x9a, 0x17, 0x16, 0x92, 0x80, 0xcf, 0x09, 0xab, 0x0f, 0x70, 0x3a, 0x89, 0x97, 0xa7, 0x3a, 0xa9,
+	0x9c, 0x9a, 0x5b, 0x39, 0x2d, 0x55, 0x39, 0xf4, 0x10, 0xf4, 0x20, 0x24, 0xd3, 0xde, 0x70, 0xca,
+	0x7c, 0x18, 0xb8, 0x44, 0xc9, 0xa3, 0xa9, 0x15, 0x43, 0x85, 0x39, 0xb9, 0x13, 0xfa, 0xad, 0xb9,
+	0x75, 0xb5, 0xa5, 0xac, 0xc8, 0x80, 0xf4, 0xfa, 0x0d, 0xa0, 0x7d, 0x32, 0x22, 0x31, 0xb9, 0x4b,
+	0x37, 0xa5, 0x30, 0x69, 0x19, 0x4c, 0x3f, 0x29, 0xb0, 0x9e, 0x31, 0x7f, 0x27, 0x70, 0x26, 0xe8,
+	0x2e, 0x33, 0xe6, 0x8a, 0xeb, 0x27, 0x49, 0xb4, 0x0d, 0x86, 0x08, 0x20, 0x32, 0xb5, 0x95, 0x95,
+	0xd7, 0x79, 0x64, 0x91, 0xf5, 0x8f, 0x02, 0x65, 0x01, 0xb7, 0x13, 0xa0, 0x4f, 0xa1, 0x16, 0x72,
+	0xa2, 0xc7, 0x50, 0x89, 0xb8, 0x9a, 0x0b, 0x71, 0xa5, 0x72, 0x84, 0xab, 0x42, 0x81, 0x31, 0xd1,
+	0xc7, 0x50, 0x91, 0x06, 0x82, 0x49, 0x2c, 0x92, 0x6f, 0x66, 0xd5, 0xe7, 0x3d, 0x84, 0x41, 0x08,
+	0x9f, 0x4e, 0x62, 0x84, 0xe1, 0xbe, 0x54, 0xe5, 0x78, 0x44, 0x08, 0x1a, 0xb3, 0xd1, 0xca, 0xda,
+	0xb8, 0x59, 0x2c, 0x8c, 0x84, 0x76, 0xea, 0xc8, 0xfa, 0x57, 0x01, 0x90, 0x59, 0xec, 0x04, 0xe8,
+	0x25, 0xd4, 0x43, 0x41, 0x65, 0xf0, 0x3d, 0xca, 0xc5, 0xc7, 0x05, 0x71, 0x4d, 0xaa, 0x70, 0x84,
+	0x2f, 0xa0, 0x9a, 0xd8, 0x98, 0x43, 0xdc, 0xc8, 0x81, 0x28, 0xb4, 0x2a, 0x52, 0x9c, 0x82, 0x3c,
+	0x87, 0x07, 0x89, 0x76, 0x0e, 0xca, 0xa7, 0x2b, 0x50, 0x0a, 0x73, 0xeb, 0x52, 0x3f, 0x8d, 0xf3,
+	0x57, 0x0d, 0xf4, 0x3d, 0x7f, 0x1c, 0x38, 0x21, 0x41, 0x9f, 0x40, 0x29, 0x24, 0xd1, 0x64, 0x14,
+	0x33, 0x70, 0xf5, 0x9d, 0x67, 0x59, 0x9b, 0x42, 0x4c, 0xfe, 0xc7, 0x4c, 0x14, 0x0b, 0x15, 0xaa,
+	0x1c, 0x3b, 0xe1, 0x35, 0xe1, 0xb8, 0x6e, 0x53, 0xee, 0x32, 0x51, 0x2c, 0x54, 0xe4, 0x75, 0xd1,
+	0xe6, 0xd7, 0x65, 0xf9, 0x94, 0xcf, 0xd9, 0x25, 0xc5, 0x37, 0xda, 0x25, 0xa5, 0x9b, 0xbb, 0x24,
+	0x99, 0x3b, 0x7a, 0x6a, 0xee, 0x58, 0x9f, 0x41, 0x2d, 0x83, 0x11, 0x95, 0xa1, 0x68, 0x7f, 0x75,
+	0xbe, 0x7b, 0xdc, 0x58, 0x43, 0x15, 0xd0, 0x0f, 0xb0, 0xbd, 0xdb, 0xb5, 0x71, 0x43, 0x41, 0x06,
+	0x14, 0x8e, 0xed, 0xb3, 0xb3, 0x86, 0x8a, 0x6a, 0x50, 0x3e, 0xe9, 0x74, 0x7b, 0x5c, 0x4a, 0xb3,
+	0x0e, 0xa0, 0x96, 0x01, 0x4a, 0xd5, 0x2e, 0x6c, 0x7c, 0x76, 0xd8, 0x39, 0x69, 0xac, 0x21, 0x80,
+	0xd2, 0x1e, 0xb3, 0xd1, 0x50, 0x90, 0x0e, 0xda, 0x97, 0x9d, 0xfd, 0x86, 0x4a, 0x7d, 0x5c, 0xec,
+	0x1e, 0x9f, 0xdb, 0x0d, 0x8d, 0x7e, 0x1e, 0xdb, 0xbb, 0x67, 0x76, 0xa3, 0x60, 0xfd, 0xae, 0x00,
+	0x74, 0x5f, 0x7b, 0x72, 0xac, 0x6c, 0x81, 0xde, 0xe7, 0x76, 0x4d, 0x85, 0xdd, 0xd2, 0x07, 0xb9,
+	0x59, 0xc6, 0x52, 0x0a, 0x6d, 0x83, 0x1e, 0x4d, 0xfa, 0x7d, 0x12, 0xc9, 0x81, 0xfe, 0x70, 0x71,
+	0x50, 0x88, 0x0b, 0x8c, 0xa5, 0x1c, 0x55, 0xb9, 0x72, 0x06, 0xa3, 0x09, 0x1b, 0xec, 0xab, 0x55,
+	0x84, 0x1c, 0x5d, 0x3d, 0x15, 0x16, 0xe5, 0x9d, 0xa6, 0xd3, 0x63, 0x28, 0xb3, 0x18, 0x88, 0x2b,
+	0xe6, 0x93, 0x81, 0xe7, 0x0c, 0xf4, 0x11, 0x94, 0x65, 0xff, 0xca, 0x11, 0x65, 0xe6, 0x9b, 0xed,
+	0x04, 0x78, 0x2e, 0x6a, 0xfd, 0xa6, 0x40, 0xf5, 0x6b, 0x27, 0xee, 0xbf, 0x92, 0x39, 0x3c, 0x80,
+	0x7a, 0xd2, 0x3f, 0x8c, 0x63, 0x2a, 0x79, 0x73, 0x82, 0xe9, 0xec, 0x89, 0x8e, 0x62, 0x72, 0xb8,
+	0xd6, 0x4f, 0x93, 0xcc, 0x90, 0xe3, 0xf5, 0xc9, 0x28, 0x31, 0xa4, 0x2e, 0x37, 0xc4, 0x04, 0xe7,
+	0x86, 0xd2, 0xa4, 0xf5, 0x87, 0x02, 0xe8, 0xa6, 0xbb, 0xff, 0xbb, 0x43, 0xde, 0x86, 0x7a, 0x14,
+	0x3b, 0x61, 0xbc, 0xf8, 0x78, 0xaa, 0x31, 0x6e, 0xd2, 0xf2, 0xcf, 0xe1, 0x5e, 0x10, 0xfa, 0xd7,
+	0x21, 0x89, 0xa2, 0x9e, 0xe7, 0xc7, 0x83, 0xab, 0x99, 0x58, 0xa3, 0x75, 0xc9, 0x3e, 0x61, 0xdc,
+	0xf4, 0x4e, 0x2a, 0x65, 0x76, 0xd2, 0x96, 0x8c, 0x36, 0x0d, 0x02, 0x6d, 0x80, 0xf1, 0x1d, 0xe5,
+	0xca, 0x47, 0xa7, 0x86, 0x75, 0x46, 0x1f, 0xba, 0xd6, 0x8f, 0x2a, 0xd4, 0x44, 0x09, 0xee, 0xd4,
+	0x20, 0x69, 0x17, 0x6a, 0xc6, 0x05, 0x1d, 0x17, 0xbc, 0x38, 0xae, 0x58, 0xa0, 0x92, 0xa4, 0x4f,
+	0x32, 0x9e, 0x6d, 0xe2, 0x0a, 0xa0, 0x09, 0x8d, 0xde, 0x85, 0x06, 0xbb, 0x28, 0xfd, 0x78, 0x71,
+	0x96, 0xdc, 0x13, 0xfc, 0x24, 0x6d, 0xcf, 0xa0, 0x96, 0x14, 0xdb, 0x89, 0xc4, 0x34, 0x29, 0xe3,
+	0xaa, 0xac, 0x24, 0xe5, 0xa1, 0xf7, 0xa0, 0x44, 0xa6, 0xc4, 0x8b, 0x23, 0xb3, 0xc2, 0x1a, 0x74,
+	0x3d, 0x0b, 0xcb, 0xa6, 0x67, 0x58, 0x88, 0x58, 0x7f, 0x29, 0x50, 0x64, 0x1c, 0xb4, 0x0d, 0x85,
+	0x78, 0x16, 0x10, 0x31, 0x75, 0x9f, 0xe4, 0x28, 0xf1, 0xbf, 0xdd, 0x59, 0x40, 0x30, 0x13, 0x45,
+	0xef, 0x80, 0x7a, 0xeb, 0x0b, 0x45, 0x1d, 0x4e, 0xd3, 0xcf, 0x19, 0xed, 0x8d, 0x9e, 0x33, 0x2d,
+	0x28, 0x27, 0xbe, 0xe8, 0x70, 0x3a, 0x3d, 0xef, 0xf2, 0x89, 0xb5, 0x6f, 0x1f, 0xdb, 0x74, 0x62,
+	0xed, 0xfc, 0xa2, 0x82, 0x7a, 0x74, 0x81, 0x5e, 0x42, 0x91, 0xaf, 0xb5, 0x15, 0x2b, 0xbe, 0xb9,
+	0x6a, 0x3d, 0x5a, 0x6b, 0xe8, 0x05, 0x68, 0x74, 0xb5, 0x2d, 0xdd, 0xf2, 0xcd, 0xe5, 0xcb, 0xd1,
+	0x5a, 0x43, 0x5d, 0xa8, 0xa4, 0x36, 0x19, 0xba, 0x75, 0xcf, 0x37, 0x6f, 0xdf, 0x91, 0x3c, 0xa6,
+	0xee, 0x6b, 0x6f, 0x31, 0xa6, 0xf9, 0x0c, 0x6e, 0x6e, 0xe4, 0x9c, 0x48, 0xed, 0x9d, 0x0e, 0x14,
+	0x59, 0xa7, 0xa3, 0xcf, 0xe5, 0x47, 0x33, 0x67, 0x1a, 0x2c, 0x49, 0x4f, 0xe6, 0x8e, 0x58, 0x6b,
+	0x6d, 0xe5, 0x7d, 0xe5, 0xb2, 0xc4, 0x7e, 0xf5, 0x7d, 0xf0, 0xdf, 0x00, 0x91, 0x8c, 0x37, 0x95,
+	0x01, 0x0e, 0x00, 0x00,
+}
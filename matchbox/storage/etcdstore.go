@@ -0,0 +1,781 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/naming"
+
+	pb "github.com/coreos/matchbox/matchbox/storage/etcdserverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+const (
+	defaultEtcdPrefix      = "/matchbox"
+	defaultEtcdDialTimeout = 5 * time.Second
+	defaultEtcdTimeout     = 5 * time.Second
+	// etcdHistoryDir is the directory below the prefix which holds recorded
+	// Revisions, excluded from watches
+	etcdHistoryDir = ".history"
+)
+
+var (
+	errNoEtcdEndpoints  = errors.New("storage: No etcd endpoints provided")
+	errEtcdWatchCreate  = errors.New("storage: etcd did not create the watch")
+	errEtcdWatchMissing = errors.New("storage: etcd watch client not configured")
+	// errEtcdResolverClosed stops the gRPC balancer's watch of the endpoints
+	errEtcdResolverClosed = errors.New("storage: etcd endpoint resolver closed")
+)
+
+// EtcdConfig initializes an etcdStore.
+type EtcdConfig struct {
+	// List of etcd host:port endpoints
+	Endpoints []string
+	// Key prefix under which resources are stored (default "/matchbox")
+	Prefix string
+	// DialTimeout is the timeout for dialing an etcd endpoint
+	DialTimeout time.Duration
+	// Client TLS credentials (optional)
+	TLS    *tls.Config
+	Logger *logrus.Logger
+}
+
+// etcdStore implements the Store interface using the etcd v3 key-value API.
+// Resources are stored below a key prefix, mirroring the fileStore layout
+// (e.g. /matchbox/groups/node1). A resource's version is the etcd mod
+// revision of its key, so conditional puts are checked by etcd itself.
+type etcdStore struct {
+	kv      pb.KVClient
+	watcher pb.WatchClient
+	prefix  string
+	logger  *logrus.Logger
+	// Revision appended by writes, or nil (see Recording)
+	revision *storagepb.Revision
+}
+
+// NewEtcdStore returns a new etcd-backed Store. Requests are balanced across
+// all endpoints which are up, so the Store fails over when an endpoint goes
+// down. NewEtcdStore fails unless an endpoint is up within the dial timeout.
+func NewEtcdStore(config *EtcdConfig) (Store, error) {
+	if len(config.Endpoints) == 0 {
+		return nil, errNoEtcdEndpoints
+	}
+	timeout := config.DialTimeout
+	if timeout == 0 {
+		timeout = defaultEtcdDialTimeout
+	}
+	opts := []grpc.DialOption{
+		grpc.WithBalancer(grpc.RoundRobin(&etcdResolver{endpoints: config.Endpoints})),
+	}
+	if config.TLS != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config.TLS)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, config.Endpoints[0], opts...)
+	if err != nil {
+		return nil, err
+	}
+	s := newEtcdStore(pb.NewKVClient(conn), config.Prefix, config.Logger)
+	s.watcher = pb.NewWatchClient(conn)
+	// wait for an endpoint to be up
+	_, err = s.kv.Range(ctx, &pb.RangeRequest{Key: []byte(s.prefix), KeysOnly: true, Limit: 1}, grpc.FailFast(false))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("storage: no etcd endpoint of %s is reachable: %v", strings.Join(config.Endpoints, ","), err)
+	}
+	return s, nil
+}
+
+// etcdResolver resolves any target to the configured etcd endpoints, so the
+// gRPC balancer connects to all of them.
+type etcdResolver struct {
+	endpoints []string
+}
+
+// Resolve returns a Watcher which reports the etcd endpoints.
+func (r *etcdResolver) Resolve(target string) (naming.Watcher, error) {
+	updates := make([]*naming.Update, len(r.endpoints))
+	for i, endpoint := range r.endpoints {
+		updates[i] = &naming.Update{Op: naming.Add, Addr: endpoint}
+	}
+	return &etcdWatcher{updates: updates, done: make(chan struct{})}, nil
+}
+
+// etcdWatcher reports a fixed set of endpoints once, then blocks until it is
+// closed.
+type etcdWatcher struct {
+	updates []*naming.Update
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Next returns the endpoints on the first call and otherwise blocks until
+// the Watcher is closed.
+func (w *etcdWatcher) Next() ([]*naming.Update, error) {
+	if updates := w.updates; updates != nil {
+		w.updates = nil
+		return updates, nil
+	}
+	<-w.done
+	return nil, errEtcdResolverClosed
+}
+
+// Close unblocks Next.
+func (w *etcdWatcher) Close() {
+	w.once.Do(func() { close(w.done) })
+}
+
+// newEtcdStore returns a new etcdStore using the given KV client.
+func newEtcdStore(kv pb.KVClient, prefix string, logger *logrus.Logger) *etcdStore {
+	if prefix == "" {
+		prefix = defaultEtcdPrefix
+	}
+	return &etcdStore{
+		kv:     kv,
+		prefix: path.Clean("/" + prefix),
+		logger: logger,
+	}
+}
+
+// Namespace returns an etcdStore of the resources of a namespace, stored
+// below the namespaces directory of the key prefix.
+func (s *etcdStore) Namespace(name string) (Store, error) {
+	if !ValidNamespace(name) {
+		return nil, ErrInvalidNamespace
+	}
+	namespaced := *s
+	namespaced.prefix = path.Join(s.prefix, namespacesDir, name)
+	return &namespaced, nil
+}
+
+// HasNamespace returns true if any key exists below the directory of a
+// namespace.
+func (s *etcdStore) HasNamespace(name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	dir := path.Join(s.prefix, namespacesDir, name) + "/"
+	resp, err := s.kv.Range(ctx, &pb.RangeRequest{
+		Key:      []byte(dir),
+		RangeEnd: prefixEnd([]byte(dir)),
+		Limit:    1,
+		KeysOnly: true,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(resp.Kvs) > 0, nil
+}
+
+// GroupPut writes the given Group.
+func (s *etcdStore) GroupPut(group *storagepb.Group) (int64, error) {
+	data, err := marshalGroup(group)
+	if err != nil {
+		return 0, err
+	}
+	return s.put(s.key("groups", group.Id), data, group.ResourceVersion)
+}
+
+// GroupGet returns a machine Group by id.
+func (s *etcdStore) GroupGet(id string) (*storagepb.Group, error) {
+	kv, err := s.get(s.key("groups", id), false)
+	if err != nil {
+		return nil, err
+	}
+	group, err := storagepb.ParseGroup(kv.Value)
+	if err != nil {
+		return nil, err
+	}
+	group.ResourceVersion = kv.ModRevision
+	return group, nil
+}
+
+// GroupDelete deletes a machine Group by id.
+func (s *etcdStore) GroupDelete(id string) error {
+	return s.delete(s.key("groups", id))
+}
+
+// GroupList lists all machine Groups.
+func (s *etcdStore) GroupList() ([]*storagepb.Group, error) {
+	kvs, err := s.list("groups", false)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*storagepb.Group, 0, len(kvs))
+	for _, kv := range kvs {
+		group, err := storagepb.ParseGroup(kv.Value)
+		if err == nil {
+			group.ResourceVersion = kv.ModRevision
+			groups = append(groups, group)
+		} else if s.logger != nil {
+			s.logger.Warningf("Group %q: %v", path.Base(string(kv.Key)), err)
+		}
+	}
+	return groups, nil
+}
+
+// GroupVersion returns the etcd revision at which the newest group key was
+// last modified, which changes when any instance writes or deletes a group.
+// A count of the group keys distinguishes deletions.
+func (s *etcdStore) GroupVersion() (string, error) {
+	kvs, err := s.list("groups", true)
+	if err != nil {
+		return "", err
+	}
+	var modRevision int64
+	for _, kv := range kvs {
+		if kv.ModRevision > modRevision {
+			modRevision = kv.ModRevision
+		}
+	}
+	return strconv.FormatInt(modRevision, 10) + "/" + strconv.Itoa(len(kvs)), nil
+}
+
+// ProfilePut writes the given Profile.
+func (s *etcdStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	data, err := marshalProfile(profile)
+	if err != nil {
+		return 0, err
+	}
+	return s.put(s.key("profiles", profile.Id), data, profile.ResourceVersion)
+}
+
+// ProfileGet gets a profile by id.
+func (s *etcdStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	kv, err := s.get(s.key("profiles", id), false)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := parseValidProfile(kv.Value)
+	if err != nil {
+		return nil, err
+	}
+	profile.ResourceVersion = kv.ModRevision
+	return profile, nil
+}
+
+// ProfileDelete deletes a profile by id.
+func (s *etcdStore) ProfileDelete(id string) error {
+	return s.delete(s.key("profiles", id))
+}
+
+// ProfileList lists all profiles.
+func (s *etcdStore) ProfileList() ([]*storagepb.Profile, error) {
+	kvs, err := s.list("profiles", false)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]*storagepb.Profile, 0, len(kvs))
+	for _, kv := range kvs {
+		profile, err := storagepb.ParseProfile(kv.Value)
+		if err == nil {
+			err = profile.AssertValid()
+		}
+		if err == nil {
+			profile.ResourceVersion = kv.ModRevision
+			profiles = append(profiles, profile)
+		} else if s.logger != nil {
+			s.logger.Warningf("Profile %q: %v", path.Base(string(kv.Key)), err)
+		}
+	}
+	return profiles, nil
+}
+
+// Check returns a Problem for each group or profile key whose value cannot
+// be parsed.
+func (s *etcdStore) Check() ([]*storagepb.Problem, error) {
+	var problems []*storagepb.Problem
+	for _, kind := range checkKinds {
+		kvs, err := s.list(kind, true)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(kvs))
+		for _, kv := range kvs {
+			names = append(names, path.Base(string(kv.Key)))
+		}
+		problems = append(problems, checkNames(kind, names, getter(s, kind))...)
+	}
+	return problems, nil
+}
+
+// IgnitionPut creates or updates an Ignition template.
+func (s *etcdStore) IgnitionPut(name string, config []byte, version int64) (int64, error) {
+	return s.put(s.key("ignition", name), config, version)
+}
+
+// IgnitionGet gets an Ignition template by name.
+func (s *etcdStore) IgnitionGet(name string) (string, error) {
+	return s.getString(s.key("ignition", name))
+}
+
+// IgnitionVersion gets the resource version of an Ignition template.
+func (s *etcdStore) IgnitionVersion(name string) (int64, error) {
+	return s.version(s.key("ignition", name))
+}
+
+// IgnitionDelete deletes an Ignition template by name.
+func (s *etcdStore) IgnitionDelete(name string) error {
+	return s.delete(s.key("ignition", name))
+}
+
+// IgnitionList lists the names of all Ignition templates.
+func (s *etcdStore) IgnitionList() ([]string, error) {
+	return s.names("ignition")
+}
+
+// GenericPut creates or updates an Generic template.
+func (s *etcdStore) GenericPut(name string, config []byte, version int64) (int64, error) {
+	return s.put(s.key("generic", name), config, version)
+}
+
+// GenericGet gets an Generic template by name.
+func (s *etcdStore) GenericGet(name string) (string, error) {
+	return s.getString(s.key("generic", name))
+}
+
+// GenericVersion gets the resource version of a Generic template.
+func (s *etcdStore) GenericVersion(name string) (int64, error) {
+	return s.version(s.key("generic", name))
+}
+
+// GenericDelete deletes an Generic template by name.
+func (s *etcdStore) GenericDelete(name string) error {
+	return s.delete(s.key("generic", name))
+}
+
+// GenericList lists the names of all Generic templates.
+func (s *etcdStore) GenericList() ([]string, error) {
+	return s.names("generic")
+}
+
+// CloudPut creates or updates a Cloud-Config template.
+func (s *etcdStore) CloudPut(name string, config []byte, version int64) (int64, error) {
+	return s.put(s.key("cloud", name), config, version)
+}
+
+// CloudGet gets a Cloud-Config template by name.
+func (s *etcdStore) CloudGet(name string) (string, error) {
+	return s.getString(s.key("cloud", name))
+}
+
+// CloudVersion gets the resource version of a Cloud-Config template.
+func (s *etcdStore) CloudVersion(name string) (int64, error) {
+	return s.version(s.key("cloud", name))
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *etcdStore) CloudDelete(name string) error {
+	return s.delete(s.key("cloud", name))
+}
+
+// CloudList lists the names of all Cloud-Config templates.
+func (s *etcdStore) CloudList() ([]string, error) {
+	return s.names("cloud")
+}
+
+// PartialPut creates or updates a partial template.
+func (s *etcdStore) PartialPut(name string, config []byte, version int64) (int64, error) {
+	return s.put(s.key("partials", name), config, version)
+}
+
+// PartialGet gets a partial template by name.
+func (s *etcdStore) PartialGet(name string) (string, error) {
+	return s.getString(s.key("partials", name))
+}
+
+// PartialVersion gets the resource version of a partial template.
+func (s *etcdStore) PartialVersion(name string) (int64, error) {
+	return s.version(s.key("partials", name))
+}
+
+// PartialDelete deletes a partial template by name.
+func (s *etcdStore) PartialDelete(name string) error {
+	return s.delete(s.key("partials", name))
+}
+
+// PartialList lists the names of all partial templates.
+func (s *etcdStore) PartialList() ([]string, error) {
+	return s.names("partials")
+}
+
+// HistoryAppend records a Revision of a resource under the history key
+// prefix of the resource.
+func (s *etcdStore) HistoryAppend(revision *storagepb.Revision) error {
+	req, err := s.historyPut(revision)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	_, err = s.kv.Put(ctx, req)
+	return err
+}
+
+// Recording returns an etcdStore of the same resources whose writes are
+// transactions which also put a Revision. The Revision's resource version is
+// unknown until the transaction is applied, so it is left zero and History
+// fills it in from the revision the Revision's key was created at.
+func (s *etcdStore) Recording(revision *storagepb.Revision) Store {
+	recording := *s
+	recording.revision = revision
+	return &recording
+}
+
+// historyPut returns the request which puts a Revision below the history key
+// prefix of its resource.
+func (s *etcdStore) historyPut(revision *storagepb.Revision) (*pb.PutRequest, error) {
+	data, err := json.Marshal(revision)
+	if err != nil {
+		return nil, err
+	}
+	// keys are ordered by the time of the write
+	key := append(s.historyPrefix(revision.Kind, revision.Name), fmt.Sprintf("%020d", time.Now().UnixNano())...)
+	return &pb.PutRequest{Key: key, Value: data}, nil
+}
+
+// History returns the recorded Revisions of a resource, oldest first.
+func (s *etcdStore) History(kind, name string) ([]*storagepb.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	prefix := s.historyPrefix(kind, name)
+	resp, err := s.kv.Range(ctx, &pb.RangeRequest{Key: prefix, RangeEnd: prefixEnd(prefix)})
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]*storagepb.Revision, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		revision := new(storagepb.Revision)
+		if err := json.Unmarshal(kv.Value, revision); err != nil {
+			return nil, err
+		}
+		// Revisions put with a write were created at its revision
+		if revision.ResourceVersion == 0 && !revision.Deleted {
+			revision.ResourceVersion = kv.CreateRevision
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// Watch returns a channel of Events for changes to keys below the prefix,
+// including changes made by other matchbox instances. Events carry the etcd
+// revision of the change.
+func (s *etcdStore) Watch(ctx context.Context) (<-chan *storagepb.Event, error) {
+	if s.watcher == nil {
+		return nil, errEtcdWatchMissing
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.watcher.Watch(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	dir := []byte(s.prefix + "/")
+	err = stream.Send(&pb.WatchRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: dir, RangeEnd: prefixEnd(dir)},
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if !resp.Created || resp.Canceled {
+		cancel()
+		return nil, errEtcdWatchCreate
+	}
+
+	ch := make(chan *storagepb.Event, watchBufferSize)
+	// recorded Revisions and the resources of other namespaces are not
+	// resources of this Store
+	history := []byte(path.Join(s.prefix, etcdHistoryDir) + "/")
+	namespaces := []byte(path.Join(s.prefix, namespacesDir) + "/")
+	go func() {
+		defer close(ch)
+		defer cancel()
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil && s.logger != nil {
+					s.logger.Infof("etcd watch closed: %v", err)
+				}
+				return
+			}
+			if resp.Canceled {
+				if s.logger != nil {
+					s.logger.Infof("etcd watch canceled: %s", resp.CancelReason)
+				}
+				return
+			}
+			for _, ev := range resp.Events {
+				if bytes.HasPrefix(ev.Kv.GetKey(), history) || bytes.HasPrefix(ev.Kv.GetKey(), namespaces) {
+					continue
+				}
+				event, err := s.event(ev)
+				if err != nil {
+					if s.logger != nil {
+						s.logger.Infof("etcd watch %q: %v", ev.Kv.GetKey(), err)
+					}
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// event converts an etcd watch event for a resource key into an Event.
+func (s *etcdStore) event(ev *pb.Event) (*storagepb.Event, error) {
+	kv := ev.Kv
+	if kv == nil {
+		return nil, errors.New("missing key-value")
+	}
+	// keys are of the form prefix/kind/name
+	rel := strings.TrimPrefix(string(kv.Key), s.prefix+"/")
+	parts := strings.SplitN(rel, "/", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("not a resource key")
+	}
+	kind, name := parts[0], parts[1]
+	if ev.Type == pb.Event_DELETE {
+		event := deleteEvent(kind, name)
+		event.Revision = kv.ModRevision
+		return event, nil
+	}
+	event := putEvent(kind, name)
+	event.Revision = kv.ModRevision
+	switch kind {
+	case "groups":
+		group, err := storagepb.ParseGroup(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		group.ResourceVersion = kv.ModRevision
+		event.Group = group
+	case "profiles":
+		profile, err := storagepb.ParseProfile(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		profile.ResourceVersion = kv.ModRevision
+		event.Profile = profile
+	default:
+		event.Template = kv.Value
+	}
+	return event, nil
+}
+
+// key returns the etcd key of the named resource of the given kind. Names
+// are cleaned so they cannot escape their kind's directory.
+func (s *etcdStore) key(kind, name string) string {
+	return path.Join(s.prefix, kind, path.Clean("/"+name))
+}
+
+// historyPrefix returns the key prefix of the history of a resource. A 0
+// byte ends the name, so nested template names do not share a prefix.
+func (s *etcdStore) historyPrefix(kind, name string) []byte {
+	return append([]byte(path.Join(s.prefix, etcdHistoryDir, kind, path.Clean("/"+name))), 0)
+}
+
+// get returns the key-value of a key or an error satisfying os.IsNotExist if
+// the key does not exist. With keysOnly, the value is omitted.
+func (s *etcdStore) get(key string, keysOnly bool) (*pb.KeyValue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	resp, err := s.kv.Range(ctx, &pb.RangeRequest{Key: []byte(key), KeysOnly: keysOnly})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, notExist("get", key)
+	}
+	return resp.Kvs[0], nil
+}
+
+// getString returns the value of a key as a string.
+func (s *etcdStore) getString(key string) (string, error) {
+	kv, err := s.get(key, false)
+	if err != nil {
+		return "", err
+	}
+	return string(kv.Value), nil
+}
+
+// version returns the mod revision of a key.
+func (s *etcdStore) version(key string) (int64, error) {
+	kv, err := s.get(key, true)
+	if err != nil {
+		return 0, err
+	}
+	return kv.ModRevision, nil
+}
+
+// put writes the value of a key and returns the new mod revision. With a
+// non-zero expected version, or a Revision to record, the write is a
+// transaction, which only succeeds if the key's mod revision is the expected
+// version.
+func (s *etcdStore) put(key string, value []byte, expected int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	req := &pb.PutRequest{Key: []byte(key), Value: value}
+	if expected == 0 && s.revision == nil {
+		resp, err := s.kv.Put(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		return resp.Header.GetRevision(), nil
+	}
+	txn := &pb.TxnRequest{
+		Success: []*pb.RequestOp{{RequestPut: req}},
+	}
+	if expected != 0 {
+		txn.Compare = []*pb.Compare{{
+			Result:      pb.Compare_EQUAL,
+			Target:      pb.Compare_MOD,
+			Key:         []byte(key),
+			ModRevision: expected,
+		}}
+	}
+	if s.revision != nil {
+		history, err := s.historyPut(s.revision)
+		if err != nil {
+			return 0, err
+		}
+		txn.Success = append(txn.Success, &pb.RequestOp{RequestPut: history})
+	}
+	resp, err := s.kv.Txn(ctx, txn)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.Succeeded {
+		return 0, ErrVersionConflict
+	}
+	return resp.Header.GetRevision(), nil
+}
+
+// delete removes a key or returns an error satisfying os.IsNotExist if
+// the key does not exist. With a Revision to record, the delete is a
+// transaction which also puts the Revision if the key exists.
+func (s *etcdStore) delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	req := &pb.DeleteRangeRequest{Key: []byte(key)}
+	if s.revision == nil {
+		resp, err := s.kv.DeleteRange(ctx, req)
+		if err != nil {
+			return err
+		}
+		if resp.Deleted == 0 {
+			return notExist("delete", key)
+		}
+		return nil
+	}
+	history, err := s.historyPut(s.revision)
+	if err != nil {
+		return err
+	}
+	resp, err := s.kv.Txn(ctx, &pb.TxnRequest{
+		Compare: []*pb.Compare{{
+			Result: pb.Compare_GREATER,
+			Target: pb.Compare_VERSION,
+			Key:    []byte(key),
+		}},
+		Success: []*pb.RequestOp{{RequestDeleteRange: req}, {RequestPut: history}},
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return notExist("delete", key)
+	}
+	return nil
+}
+
+// list returns the key-values directly below the given kind's directory,
+// sorted by key. With keysOnly, values are omitted.
+func (s *etcdStore) list(kind string, keysOnly bool) ([]*pb.KeyValue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	dir := path.Join(s.prefix, kind) + "/"
+	resp, err := s.kv.Range(ctx, &pb.RangeRequest{
+		Key:      []byte(dir),
+		RangeEnd: prefixEnd([]byte(dir)),
+		KeysOnly: keysOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	kvs := make([]*pb.KeyValue, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		if !strings.Contains(strings.TrimPrefix(string(kv.Key), dir), "/") {
+			kvs = append(kvs, kv)
+		}
+	}
+	return kvs, nil
+}
+
+// names returns the names of all resources below the given kind's
+// directory, including nested ones, sorted by key.
+func (s *etcdStore) names(kind string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+	dir := path.Join(s.prefix, kind) + "/"
+	resp, err := s.kv.Range(ctx, &pb.RangeRequest{
+		Key:      []byte(dir),
+		RangeEnd: prefixEnd([]byte(dir)),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		names = append(names, strings.TrimPrefix(string(kv.Key), dir))
+	}
+	return names, nil
+}
+
+// prefixEnd returns the range end which selects all keys with the given
+// prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// all 0xff bytes, select every key
+	return []byte{0}
+}
+
+// notExist returns an error for a missing key which satisfies os.IsNotExist,
+// like the errors returned by the fileStore.
+func notExist(op, key string) error {
+	return &os.PathError{Op: op, Path: key, Err: os.ErrNotExist}
+}
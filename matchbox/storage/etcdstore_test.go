@@ -0,0 +1,431 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	pb "github.com/coreos/matchbox/matchbox/storage/etcdserverpb"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestEtcdGroupCRUD(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Group creation was successful
+	// - Group can be retrieved by id
+	// - Group can be deleted by id
+	version, err := store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withGroupVersion(fake.Group, version), group)
+
+	err = store.GroupDelete(fake.Group.Id)
+	assert.Nil(t, err)
+	_, err = store.GroupGet(fake.Group.Id)
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+		assert.True(t, os.IsNotExist(err))
+	}
+	err = store.GroupDelete(fake.Group.Id)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestEtcdGroupGet(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{
+			fake.Group.Id:           fake.Group,
+			fake.GroupNoMetadata.Id: fake.GroupNoMetadata,
+		},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Groups written to the store can be retrieved
+	// - Groups have the mod revision of their key as resource version
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.True(t, group.ResourceVersion > 0)
+	assert.Equal(t, fake.Group, withGroupVersion(group, 0))
+	group, err = store.GroupGet(fake.GroupNoMetadata.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.GroupNoMetadata, withGroupVersion(group, 0))
+}
+
+func TestEtcdGroupGet_NoGroup(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	_, err = store.GroupGet("no-such-group")
+	if assert.Error(t, err) {
+		assert.IsType(t, &os.PathError{}, err)
+	}
+}
+
+func TestEtcdGroupList(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{
+			fake.Group.Id:           fake.Group,
+			fake.GroupNoMetadata.Id: fake.GroupNoMetadata,
+		},
+		// keys in other directories must not be listed
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(groups)) {
+		assert.Contains(t, unversionedGroups(groups), fake.Group)
+		assert.Contains(t, unversionedGroups(groups), fake.GroupNoMetadata)
+		assert.NotContains(t, groups, &storagepb.Group{})
+	}
+}
+
+func TestEtcdGroupVersion(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	versioner := store.(GroupVersioner)
+	v1, err := versioner.GroupVersion()
+	assert.Nil(t, err)
+	// writes to other kinds do not change the version
+	_, err = store.ProfilePut(fake.Profile)
+	assert.Nil(t, err)
+	v2, err := versioner.GroupVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, v1, v2)
+
+	_, err = store.GroupPut(fake.GroupNoMetadata)
+	assert.Nil(t, err)
+	v3, err := versioner.GroupVersion()
+	assert.Nil(t, err)
+	assert.NotEqual(t, v2, v3)
+	assert.Nil(t, store.GroupDelete(fake.Group.Id))
+	v4, err := versioner.GroupVersion()
+	assert.Nil(t, err)
+	assert.NotEqual(t, v3, v4)
+}
+
+func TestEtcdProfileCRUD(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Profile creation was successful
+	// - Profile can be retrieved by id
+	// - Profile can be deleted by id
+	version, err := store.ProfilePut(fake.Profile)
+	assert.Nil(t, err)
+
+	profile, err := store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withProfileVersion(fake.Profile, version), profile)
+
+	err = store.ProfileDelete(fake.Profile.Id)
+	assert.Nil(t, err)
+	_, err = store.ProfileGet(fake.Profile.Id)
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+	}
+}
+
+func TestEtcdProfileGet(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	profile, err := store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Profile, withProfileVersion(profile, 0))
+	_, err = store.ProfileGet("no-such-profile")
+	if assert.Error(t, err) {
+		assert.IsType(t, &os.PathError{}, err)
+	}
+}
+
+func TestEtcdProfileList(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	profiles, err := store.ProfileList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(profiles)) {
+		assert.Equal(t, fake.Profile, withProfileVersion(profiles[0], 0))
+	}
+}
+
+func TestEtcdIgnitionCRUD(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Ignition template creation was successful
+	// - Ignition template can be retrieved by name
+	// - Ignition template can be deleted by name
+	_, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), 0)
+	assert.Nil(t, err)
+
+	template, err := store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+
+	err = store.IgnitionDelete(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	_, err = store.IgnitionGet(fake.IgnitionYAMLName)
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+	}
+}
+
+func TestEtcdGenericCRUD(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - Generic template creation was successful
+	// - Generic template can be retrieved by name
+	// - Generic template can be deleted by name
+	_, err = store.GenericPut(fake.GenericName, []byte(fake.Generic), 0)
+	assert.Nil(t, err)
+
+	template, err := store.GenericGet(fake.GenericName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Generic, template)
+
+	err = store.GenericDelete(fake.GenericName)
+	assert.Nil(t, err)
+	_, err = store.GenericGet(fake.GenericName)
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+	}
+}
+
+func TestEtcdCloudGet(t *testing.T) {
+	contents := "#cloud-config"
+	store, cleanup, err := setupEtcd(&fake.FixedStore{
+		CloudConfigs: map[string]string{"cloudcfg.yaml": contents},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	cfg, err := store.CloudGet("cloudcfg.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, contents, cfg)
+}
+
+func TestEtcdTemplates(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testTemplates(t, store)
+}
+
+func TestEtcdConditionalPut(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testConditionalPut(t, store)
+}
+
+func TestEtcdConditionalPut_Concurrent(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	version, err := store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+
+	// assert that of concurrent conditional puts of the same version, exactly
+	// one succeeds and the others conflict
+	const writers = 8
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		go func() {
+			_, err := store.GroupPut(withGroupVersion(fake.Group, version))
+			errs <- err
+		}()
+	}
+	succeeded := 0
+	for i := 0; i < writers; i++ {
+		if err := <-errs; err == nil {
+			succeeded++
+		} else {
+			assert.Equal(t, ErrVersionConflict, err)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestEtcdHistory(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testHistory(t, store)
+}
+
+func TestEtcdRecording(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testRecording(t, store)
+}
+
+func TestEtcdNamespaces(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testNamespaces(t, store)
+}
+
+func TestEtcdHistory_NotWatched(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := store.Watch(ctx)
+	assert.Nil(t, err)
+	// assert that recorded Revisions are not reported as resource Events
+	err = store.(Historian).HistoryAppend(&storagepb.Revision{Kind: "generic", Name: fake.GenericName, ResourceVersion: 1})
+	assert.Nil(t, err)
+	_, err = store.GenericPut(fake.GenericName, []byte(fake.Generic), 0)
+	assert.Nil(t, err)
+	select {
+	case event := <-events:
+		assert.Equal(t, "generic", event.Kind)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for generic event")
+	}
+}
+
+func TestEtcdWatch(t *testing.T) {
+	store, cleanup, err := setupEtcd(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testWatch(t, store)
+}
+
+func TestEtcdKey(t *testing.T) {
+	s := newEtcdStore(nil, "", nil)
+	cases := []struct {
+		kind     string
+		name     string
+		expected string
+	}{
+		{"groups", "node1", "/matchbox/groups/node1"},
+		{"ignition", "../profiles/p", "/matchbox/ignition/profiles/p"},
+		{"generic", "/a/../b", "/matchbox/generic/b"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, s.key(c.kind, c.name))
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, []byte("/matchbox/groups0"), prefixEnd([]byte("/matchbox/groups/")))
+	assert.Equal(t, []byte("b"), prefixEnd([]byte("a\xff")))
+	assert.Equal(t, []byte{0}, prefixEnd([]byte{0xff}))
+}
+
+// unversionedGroups returns copies of Groups without resource versions.
+func unversionedGroups(groups []*storagepb.Group) []*storagepb.Group {
+	copies := make([]*storagepb.Group, len(groups))
+	for i, group := range groups {
+		copies[i] = withGroupVersion(group, 0)
+	}
+	return copies
+}
+
+// setupEtcd returns an etcd Store whose resources mirror a given fixedStore,
+// connected to the etcd of testEtcdConfig. The caller must call the returned
+// cleanup function when finished, which deletes the resources.
+func setupEtcd(fixedStore *fake.FixedStore) (Store, func(), error) {
+	config, stop, err := testEtcdConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := NewEtcdStore(config)
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+	kv := store.(*etcdStore).kv
+	prefix := []byte(store.(*etcdStore).prefix + "/")
+	cleanup := func() {
+		kv.DeleteRange(context.Background(), &pb.DeleteRangeRequest{Key: prefix, RangeEnd: prefixEnd(prefix)})
+		stop()
+	}
+
+	put := func(kind, name string, value []byte) error {
+		_, err := kv.Put(context.Background(), &pb.PutRequest{Key: []byte(store.(*etcdStore).key(kind, name)), Value: value})
+		return err
+	}
+	for _, profile := range fixedStore.Profiles {
+		data, err := json.MarshalIndent(profile, "", "\t")
+		if err != nil {
+			return store, cleanup, err
+		}
+		if err := put("profiles", profile.Id, data); err != nil {
+			return store, cleanup, err
+		}
+	}
+	for _, group := range fixedStore.Groups {
+		richGroup, err := group.ToRichGroup()
+		if err != nil {
+			return store, cleanup, err
+		}
+		data, err := json.MarshalIndent(richGroup, "", "\t")
+		if err != nil {
+			return store, cleanup, err
+		}
+		if err := put("groups", group.Id, data); err != nil {
+			return store, cleanup, err
+		}
+	}
+	for name, content := range fixedStore.IgnitionConfigs {
+		if err := put("ignition", name, []byte(content)); err != nil {
+			return store, cleanup, err
+		}
+	}
+	for name, content := range fixedStore.GenericConfigs {
+		if err := put("generic", name, []byte(content)); err != nil {
+			return store, cleanup, err
+		}
+	}
+	for name, content := range fixedStore.CloudConfigs {
+		if err := put("cloud", name, []byte(content)); err != nil {
+			return store, cleanup, err
+		}
+	}
+	for name, content := range fixedStore.Partials {
+		if err := put("partials", name, []byte(content)); err != nil {
+			return store, cleanup, err
+		}
+	}
+	return store, cleanup, nil
+}
@@ -1,14 +1,42 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
 )
 
+// resourceExts are the extensions of group and profile files, in order of
+// precedence. Groups and Profiles are written as JSON, unless they were read
+// from a YAML file.
+var resourceExts = []string{".json", ".yaml", ".yml"}
+
+const (
+	// versionsDir is the directory below the root in which the fileStore
+	// keeps the resource version of each resource file, at the resource's
+	// path.
+	versionsDir = ".versions"
+	// historyDir is the directory below the root in which the fileStore keeps
+	// a directory of numbered Revision files for each resource.
+	historyDir = ".history"
+	// whiteoutsDir is the directory below the root in which the fileStore
+	// keeps an empty whiteout file for each whited out resource, at the
+	// resource's name.
+	whiteoutsDir = ".whiteouts"
+)
+
 // Config initializes a fileStore.
 type Config struct {
 	Root   string
@@ -16,10 +44,18 @@ type Config struct {
 }
 
 // fileStore implements ths Store interface. Queries to the file system
-// are restricted to the specified directory tree.
+// are restricted to the specified directory tree. Only writes made through
+// the fileStore are reported to watchers.
+//
+// Resource versions are kept in files below the versions directory. A
+// resource file without a version file (e.g. one written by hand) has
+// version 1.
 type fileStore struct {
-	root   string
-	logger *logrus.Logger
+	root     string
+	logger   *logrus.Logger
+	watchers watchHub
+	// serializes writes so conditional puts can check versions
+	mu sync.Mutex
 }
 
 // NewFileStore returns a new memory-backed Store.
@@ -30,109 +66,251 @@ func NewFileStore(config *Config) Store {
 	}
 }
 
+// Namespace returns a fileStore of the resources of a namespace, kept in a
+// directory tree of the same layout below the namespaces directory.
+func (s *fileStore) Namespace(name string) (Store, error) {
+	if !ValidNamespace(name) {
+		return nil, ErrInvalidNamespace
+	}
+	return &fileStore{
+		root:   filepath.Join(s.root, namespacesDir, name),
+		logger: s.logger,
+	}, nil
+}
+
+// HasNamespace returns true if the directory tree of a namespace exists.
+func (s *fileStore) HasNamespace(name string) (bool, error) {
+	info, err := os.Stat(filepath.Join(s.root, namespacesDir, name))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
 // GroupPut writes the given Group.
-func (s *fileStore) GroupPut(group *storagepb.Group) error {
-	richGroup, err := group.ToRichGroup()
+func (s *fileStore) GroupPut(group *storagepb.Group) (int64, error) {
+	return s.groupPut(group, 0)
+}
+
+// groupPut writes the given Group with a version greater than a minimum
+// version.
+func (s *fileStore) groupPut(group *storagepb.Group, min int64) (int64, error) {
+	file := s.resourceFile("groups", group.Id)
+	data, err := marshalGroup(group)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	data, err := json.MarshalIndent(richGroup, "", "\t")
+	if data, err = fileFormat(file, data); err != nil {
+		return 0, err
+	}
+	version, err := s.write(file, data, group.ResourceVersion, min)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return Dir(s.root).writeFile(filepath.Join("groups", group.Id+".json"), data)
+	event := putEvent("groups", group.Id)
+	event.Group = withGroupVersion(group, version)
+	s.watchers.publish(event)
+	return version, nil
 }
 
 // GroupGet returns a machine Group by id.
 func (s *fileStore) GroupGet(id string) (*storagepb.Group, error) {
-	data, err := Dir(s.root).readFile(filepath.Join("groups", id+".json"))
+	file := s.resourceFile("groups", id)
+	// read the version first, so a concurrent write leads to a conflict
+	// rather than an update based on stale contents
+	version, _, err := s.version(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := Dir(s.root).readFile(file)
 	if err != nil {
 		return nil, err
 	}
-	group, err := storagepb.ParseGroup(data)
+	group, err := storagepb.ParseGroupFile(file, data)
 	if err != nil {
 		return nil, err
 	}
+	group.ResourceVersion = version
 	return group, err
 }
 
 // GroupDelete deletes a machine Group by id.
 func (s *fileStore) GroupDelete(id string) error {
-	return Dir(s.root).deleteFile(filepath.Join("groups", id+".json"))
+	if err := s.delete(s.resourceFile("groups", id)); err != nil {
+		return err
+	}
+	s.watchers.publish(deleteEvent("groups", id))
+	return nil
 }
 
 // GroupList lists all machine Groups.
 func (s *fileStore) GroupList() ([]*storagepb.Group, error) {
-	files, err := Dir(s.root).readDir("groups")
+	names, err := s.resourceNames("groups")
 	if err != nil {
 		return nil, err
 	}
-	groups := make([]*storagepb.Group, 0, len(files))
-	for _, finfo := range files {
-		name := strings.TrimSuffix(finfo.Name(), filepath.Ext(finfo.Name()))
+	groups := make([]*storagepb.Group, 0, len(names))
+	for _, name := range names {
 		group, err := s.GroupGet(name)
 		if err == nil {
 			groups = append(groups, group)
 		} else if s.logger != nil {
-			s.logger.Infof("Group %q: %v", name, err)
+			s.logger.Warningf("Group %q: %v", name, err)
 		}
 	}
 	return groups, nil
 }
 
+// GroupVersion returns a fingerprint of the names, sizes, and modification
+// times of the group files, which changes when group files are added,
+// removed, or edited. Symlinked group files are fingerprinted by their
+// targets, since a symlink is unchanged when its target is replaced, as when
+// a mounted Kubernetes ConfigMap is updated.
+func (s *fileStore) GroupVersion() (string, error) {
+	files, err := s.readDir("groups")
+	if err != nil {
+		return "", err
+	}
+	dir, err := Dir(s.root).sanitize("groups")
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New64a()
+	for _, finfo := range files {
+		var target string
+		if finfo.Mode()&os.ModeSymlink != 0 {
+			if target, err = filepath.EvalSymlinks(filepath.Join(dir, finfo.Name())); err == nil {
+				finfo, err = os.Stat(target)
+			}
+			// dangling symlinks are fingerprinted as they are
+			if err != nil && !os.IsNotExist(err) {
+				return "", err
+			}
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00%d\x00%d\x00", finfo.Name(), target, finfo.Size(), finfo.ModTime().UnixNano())
+	}
+	return fmt.Sprintf("%x", h.Sum64()), nil
+}
+
 // ProfilePut writes the given Profile.
-func (s *fileStore) ProfilePut(profile *storagepb.Profile) error {
-	data, err := json.MarshalIndent(profile, "", "\t")
+func (s *fileStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	return s.profilePut(profile, 0)
+}
+
+// profilePut writes the given Profile with a version greater than a minimum
+// version.
+func (s *fileStore) profilePut(profile *storagepb.Profile, min int64) (int64, error) {
+	file := s.resourceFile("profiles", profile.Id)
+	data, err := marshalProfile(profile)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if data, err = fileFormat(file, data); err != nil {
+		return 0, err
 	}
-	return Dir(s.root).writeFile(filepath.Join("profiles", profile.Id+".json"), data)
+	version, err := s.write(file, data, profile.ResourceVersion, min)
+	if err != nil {
+		return 0, err
+	}
+	event := putEvent("profiles", profile.Id)
+	event.Profile = withProfileVersion(profile, version)
+	s.watchers.publish(event)
+	return version, nil
 }
 
 // ProfileGet gets a profile by id.
 func (s *fileStore) ProfileGet(id string) (*storagepb.Profile, error) {
-	data, err := Dir(s.root).readFile(filepath.Join("profiles", id+".json"))
+	file := s.resourceFile("profiles", id)
+	version, _, err := s.version(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := Dir(s.root).readFile(file)
 	if err != nil {
 		return nil, err
 	}
-	profile := new(storagepb.Profile)
-	err = json.Unmarshal(data, profile)
+	profile, err := storagepb.ParseProfileFile(file, data)
 	if err != nil {
 		return nil, err
 	}
 	if err := profile.AssertValid(); err != nil {
 		return nil, err
 	}
+	profile.ResourceVersion = version
 	return profile, err
 }
 
 // ProfileDelete deletes a profile by id.
 func (s *fileStore) ProfileDelete(id string) error {
-	return Dir(s.root).deleteFile(filepath.Join("profiles", id+".json"))
+	if err := s.delete(s.resourceFile("profiles", id)); err != nil {
+		return err
+	}
+	s.watchers.publish(deleteEvent("profiles", id))
+	return nil
 }
 
 // ProfileList lists all profiles.
 func (s *fileStore) ProfileList() ([]*storagepb.Profile, error) {
-	files, err := Dir(s.root).readDir("profiles")
+	names, err := s.resourceNames("profiles")
 	if err != nil {
 		return nil, err
 	}
-	profiles := make([]*storagepb.Profile, 0, len(files))
-	for _, finfo := range files {
-		name := strings.TrimSuffix(finfo.Name(), filepath.Ext(finfo.Name()))
+	profiles := make([]*storagepb.Profile, 0, len(names))
+	for _, name := range names {
 		profile, err := s.ProfileGet(name)
 		if err == nil {
 			profiles = append(profiles, profile)
 		} else if s.logger != nil {
-			s.logger.Infof("Profile %q: %v", name, err)
+			s.logger.Warningf("Profile %q: %v", name, err)
 		}
 	}
 	return profiles, nil
 }
 
+// Check returns a Problem for each group or profile file which cannot be
+// parsed and for each id with several files (e.g. node1.json and
+// node1.yaml), of which only the first one by precedence is read.
+func (s *fileStore) Check() ([]*storagepb.Problem, error) {
+	var problems []*storagepb.Problem
+	for _, kind := range checkKinds {
+		files, err := s.readDir(kind)
+		if err != nil {
+			return nil, err
+		}
+		byName := make(map[string][]string)
+		for _, finfo := range files {
+			if name, ok := resourceName(finfo); ok {
+				byName[name] = append(byName[name], finfo.Name())
+			}
+		}
+		names, err := s.resourceNames(kind)
+		if err != nil {
+			return nil, err
+		}
+		kindProblems := checkNames(kind, names, getter(s, kind))
+		for _, name := range names {
+			if len(byName[name]) > 1 {
+				kindProblems = append(kindProblems, &storagepb.Problem{
+					Kind:    kind,
+					Name:    name,
+					Message: fmt.Sprintf("several files %s, only %s is read", strings.Join(byName[name], ", "), filepath.Base(s.resourceFile(kind, name))),
+				})
+			}
+		}
+		sort.SliceStable(kindProblems, func(i, j int) bool {
+			return kindProblems[i].Name < kindProblems[j].Name
+		})
+		problems = append(problems, kindProblems...)
+	}
+	return problems, nil
+}
+
 // IgnitionPut creates or updates an Ignition template.
-func (s *fileStore) IgnitionPut(name string, config []byte) error {
-	return Dir(s.root).writeFile(filepath.Join("ignition", name), config)
+func (s *fileStore) IgnitionPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("ignition", name, config, version, 0)
 }
 
 // IgnitionGet gets an Ignition template by name.
@@ -141,14 +319,29 @@ func (s *fileStore) IgnitionGet(name string) (string, error) {
 	return string(data), err
 }
 
+// IgnitionVersion gets the resource version of an Ignition template.
+func (s *fileStore) IgnitionVersion(name string) (int64, error) {
+	version, _, err := s.version(filepath.Join("ignition", name))
+	return version, err
+}
+
 // IgnitionDelete deletes an Ignition template by name.
 func (s *fileStore) IgnitionDelete(name string) error {
-	return Dir(s.root).deleteFile(filepath.Join("ignition", name))
+	if err := s.delete(filepath.Join("ignition", name)); err != nil {
+		return err
+	}
+	s.watchers.publish(deleteEvent("ignition", name))
+	return nil
+}
+
+// IgnitionList lists the names of all Ignition templates.
+func (s *fileStore) IgnitionList() ([]string, error) {
+	return s.templateList("ignition")
 }
 
 // GenericPut creates or updates an Generic template.
-func (s *fileStore) GenericPut(name string, config []byte) error {
-	return Dir(s.root).writeFile(filepath.Join("generic", name), config)
+func (s *fileStore) GenericPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("generic", name, config, version, 0)
 }
 
 // GenericGet gets an Generic template by name.
@@ -157,9 +350,29 @@ func (s *fileStore) GenericGet(name string) (string, error) {
 	return string(data), err
 }
 
+// GenericVersion gets the resource version of a Generic template.
+func (s *fileStore) GenericVersion(name string) (int64, error) {
+	version, _, err := s.version(filepath.Join("generic", name))
+	return version, err
+}
+
 // GenericDelete deletes an Generic template by name.
 func (s *fileStore) GenericDelete(name string) error {
-	return Dir(s.root).deleteFile(filepath.Join("generic", name))
+	if err := s.delete(filepath.Join("generic", name)); err != nil {
+		return err
+	}
+	s.watchers.publish(deleteEvent("generic", name))
+	return nil
+}
+
+// GenericList lists the names of all Generic templates.
+func (s *fileStore) GenericList() ([]string, error) {
+	return s.templateList("generic")
+}
+
+// CloudPut creates or updates a Cloud-Config template.
+func (s *fileStore) CloudPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("cloud", name, config, version, 0)
 }
 
 // CloudGet gets a Cloud-Config template by name.
@@ -167,3 +380,343 @@ func (s *fileStore) CloudGet(name string) (string, error) {
 	data, err := Dir(s.root).readFile(filepath.Join("cloud", name))
 	return string(data), err
 }
+
+// CloudVersion gets the resource version of a Cloud-Config template.
+func (s *fileStore) CloudVersion(name string) (int64, error) {
+	version, _, err := s.version(filepath.Join("cloud", name))
+	return version, err
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *fileStore) CloudDelete(name string) error {
+	if err := s.delete(filepath.Join("cloud", name)); err != nil {
+		return err
+	}
+	s.watchers.publish(deleteEvent("cloud", name))
+	return nil
+}
+
+// CloudList lists the names of all Cloud-Config templates.
+func (s *fileStore) CloudList() ([]string, error) {
+	return s.templateList("cloud")
+}
+
+// PartialPut creates or updates a partial template.
+func (s *fileStore) PartialPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("partials", name, config, version, 0)
+}
+
+// PartialGet gets a partial template by name.
+func (s *fileStore) PartialGet(name string) (string, error) {
+	data, err := Dir(s.root).readFile(filepath.Join("partials", name))
+	return string(data), err
+}
+
+// PartialVersion gets the resource version of a partial template.
+func (s *fileStore) PartialVersion(name string) (int64, error) {
+	version, _, err := s.version(filepath.Join("partials", name))
+	return version, err
+}
+
+// PartialDelete deletes a partial template by name.
+func (s *fileStore) PartialDelete(name string) error {
+	if err := s.delete(filepath.Join("partials", name)); err != nil {
+		return err
+	}
+	s.watchers.publish(deleteEvent("partials", name))
+	return nil
+}
+
+// PartialList lists the names of all partial templates.
+func (s *fileStore) PartialList() ([]string, error) {
+	return s.templateList("partials")
+}
+
+// Watch returns a channel of Events for writes made through the fileStore.
+func (s *fileStore) Watch(ctx context.Context) (<-chan *storagepb.Event, error) {
+	return s.watchers.watch(ctx), nil
+}
+
+// HistoryAppend records a Revision of a resource in the resource's history
+// directory.
+func (s *fileStore) HistoryAppend(revision *storagepb.Revision) error {
+	data, err := json.MarshalIndent(revision, "", "\t")
+	if err != nil {
+		return err
+	}
+	dir := historyPath(revision.Kind, revision.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files, err := s.historyFiles(dir)
+	if err != nil {
+		return err
+	}
+	return Dir(s.root).writeFile(filepath.Join(dir, fmt.Sprintf("%08d.json", len(files)+1)), data)
+}
+
+// History returns the recorded Revisions of a resource, oldest first.
+func (s *fileStore) History(kind, name string) ([]*storagepb.Revision, error) {
+	dir := historyPath(kind, name)
+	files, err := s.historyFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]*storagepb.Revision, 0, len(files))
+	for _, finfo := range files {
+		data, err := Dir(s.root).readFile(filepath.Join(dir, finfo.Name()))
+		if err != nil {
+			return nil, err
+		}
+		revision := new(storagepb.Revision)
+		if err := json.Unmarshal(data, revision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// WhiteoutPut records a whiteout of a resource.
+func (s *fileStore) WhiteoutPut(kind, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Dir(s.root).writeFile(filepath.Join(whiteoutsDir, kind, name), nil)
+}
+
+// WhiteoutDelete removes the whiteout of a resource, if any.
+func (s *fileStore) WhiteoutDelete(kind, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := Dir(s.root).deleteFile(filepath.Join(whiteoutsDir, kind, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Whiteouts returns the names of the whited out resources of a kind.
+func (s *fileStore) Whiteouts(kind string) ([]string, error) {
+	return s.templateList(filepath.Join(whiteoutsDir, kind))
+}
+
+// historyFiles returns the sorted Revision files in a history directory.
+// Subdirectories hold the history of templates nested below the resource's
+// name and are skipped.
+func (s *fileStore) historyFiles(dir string) ([]os.FileInfo, error) {
+	entries, err := Dir(s.root).readDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var files []os.FileInfo
+	for _, finfo := range entries {
+		if !finfo.IsDir() && filepath.Ext(finfo.Name()) == ".json" {
+			files = append(files, finfo)
+		}
+	}
+	return files, nil
+}
+
+// templatePut writes a template of the given kind with a version greater
+// than a minimum version and publishes the Event.
+func (s *fileStore) templatePut(kind, name string, config []byte, expected, min int64) (int64, error) {
+	version, err := s.write(filepath.Join(kind, name), config, expected, min)
+	if err != nil {
+		return 0, err
+	}
+	event := putEvent(kind, name)
+	event.Template = config
+	s.watchers.publish(event)
+	return version, nil
+}
+
+// resourceFile returns the file of a Group or Profile: the first existing
+// file of its id with a resource extension, in order of precedence, or else
+// the JSON file.
+func (s *fileStore) resourceFile(kind, id string) string {
+	for _, ext := range resourceExts {
+		file := filepath.Join(kind, id+ext)
+		if _, err := Dir(s.root).stat(file); err == nil {
+			return file
+		}
+	}
+	return filepath.Join(kind, id+resourceExts[0])
+}
+
+// resourceNames returns the sorted ids of the Groups or Profiles of a kind's
+// directory. Directories, such as those of example groups, and files without
+// a resource extension are ignored.
+func (s *fileStore) resourceNames(kind string) ([]string, error) {
+	files, err := s.readDir(kind)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(files))
+	for _, finfo := range files {
+		if name, ok := resourceName(finfo); ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readDir returns the sorted entries of a directory of a resource kind. A
+// missing directory, e.g. of a namespace without resources of the kind, has
+// no entries.
+func (s *fileStore) readDir(kind string) ([]os.FileInfo, error) {
+	files, err := Dir(s.root).readDir(kind)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return files, err
+}
+
+// templateList returns the slash separated paths of the template files below
+// a kind's directory, in lexical order. A missing directory has no templates.
+// Symlinked directories and files are followed, e.g. for a kind directory or
+// templates mounted from elsewhere, and hidden entries, such as the ..data
+// links of a mounted Kubernetes ConfigMap, are skipped.
+func (s *fileStore) templateList(kind string) ([]string, error) {
+	return Dir(s.root).templateList(kind)
+}
+
+// templateList returns the slash separated paths of the template files below
+// a kind's directory, following the rules of fileStore.templateList.
+func (d Dir) templateList(kind string) ([]string, error) {
+	dir, err := d.sanitize(kind)
+	if err != nil {
+		return nil, err
+	}
+	finfo, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !finfo.IsDir() {
+		return nil, fmt.Errorf("matchbox: %s is not a directory", dir)
+	}
+	var names []string
+	err = walkTemplates(dir, "", make(map[string]bool), &names)
+	return names, err
+}
+
+// walkTemplates appends the slash separated paths of the regular files below
+// a directory, prefixed by the directory's path, following symlinks. The real
+// paths of visited directories are tracked, so symlink cycles end.
+func walkTemplates(dir, prefix string, visited map[string]bool, names *[]string) error {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if visited[real] {
+		return nil
+	}
+	visited[real] = true
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, finfo := range files {
+		if strings.HasPrefix(finfo.Name(), ".") {
+			continue
+		}
+		file := filepath.Join(dir, finfo.Name())
+		if finfo.Mode()&os.ModeSymlink != 0 {
+			if finfo, err = os.Stat(file); os.IsNotExist(err) {
+				// skip dangling symlinks
+				continue
+			} else if err != nil {
+				return err
+			}
+		}
+		switch {
+		case finfo.IsDir():
+			if err := walkTemplates(file, prefix+finfo.Name()+"/", visited, names); err != nil {
+				return err
+			}
+		case finfo.Mode().IsRegular():
+			*names = append(*names, prefix+finfo.Name())
+		}
+	}
+	return nil
+}
+
+// write writes a resource file and its new version, which is greater than a
+// minimum version, unless a non-zero expected version differs from the
+// current version of the resource.
+func (s *fileStore) write(file string, data []byte, expected, min int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, last, err := s.version(file)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if expected != 0 && expected != current {
+		return 0, ErrVersionConflict
+	}
+	if err := Dir(s.root).writeFile(file, data); err != nil {
+		return 0, err
+	}
+	// versions of deleted resources are kept, so a re-created resource
+	// never reuses a version
+	if last < current {
+		last = current
+	}
+	if last < min {
+		last = min
+	}
+	version := last + 1
+	err = Dir(s.root).writeFile(filepath.Join(versionsDir, file), []byte(strconv.FormatInt(version, 10)))
+	return version, err
+}
+
+// delete removes a resource file. Its version file is kept.
+func (s *fileStore) delete(file string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Dir(s.root).deleteFile(file)
+}
+
+// version returns the current version of a resource file and the last
+// version written. The current version is 0 and the error satisfies
+// os.IsNotExist if the resource file does not exist.
+func (s *fileStore) version(file string) (current, last int64, err error) {
+	if data, err := Dir(s.root).readFile(filepath.Join(versionsDir, file)); err == nil {
+		last, _ = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	}
+	if _, err := Dir(s.root).stat(file); err != nil {
+		return 0, last, err
+	}
+	if last == 0 {
+		return 1, last, nil
+	}
+	return last, last, nil
+}
+
+// resourceName returns the id of a group or profile file and true, or false
+// if the file is a directory or has no resource extension.
+func resourceName(finfo os.FileInfo) (string, bool) {
+	ext := filepath.Ext(finfo.Name())
+	if finfo.IsDir() || !containsString(resourceExts, ext) {
+		return "", false
+	}
+	return strings.TrimSuffix(finfo.Name(), ext), true
+}
+
+// fileFormat returns JSON data in the format of a group or profile file,
+// YAML if the file has a YAML extension.
+func fileFormat(file string, data []byte) ([]byte, error) {
+	if storagepb.IsYAML(file) {
+		return storagepb.JSONToYAML(data)
+	}
+	return data, nil
+}
+
+// historyPath returns the history directory of a resource. Names are cleaned
+// so they cannot escape their kind's directory.
+func historyPath(kind, name string) string {
+	return filepath.Join(historyDir, kind, filepath.FromSlash(path.Clean("/"+name)))
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -23,12 +24,12 @@ func TestGroupCRUD(t *testing.T) {
 	// - Group creation was successful
 	// - Group can be retrieved by id
 	// - Group can be deleted by id
-	err = store.GroupPut(fake.Group)
+	version, err := store.GroupPut(fake.Group)
 	assert.Nil(t, err)
 
 	group, err := store.GroupGet(fake.Group.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.Group, group)
+	assert.Equal(t, withGroupVersion(fake.Group, version), group)
 
 	err = store.GroupDelete(fake.Group.Id)
 	assert.Nil(t, err)
@@ -53,10 +54,10 @@ func TestGroupGet(t *testing.T) {
 	// - Groups written to the store can be retrieved
 	group, err := store.GroupGet(fake.Group.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.Group, group)
+	assert.Equal(t, withGroupVersion(fake.Group, 1), group)
 	group, err = store.GroupGet(fake.GroupNoMetadata.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.GroupNoMetadata, group)
+	assert.Equal(t, withGroupVersion(fake.GroupNoMetadata, 1), group)
 }
 
 func TestGroupGet_NoGroup(t *testing.T) {
@@ -85,12 +86,144 @@ func TestGroupList(t *testing.T) {
 	groups, err := store.GroupList()
 	assert.Nil(t, err)
 	if assert.Equal(t, 2, len(groups)) {
-		assert.Contains(t, groups, fake.Group)
-		assert.Contains(t, groups, fake.GroupNoMetadata)
+		assert.Contains(t, groups, withGroupVersion(fake.Group, 1))
+		assert.Contains(t, groups, withGroupVersion(fake.GroupNoMetadata, 1))
 		assert.NotContains(t, groups, &storagepb.Group{})
 	}
 }
 
+func TestGroupVersion(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(&Config{Root: dir}).(*fileStore)
+	// assert that:
+	// - the version is stable while group files are unchanged
+	// - the version changes when group files are added or removed
+	v1, err := store.GroupVersion()
+	assert.Nil(t, err)
+	v2, err := store.GroupVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, v1, v2)
+
+	_, err = store.GroupPut(fake.GroupNoMetadata)
+	assert.Nil(t, err)
+	v3, err := store.GroupVersion()
+	assert.Nil(t, err)
+	assert.NotEqual(t, v1, v3)
+
+	err = store.GroupDelete(fake.GroupNoMetadata.Id)
+	assert.Nil(t, err)
+	v4, err := store.GroupVersion()
+	assert.Nil(t, err)
+	assert.NotEqual(t, v3, v4)
+}
+
+func TestGroupVersion_Symlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// groups laid out like a mounted Kubernetes ConfigMap
+	groups := filepath.Join(dir, "groups")
+	data, err := marshalGroup(fake.Group)
+	assert.Nil(t, err)
+	modTime := time.Unix(1500000000, 0)
+	for _, version := range []string{"..2018_04_01", "..2018_04_02"} {
+		file := filepath.Join(groups, version, fake.Group.Id+".json")
+		assert.Nil(t, os.MkdirAll(filepath.Dir(file), 0755))
+		assert.Nil(t, ioutil.WriteFile(file, data, 0644))
+		assert.Nil(t, os.Chtimes(file, modTime, modTime))
+	}
+	assert.Nil(t, os.Symlink("..2018_04_01", filepath.Join(groups, "..data")))
+	assert.Nil(t, os.Symlink(filepath.Join("..data", fake.Group.Id+".json"), filepath.Join(groups, fake.Group.Id+".json")))
+
+	store := NewFileStore(&Config{Root: dir}).(*fileStore)
+	v1, err := store.GroupVersion()
+	assert.Nil(t, err)
+
+	// assert that the version changes when the ConfigMap is updated, although
+	// the group file symlink and the target's size and time are unchanged
+	assert.Nil(t, os.Symlink("..2018_04_02", filepath.Join(groups, "..data_tmp")))
+	assert.Nil(t, os.Rename(filepath.Join(groups, "..data_tmp"), filepath.Join(groups, "..data")))
+	assert.Nil(t, os.Chtimes(groups, modTime, modTime))
+	v2, err := store.GroupVersion()
+	assert.Nil(t, err)
+	assert.NotEqual(t, v1, v2)
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Selector, group.Selector)
+}
+
+func TestFileConditionalPut(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	testConditionalPut(t, NewFileStore(&Config{Root: dir}))
+}
+
+func TestFileTemplates(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	testTemplates(t, NewFileStore(&Config{Root: dir}))
+}
+
+func TestFileTemplates_NoDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// assert that a missing template directory has no templates
+	names, err := NewFileStore(&Config{Root: dir}).CloudList()
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+}
+
+func TestFileTemplates_Symlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// ignition -> configs, laid out like a mounted Kubernetes ConfigMap
+	configs := filepath.Join(dir, "configs")
+	assert.Nil(t, os.MkdirAll(filepath.Join(configs, "..2018_04_01", "roles"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(configs, "..2018_04_01", "base.yaml"), []byte("base"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(configs, "..2018_04_01", "roles", "worker.yaml"), []byte("worker"), 0644))
+	assert.Nil(t, os.Symlink("..2018_04_01", filepath.Join(configs, "..data")))
+	assert.Nil(t, os.Symlink(filepath.Join("..data", "base.yaml"), filepath.Join(configs, "base.yaml")))
+	assert.Nil(t, os.Symlink(filepath.Join("..data", "roles"), filepath.Join(configs, "roles")))
+	assert.Nil(t, os.Symlink("missing.yaml", filepath.Join(configs, "dangling.yaml")))
+	assert.Nil(t, os.Symlink(configs, filepath.Join(dir, "ignition")))
+
+	store := NewFileStore(&Config{Root: dir})
+	// assert that:
+	// - symlinked directories and files are followed
+	// - hidden entries and dangling symlinks are skipped
+	names, err := store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"base.yaml", "roles/worker.yaml"}, names)
+	for _, name := range names {
+		_, err := store.IgnitionGet(name)
+		assert.Nil(t, err)
+	}
+}
+
+func TestFileVersion_Unversioned(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(&Config{Root: dir})
+	// assert that files written by hand have version 1 and can be updated
+	// conditionally
+	version, err := store.GroupPut(withGroupVersion(fake.Group, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), version)
+}
+
 func TestProfileCRUD(t *testing.T) {
 	dir, err := setup(&fake.FixedStore{})
 	assert.Nil(t, err)
@@ -101,12 +234,12 @@ func TestProfileCRUD(t *testing.T) {
 	// - Profile creation was successful
 	// - Profile can be retrieved by id
 	// - Profile can be deleted by id
-	err = store.ProfilePut(fake.Profile)
+	version, err := store.ProfilePut(fake.Profile)
 	assert.Nil(t, err)
 
 	profile, err := store.ProfileGet(fake.Profile.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.Profile, profile)
+	assert.Equal(t, withProfileVersion(fake.Profile, version), profile)
 
 	err = store.ProfileDelete(fake.Profile.Id)
 	assert.Nil(t, err)
@@ -125,7 +258,7 @@ func TestProfileGet(t *testing.T) {
 
 	store := NewFileStore(&Config{Root: dir})
 	profile, err := store.ProfileGet(fake.Profile.Id)
-	assert.Equal(t, fake.Profile, profile)
+	assert.Equal(t, withProfileVersion(fake.Profile, 1), profile)
 	assert.Nil(t, err)
 	_, err = store.ProfileGet("no-such-profile")
 	if assert.Error(t, err) {
@@ -144,8 +277,67 @@ func TestProfileList(t *testing.T) {
 	profiles, err := store.ProfileList()
 	assert.Nil(t, err)
 	if assert.Equal(t, 1, len(profiles)) {
-		assert.Equal(t, fake.Profile, profiles[0])
+		assert.Equal(t, withProfileVersion(fake.Profile, 1), profiles[0])
+	}
+}
+
+func TestFileYAML(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	group := "id: node1\nprofile: p\nselector:\n  mac: 52:da:00:89:d8:10\nmetadata:\n  ssh_authorized_keys:\n  - ssh-rsa AAAA\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "groups", "node1.yaml"), []byte(group), defaultFileMode)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "profiles", "p.yml"), []byte("id: p\nignition_id: p.yaml\n"), defaultFileMode)
+	assert.Nil(t, err)
+
+	store := NewFileStore(&Config{Root: dir})
+	// assert that YAML groups and profiles are read
+	expected := &storagepb.Group{
+		Id:              "node1",
+		Profile:         "p",
+		Selector:        map[string]string{"mac": "52:da:00:89:d8:10"},
+		Metadata:        []byte(`{"ssh_authorized_keys":["ssh-rsa AAAA"]}`),
+		ResourceVersion: 1,
+	}
+	got, err := store.GroupGet("node1")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, got)
+	profiles, err := store.ProfileList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(profiles)) {
+		assert.Equal(t, "p.yaml", profiles[0].IgnitionId)
 	}
+
+	// assert that updates are written back as YAML
+	got.Profile = "q"
+	_, err = store.GroupPut(got)
+	assert.Nil(t, err)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "groups", "node1.yaml"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "profile: q\n")
+	_, err = os.Stat(filepath.Join(dir, "groups", "node1.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	// assert that an id with several files is reported and the JSON file read
+	err = ioutil.WriteFile(filepath.Join(dir, "groups", "node1.json"), []byte(`{"id": "node1", "profile": "json"}`), defaultFileMode)
+	assert.Nil(t, err)
+	problems, err := store.(Checker).Check()
+	assert.Nil(t, err)
+	expectedProblems := []*storagepb.Problem{
+		{Kind: "groups", Name: "node1", Message: "several files node1.json, node1.yaml, only node1.json is read"},
+	}
+	assert.Equal(t, expectedProblems, problems)
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(groups)) {
+		assert.Equal(t, "json", groups[0].Profile)
+	}
+
+	// assert that YAML files can be deleted
+	assert.Nil(t, store.ProfileDelete("p"))
+	_, err = os.Stat(filepath.Join(dir, "profiles", "p.yml"))
+	assert.True(t, os.IsNotExist(err))
 }
 
 func TestIgnitionCRUD(t *testing.T) {
@@ -158,7 +350,7 @@ func TestIgnitionCRUD(t *testing.T) {
 	// - Ignition template creation was successful
 	// - Ignition template can be retrieved by name
 	// - Ignition template can be deleted by name
-	err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML))
+	_, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), 0)
 	assert.Nil(t, err)
 
 	template, err := store.IgnitionGet(fake.IgnitionYAMLName)
@@ -197,7 +389,7 @@ func TestGenericCRUD(t *testing.T) {
 	// - Generic template creation was successful
 	// - Generic template can be retrieved by name
 	// - Generic template can be deleted by name
-	err = store.GenericPut(fake.GenericName, []byte(fake.Generic))
+	_, err = store.GenericPut(fake.GenericName, []byte(fake.Generic), 0)
 	assert.Nil(t, err)
 
 	template, err := store.GenericGet(fake.GenericName)
@@ -254,7 +446,8 @@ func setup(fixedStore *fake.FixedStore) (root string, err error) {
 	ignitionDir := filepath.Join(root, "ignition")
 	genericDir := filepath.Join(root, "generic")
 	cloudDir := filepath.Join(root, "cloud")
-	if err := mkdirs(profileDir, groupDir, ignitionDir, genericDir, cloudDir); err != nil {
+	partialsDir := filepath.Join(root, "partials")
+	if err := mkdirs(profileDir, groupDir, ignitionDir, genericDir, cloudDir, partialsDir); err != nil {
 		return root, err
 	}
 	// files
@@ -305,6 +498,13 @@ func setup(fixedStore *fake.FixedStore) (root string, err error) {
 			return root, err
 		}
 	}
+	for name, content := range fixedStore.Partials {
+		partialFile := filepath.Join(partialsDir, name)
+		err = ioutil.WriteFile(partialFile, []byte(content), defaultFileMode)
+		if err != nil {
+			return root, err
+		}
+	}
 	return root, nil
 }
 
@@ -318,3 +518,127 @@ func mkdirs(names ...string) error {
 	}
 	return nil
 }
+
+// testConditionalPut asserts that a Store versions resources and rejects
+// puts whose resource version is not the stored one.
+func testConditionalPut(t *testing.T, store Store) {
+	// Groups
+	v1, err := store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, v1, group.ResourceVersion)
+	v2, err := store.GroupPut(group)
+	assert.Nil(t, err)
+	assert.True(t, v2 > v1)
+	_, err = store.GroupPut(group)
+	assert.Equal(t, ErrVersionConflict, err)
+	group, err = store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, v2, group.ResourceVersion)
+	// re-created resources never reuse a version
+	assert.Nil(t, store.GroupDelete(fake.Group.Id))
+	_, err = store.GroupPut(group)
+	assert.Equal(t, ErrVersionConflict, err)
+	v3, err := store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	assert.True(t, v3 > v2)
+
+	// Profiles
+	_, err = store.ProfilePut(withProfileVersion(fake.Profile, 1))
+	assert.Equal(t, ErrVersionConflict, err)
+	v1, err = store.ProfilePut(fake.Profile)
+	assert.Nil(t, err)
+	_, err = store.ProfilePut(withProfileVersion(fake.Profile, v1+1))
+	assert.Equal(t, ErrVersionConflict, err)
+	v2, err = store.ProfilePut(withProfileVersion(fake.Profile, v1))
+	assert.Nil(t, err)
+	profile, err := store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withProfileVersion(fake.Profile, v2), profile)
+
+	// templates
+	v1, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), 0)
+	assert.Nil(t, err)
+	version, err := store.IgnitionVersion(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, v1, version)
+	v2, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte("{}"), v1)
+	assert.Nil(t, err)
+	_, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), v1)
+	assert.Equal(t, ErrVersionConflict, err)
+	template, err := store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", template)
+	version, err = store.IgnitionVersion(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, v2, version)
+
+	v1, err = store.GenericPut(fake.GenericName, []byte(fake.Generic), 0)
+	assert.Nil(t, err)
+	_, err = store.GenericPut(fake.GenericName, []byte(fake.Generic), v1+1)
+	assert.Equal(t, ErrVersionConflict, err)
+	version, err = store.GenericVersion(fake.GenericName)
+	assert.Nil(t, err)
+	assert.Equal(t, v1, version)
+	_, err = store.GenericVersion("no-such-template")
+	assert.True(t, os.IsNotExist(err))
+}
+
+// testTemplates asserts that a Store creates, lists, and deletes Cloud-Config
+// and partial templates and lists templates of each kind, including nested
+// ones.
+func testTemplates(t *testing.T, store Store) {
+	v1, err := store.CloudPut("cloud.yaml", []byte("#cloud-config"), 0)
+	assert.Nil(t, err)
+	template, err := store.CloudGet("cloud.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "#cloud-config", template)
+	version, err := store.CloudVersion("cloud.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, v1, version)
+	_, err = store.CloudPut("cloud.yaml", []byte("#cloud-config"), v1+1)
+	assert.Equal(t, ErrVersionConflict, err)
+
+	_, err = store.CloudPut("nested/b.yaml", []byte("b"), 0)
+	assert.Nil(t, err)
+	_, err = store.IgnitionPut("a.yaml", []byte("a"), 0)
+	assert.Nil(t, err)
+	_, err = store.GenericPut("c.tmpl", []byte("c"), 0)
+	assert.Nil(t, err)
+	names, err := store.CloudList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cloud.yaml", "nested/b.yaml"}, names)
+	names, err = store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a.yaml"}, names)
+	names, err = store.GenericList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"c.tmpl"}, names)
+
+	assert.Nil(t, store.CloudDelete("cloud.yaml"))
+	_, err = store.CloudGet("cloud.yaml")
+	assert.True(t, os.IsNotExist(err))
+	assert.True(t, os.IsNotExist(store.CloudDelete("cloud.yaml")))
+	names, err = store.CloudList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nested/b.yaml"}, names)
+
+	v1, err = store.PartialPut("users/ssh.yaml", []byte("d"), 0)
+	assert.Nil(t, err)
+	template, err = store.PartialGet("users/ssh.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "d", template)
+	version, err = store.PartialVersion("users/ssh.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, v1, version)
+	names, err = store.PartialList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"users/ssh.yaml"}, names)
+	assert.Nil(t, store.PartialDelete("users/ssh.yaml"))
+	_, err = store.PartialGet("users/ssh.yaml")
+	assert.True(t, os.IsNotExist(err))
+	names, err = store.PartialList()
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+}
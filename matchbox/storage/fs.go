@@ -55,6 +55,16 @@ func (d Dir) writeFile(path string, data []byte) error {
 	return ioutil.WriteFile(path, data, defaultFileMode)
 }
 
+// stat returns the FileInfo of the file at the given path, restricted to a
+// specific directory tree.
+func (d Dir) stat(path string) (os.FileInfo, error) {
+	path, err := d.sanitize(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.Stat(path)
+}
+
 // deleteFile removes the file at the given path, restricted to a specific
 // directory tree.
 func (d Dir) deleteFile(path string) error {
@@ -0,0 +1,698 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// gitCommitter is the committer of the commits made by a gitStore. Commits
+// are authored by the author of each write.
+const gitCommitter = "matchbox"
+
+// GitConfig initializes a gitStore.
+type GitConfig struct {
+	// Root is a directory of a git working tree laid out like a fileStore's
+	// data directory. It may be below the top of the working tree.
+	Root string
+	// Ref optionally pins reads of Groups, Profiles, and templates to the
+	// tree of a git ref (e.g. a tag or a remote branch). The Store is then
+	// read-only, since writes to the working tree would not be read back.
+	Ref    string
+	Logger *logrus.Logger
+}
+
+// gitStore is a fileStore over a git working tree which commits every write
+// it records in its history, authored by the Revision's author. Resource
+// versions, history, and whiteouts are kept in untracked directories, as for
+// the fileStore, and are excluded from git. A gitStore with a pinned ref is
+// read-only.
+type gitStore struct {
+	*fileStore
+	ref string
+	// directory in which git is run, the root of the default namespace
+	dir string
+	// path of the root relative to dir, empty for the default namespace
+	prefix string
+	// serializes use of the git index
+	mu *sync.Mutex
+}
+
+// NewGitStore returns a new Store backed by a git working tree.
+func NewGitStore(config *GitConfig) (Store, error) {
+	s := &gitStore{
+		fileStore: &fileStore{
+			root:   config.Root,
+			logger: config.Logger,
+		},
+		ref: config.Ref,
+		dir: config.Root,
+		mu:  new(sync.Mutex),
+	}
+	if out, err := s.git(nil, "rev-parse", "--is-inside-work-tree"); err != nil || strings.TrimSpace(string(out)) != "true" {
+		return nil, fmt.Errorf("storage: %s is not in a git working tree: %v", config.Root, err)
+	}
+	if s.ref != "" {
+		if _, err := s.git(nil, "rev-parse", "--verify", "--quiet", s.ref+"^{commit}"); err != nil {
+			return nil, fmt.Errorf("storage: invalid git ref %q", s.ref)
+		}
+	}
+	if err := s.exclude(versionsDir+"/", historyDir+"/", whiteoutsDir+"/"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Namespace returns a gitStore of the resources of a namespace, kept in a
+// directory tree of the same layout below the namespaces directory. Writes
+// are committed to the same repository.
+func (s *gitStore) Namespace(name string) (Store, error) {
+	store, err := s.fileStore.Namespace(name)
+	if err != nil {
+		return nil, err
+	}
+	return &gitStore{
+		fileStore: store.(*fileStore),
+		ref:       s.ref,
+		dir:       s.dir,
+		prefix:    filepath.Join(namespacesDir, name),
+		mu:        s.mu,
+	}, nil
+}
+
+// HasNamespace returns true if the directory tree of a namespace exists, in
+// the tree of the pinned ref if any.
+func (s *gitStore) HasNamespace(name string) (bool, error) {
+	if s.ref == "" {
+		return s.fileStore.HasNamespace(name)
+	}
+	out, err := s.git(nil, "ls-tree", s.ref, s.path(filepath.Join(namespacesDir, name)))
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(string(out), " tree "), nil
+}
+
+// GroupGet returns a machine Group by id.
+func (s *gitStore) GroupGet(id string) (*storagepb.Group, error) {
+	if s.ref == "" {
+		return s.fileStore.GroupGet(id)
+	}
+	file, data, err := s.showResource("groups", id)
+	if err != nil {
+		return nil, err
+	}
+	group, err := storagepb.ParseGroupFile(file, data)
+	if err != nil {
+		return nil, err
+	}
+	group.ResourceVersion, _, _ = s.version(file)
+	return group, nil
+}
+
+// GroupList lists all machine Groups.
+func (s *gitStore) GroupList() ([]*storagepb.Group, error) {
+	if s.ref == "" {
+		return s.fileStore.GroupList()
+	}
+	names, err := s.list("groups")
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*storagepb.Group, 0, len(names))
+	for _, name := range names {
+		group, err := s.GroupGet(name)
+		if err == nil {
+			groups = append(groups, group)
+		} else if s.logger != nil {
+			s.logger.Warningf("Group %q: %v", name, err)
+		}
+	}
+	return groups, nil
+}
+
+// GroupVersion returns the id of the groups tree of the pinned ref, which
+// changes when the ref is moved to a commit with different Groups.
+func (s *gitStore) GroupVersion() (string, error) {
+	if s.ref == "" {
+		return s.fileStore.GroupVersion()
+	}
+	out, err := s.git(nil, "ls-tree", s.ref, s.path("groups"))
+	return string(out), err
+}
+
+// ProfileGet gets a profile by id.
+func (s *gitStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	if s.ref == "" {
+		return s.fileStore.ProfileGet(id)
+	}
+	file, data, err := s.showResource("profiles", id)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := storagepb.ParseProfileFile(file, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.AssertValid(); err != nil {
+		return nil, err
+	}
+	profile.ResourceVersion, _, _ = s.version(file)
+	return profile, nil
+}
+
+// ProfileList lists all profiles.
+func (s *gitStore) ProfileList() ([]*storagepb.Profile, error) {
+	if s.ref == "" {
+		return s.fileStore.ProfileList()
+	}
+	names, err := s.list("profiles")
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]*storagepb.Profile, 0, len(names))
+	for _, name := range names {
+		profile, err := s.ProfileGet(name)
+		if err == nil {
+			profiles = append(profiles, profile)
+		} else if s.logger != nil {
+			s.logger.Warningf("Profile %q: %v", name, err)
+		}
+	}
+	return profiles, nil
+}
+
+// Check returns a Problem for each group or profile file which cannot be
+// parsed, in the tree of the pinned ref if any.
+func (s *gitStore) Check() ([]*storagepb.Problem, error) {
+	if s.ref == "" {
+		return s.fileStore.Check()
+	}
+	var problems []*storagepb.Problem
+	for _, kind := range checkKinds {
+		names, err := s.list(kind)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, checkNames(kind, names, getter(s, kind))...)
+	}
+	return problems, nil
+}
+
+// IgnitionGet gets an Ignition template by name.
+func (s *gitStore) IgnitionGet(name string) (string, error) {
+	if s.ref == "" {
+		return s.fileStore.IgnitionGet(name)
+	}
+	data, err := s.show(filepath.Join("ignition", name))
+	return string(data), err
+}
+
+// IgnitionVersion gets the resource version of an Ignition template.
+func (s *gitStore) IgnitionVersion(name string) (int64, error) {
+	if s.ref == "" {
+		return s.fileStore.IgnitionVersion(name)
+	}
+	return s.refVersion(filepath.Join("ignition", name))
+}
+
+// IgnitionList lists the names of all Ignition templates.
+func (s *gitStore) IgnitionList() ([]string, error) {
+	if s.ref == "" {
+		return s.fileStore.IgnitionList()
+	}
+	return s.refTemplateList("ignition")
+}
+
+// GenericGet gets an Generic template by name.
+func (s *gitStore) GenericGet(name string) (string, error) {
+	if s.ref == "" {
+		return s.fileStore.GenericGet(name)
+	}
+	data, err := s.show(filepath.Join("generic", name))
+	return string(data), err
+}
+
+// GenericVersion gets the resource version of a Generic template.
+func (s *gitStore) GenericVersion(name string) (int64, error) {
+	if s.ref == "" {
+		return s.fileStore.GenericVersion(name)
+	}
+	return s.refVersion(filepath.Join("generic", name))
+}
+
+// GenericList lists the names of all Generic templates.
+func (s *gitStore) GenericList() ([]string, error) {
+	if s.ref == "" {
+		return s.fileStore.GenericList()
+	}
+	return s.refTemplateList("generic")
+}
+
+// CloudGet gets a Cloud-Config template by name.
+func (s *gitStore) CloudGet(name string) (string, error) {
+	if s.ref == "" {
+		return s.fileStore.CloudGet(name)
+	}
+	data, err := s.show(filepath.Join("cloud", name))
+	return string(data), err
+}
+
+// CloudVersion gets the resource version of a Cloud-Config template.
+func (s *gitStore) CloudVersion(name string) (int64, error) {
+	if s.ref == "" {
+		return s.fileStore.CloudVersion(name)
+	}
+	return s.refVersion(filepath.Join("cloud", name))
+}
+
+// CloudList lists the names of all Cloud-Config templates.
+func (s *gitStore) CloudList() ([]string, error) {
+	if s.ref == "" {
+		return s.fileStore.CloudList()
+	}
+	return s.refTemplateList("cloud")
+}
+
+// PartialGet gets a partial template by name.
+func (s *gitStore) PartialGet(name string) (string, error) {
+	if s.ref == "" {
+		return s.fileStore.PartialGet(name)
+	}
+	data, err := s.show(filepath.Join("partials", name))
+	return string(data), err
+}
+
+// PartialVersion gets the resource version of a partial template.
+func (s *gitStore) PartialVersion(name string) (int64, error) {
+	if s.ref == "" {
+		return s.fileStore.PartialVersion(name)
+	}
+	return s.refVersion(filepath.Join("partials", name))
+}
+
+// PartialList lists the names of all partial templates.
+func (s *gitStore) PartialList() ([]string, error) {
+	if s.ref == "" {
+		return s.fileStore.PartialList()
+	}
+	return s.refTemplateList("partials")
+}
+
+// GroupPut writes a machine Group, unless a ref is pinned.
+func (s *gitStore) GroupPut(group *storagepb.Group) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.GroupPut(group)
+}
+
+// GroupDelete deletes a machine Group, unless a ref is pinned.
+func (s *gitStore) GroupDelete(id string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.GroupDelete(id)
+}
+
+// ProfilePut writes a Profile, unless a ref is pinned.
+func (s *gitStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.ProfilePut(profile)
+}
+
+// ProfileDelete deletes a Profile, unless a ref is pinned.
+func (s *gitStore) ProfileDelete(id string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.ProfileDelete(id)
+}
+
+// IgnitionPut writes an Ignition template, unless a ref is pinned.
+func (s *gitStore) IgnitionPut(name string, config []byte, version int64) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.IgnitionPut(name, config, version)
+}
+
+// IgnitionDelete deletes an Ignition template, unless a ref is pinned.
+func (s *gitStore) IgnitionDelete(name string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.IgnitionDelete(name)
+}
+
+// GenericPut writes a Generic template, unless a ref is pinned.
+func (s *gitStore) GenericPut(name string, config []byte, version int64) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.GenericPut(name, config, version)
+}
+
+// GenericDelete deletes a Generic template, unless a ref is pinned.
+func (s *gitStore) GenericDelete(name string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.GenericDelete(name)
+}
+
+// CloudPut writes a Cloud-Config template, unless a ref is pinned.
+func (s *gitStore) CloudPut(name string, config []byte, version int64) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.CloudPut(name, config, version)
+}
+
+// CloudDelete deletes a Cloud-Config template, unless a ref is pinned.
+func (s *gitStore) CloudDelete(name string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.CloudDelete(name)
+}
+
+// PartialPut writes a partial template, unless a ref is pinned.
+func (s *gitStore) PartialPut(name string, config []byte, version int64) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.PartialPut(name, config, version)
+}
+
+// PartialDelete deletes a partial template, unless a ref is pinned.
+func (s *gitStore) PartialDelete(name string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.PartialDelete(name)
+}
+
+// groupPut writes a machine Group with a version greater than a minimum
+// version, unless a ref is pinned.
+func (s *gitStore) groupPut(group *storagepb.Group, min int64) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.groupPut(group, min)
+}
+
+// profilePut writes a Profile with a version greater than a minimum version,
+// unless a ref is pinned.
+func (s *gitStore) profilePut(profile *storagepb.Profile, min int64) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.profilePut(profile, min)
+}
+
+// templatePut writes a template with a version greater than a minimum
+// version, unless a ref is pinned.
+func (s *gitStore) templatePut(kind, name string, config []byte, expected, min int64) (int64, error) {
+	if s.ref != "" {
+		return 0, ErrReadOnly
+	}
+	return s.fileStore.templatePut(kind, name, config, expected, min)
+}
+
+// WhiteoutPut records a whiteout of a resource, unless a ref is pinned.
+func (s *gitStore) WhiteoutPut(kind, name string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.WhiteoutPut(kind, name)
+}
+
+// WhiteoutDelete removes the whiteout of a resource, unless a ref is pinned.
+func (s *gitStore) WhiteoutDelete(kind, name string) error {
+	if s.ref != "" {
+		return ErrReadOnly
+	}
+	return s.fileStore.WhiteoutDelete(kind, name)
+}
+
+// HistoryAppend commits the write of the resource described by the Revision,
+// authored by the Revision's author, and records the Revision. Writes which
+// left the working tree unchanged are not committed.
+func (s *gitStore) HistoryAppend(revision *storagepb.Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files, err := s.revisionFiles(revision.Kind, revision.Name)
+	if err != nil {
+		return err
+	}
+	if len(files) != 0 {
+		args := append([]string{"status", "--porcelain", "--"}, files...)
+		status, err := s.git(nil, args...)
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(status)) != 0 {
+			if err := s.commit(revision, files); err != nil {
+				return err
+			}
+		}
+	}
+	return s.fileStore.HistoryAppend(revision)
+}
+
+// revisionFiles returns the files of a resource which exist in the working
+// tree or are tracked, so a write which changed the format of a group or
+// profile file (or deleted it) is committed completely.
+func (s *gitStore) revisionFiles(kind, name string) ([]string, error) {
+	var candidates []string
+	if kind == "groups" || kind == "profiles" {
+		for _, ext := range resourceExts {
+			candidates = append(candidates, filepath.Join(kind, name+ext))
+		}
+	} else {
+		candidates = []string{filepath.Join(kind, name)}
+	}
+	var files []string
+	for _, file := range candidates {
+		if _, err := Dir(s.root).stat(file); err == nil {
+			files = append(files, s.path(file))
+			continue
+		}
+		tracked, err := s.git(nil, "ls-files", "--", s.path(file))
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(tracked)) != 0 {
+			files = append(files, s.path(file))
+		}
+	}
+	return files, nil
+}
+
+// commit commits the current state of files in the working tree.
+func (s *gitStore) commit(revision *storagepb.Revision, files []string) error {
+	author := revision.Author
+	if author == "" {
+		author = gitCommitter
+	}
+	env := []string{
+		"GIT_AUTHOR_NAME=" + author,
+		"GIT_AUTHOR_EMAIL=" + gitEmail(author),
+		"GIT_COMMITTER_NAME=" + gitCommitter,
+		"GIT_COMMITTER_EMAIL=" + gitEmail(gitCommitter),
+	}
+	if revision.Time != 0 {
+		env = append(env, fmt.Sprintf("GIT_AUTHOR_DATE=@%d +0000", revision.Time))
+	}
+	action := "Update"
+	if revision.Deleted {
+		action = "Delete"
+	}
+	resource := path.Join(revision.Kind, revision.Name)
+	if s.prefix != "" {
+		resource = path.Join(filepath.ToSlash(s.prefix), resource)
+	}
+	message := fmt.Sprintf("%s %s\n\nResource-Version: %d\n", action, resource, revision.ResourceVersion)
+	if _, err := s.git(env, append([]string{"add", "--all", "--"}, files...)...); err != nil {
+		return err
+	}
+	_, err := s.git(env, append([]string{"commit", "--quiet", "--message", message, "--"}, files...)...)
+	return err
+}
+
+// show reads a file from the tree of the pinned ref. The error satisfies
+// os.IsNotExist if the tree has no such file.
+func (s *gitStore) show(file string) ([]byte, error) {
+	file = s.path(file)
+	data, err := s.git(nil, "cat-file", "blob", s.ref+":"+file)
+	if err != nil {
+		return nil, notExist("read", file)
+	}
+	return data, nil
+}
+
+// showResource reads the file of a Group or Profile from the tree of the
+// pinned ref, the first of its id with a resource extension, in order of
+// precedence. The error satisfies os.IsNotExist if the tree has no such file.
+func (s *gitStore) showResource(kind, id string) (string, []byte, error) {
+	var err error
+	for _, ext := range resourceExts {
+		file := filepath.Join(kind, id+ext)
+		var data []byte
+		if data, err = s.show(file); err == nil {
+			return file, data, nil
+		}
+	}
+	return "", nil, err
+}
+
+// list returns the sorted ids of the group or profile files of a directory
+// in the tree of the pinned ref. Subdirectories and files without a resource
+// extension are ignored.
+func (s *gitStore) list(dir string) ([]string, error) {
+	out, err := s.git(nil, "ls-tree", s.ref, s.path(dir)+"/")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// <mode> SP <type> SP <object> TAB <file>
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) != 2 || !strings.Contains(fields[0], " blob ") {
+			continue
+		}
+		base := path.Base(fields[1])
+		ext := path.Ext(base)
+		name := strings.TrimSuffix(base, ext)
+		if containsString(resourceExts, ext) && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// refTemplateList returns the slash separated paths of the template files
+// below a kind's directory in the tree of the pinned ref.
+func (s *gitStore) refTemplateList(kind string) ([]string, error) {
+	dir := s.path(kind) + "/"
+	out, err := s.git(nil, "ls-tree", "-r", "--name-only", s.ref, dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			names = append(names, strings.TrimPrefix(line, strings.TrimPrefix(dir, "./")))
+		}
+	}
+	return names, nil
+}
+
+// refVersion returns the resource version of a file in the tree of the
+// pinned ref, which is the version of the working tree file or 0 if it was
+// removed from the working tree. The error satisfies os.IsNotExist if the
+// tree has no such file.
+func (s *gitStore) refVersion(file string) (int64, error) {
+	if _, err := s.git(nil, "cat-file", "-e", s.ref+":"+s.path(file)); err != nil {
+		return 0, notExist("stat", s.path(file))
+	}
+	version, _, _ := s.version(file)
+	return version, nil
+}
+
+// exclude adds patterns to the repository's exclude file, unless present.
+func (s *gitStore) exclude(patterns ...string) error {
+	out, err := s.git(nil, "rev-parse", "--git-path", "info/exclude")
+	if err != nil {
+		return err
+	}
+	file := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(s.dir, file)
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := strings.Split(string(data), "\n")
+	var missing []string
+	for _, pattern := range patterns {
+		if !containsString(lines, pattern) {
+			missing = append(missing, pattern)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, strings.Join(missing, "\n")+"\n"...)
+	if err := os.MkdirAll(filepath.Dir(file), defaultDirectoryMode); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, defaultFileMode)
+}
+
+// path returns the path of a file below the root, relative to the directory
+// in which git is run.
+func (s *gitStore) path(file string) string {
+	return gitPath(filepath.Join(s.prefix, file))
+}
+
+// git runs a git command in the git directory with the given additional
+// environment and returns its standard output.
+func (s *gitStore) git(env []string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.dir
+	cmd.Env = append(os.Environ(), env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// gitEmail returns the email address of a commit author or committer: the
+// author itself if it is an email address, such as a client certificate's
+// subject, and otherwise a placeholder address of the author's name.
+func gitEmail(author string) string {
+	if strings.Contains(author, "@") {
+		return author
+	}
+	return strings.Replace(author, " ", "-", -1) + "@matchbox.invalid"
+}
+
+// gitPath returns a cleaned, slash separated path relative to the current
+// directory, so git cannot be directed outside of the root.
+func gitPath(file string) string {
+	return "./" + strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(file)), "/")
+}
+
+// containsString returns whether a list of strings contains a string.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
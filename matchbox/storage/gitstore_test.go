@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestGitCommits(t *testing.T) {
+	store, dir := setupGit(t, &fake.FixedStore{})
+	defer os.RemoveAll(dir)
+	historian := store.(Historian)
+
+	// assert that recorded writes are committed with the Revision's author
+	version, err := store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	err = historian.HistoryAppend(&storagepb.Revision{Kind: "groups", Name: fake.Group.Id, ResourceVersion: version, Time: 100, Author: "alice"})
+	assert.Nil(t, err)
+	assert.Nil(t, store.GroupDelete(fake.Group.Id))
+	err = historian.HistoryAppend(&storagepb.Revision{Kind: "groups", Name: fake.Group.Id, Time: 200, Author: "bob", Deleted: true})
+	assert.Nil(t, err)
+	// a write which changed nothing is not committed
+	err = historian.HistoryAppend(&storagepb.Revision{Kind: "groups", Name: fake.Group.Id, Author: "carol", Deleted: true})
+	assert.Nil(t, err)
+
+	log, err := runGit(dir, "log", "--format=%an <%ae> %at %s")
+	assert.Nil(t, err)
+	expected := []string{
+		"bob <bob@matchbox.invalid> 200 Delete groups/" + fake.Group.Id,
+		"alice <alice@matchbox.invalid> 100 Update groups/" + fake.Group.Id,
+		"test <test@example.com> 0 Initial commit",
+	}
+	assert.Equal(t, expected, strings.Split(strings.TrimSpace(log), "\n"))
+	message, err := runGit(dir, "log", "--skip=1", "-1", "--format=%b")
+	assert.Nil(t, err)
+	assert.Contains(t, message, "Resource-Version: 1")
+
+	committer, err := runGit(dir, "log", "-1", "--format=%cn <%ce>")
+	assert.Nil(t, err)
+	assert.Equal(t, "matchbox <matchbox@matchbox.invalid>", strings.TrimSpace(committer))
+
+	// assert that versions, history, and whiteouts are neither committed nor
+	// untracked
+	assert.Nil(t, store.(Whiteouter).WhiteoutPut("profiles", fake.Profile.Id))
+	status, err := runGit(dir, "status", "--porcelain")
+	assert.Nil(t, err)
+	assert.Empty(t, status)
+}
+
+func TestGitRef(t *testing.T) {
+	working, dir := setupGit(t, &fake.FixedStore{
+		Groups:          map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML},
+	})
+	defer os.RemoveAll(dir)
+	_, err := runGit(dir, "tag", "pinned")
+	assert.Nil(t, err)
+	store, err := NewGitStore(&GitConfig{Root: dir, Ref: "pinned"})
+	assert.Nil(t, err)
+
+	// assert that reads are served from the pinned ref, not the working tree
+	assert.Nil(t, working.GroupDelete(fake.Group.Id))
+	_, err = working.IgnitionPut(fake.IgnitionYAMLName, []byte("changed"), 0)
+	assert.Nil(t, err)
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Selector, group.Selector)
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+	profiles, err := store.ProfileList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(profiles)) {
+		assert.Equal(t, withProfileVersion(fake.Profile, 1), profiles[0])
+	}
+	template, err := store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+	version, err := store.IgnitionVersion(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.True(t, version > 1)
+	names, err := store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{fake.IgnitionYAMLName}, names)
+	_, err = store.GenericGet("missing")
+	assert.True(t, os.IsNotExist(err))
+	_, err = store.GenericVersion("missing")
+	assert.True(t, os.IsNotExist(err))
+
+	// assert that moving the ref changes the Group version
+	before, err := store.(GroupVersioner).GroupVersion()
+	assert.Nil(t, err)
+	err = working.(Historian).HistoryAppend(&storagepb.Revision{Kind: "groups", Name: fake.Group.Id, Deleted: true})
+	assert.Nil(t, err)
+	_, err = runGit(dir, "tag", "--force", "pinned")
+	assert.Nil(t, err)
+	after, err := store.(GroupVersioner).GroupVersion()
+	assert.Nil(t, err)
+	assert.NotEqual(t, before, after)
+	_, err = store.GroupGet(fake.Group.Id)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGitRef_ReadOnly(t *testing.T) {
+	_, dir := setupGit(t, &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML},
+	})
+	defer os.RemoveAll(dir)
+	root, err := NewGitStore(&GitConfig{Root: dir, Ref: "HEAD"})
+	assert.Nil(t, err)
+	namespace, err := root.(Namespacer).Namespace("dev")
+	assert.Nil(t, err)
+
+	// assert that writes are rejected, since reads would not see them
+	for _, store := range []Store{root, namespace} {
+		_, err = store.GroupPut(fake.Group)
+		assert.Equal(t, ErrReadOnly, err)
+		assert.Equal(t, ErrReadOnly, store.GroupDelete(fake.Group.Id))
+		_, err = store.ProfilePut(fake.Profile)
+		assert.Equal(t, ErrReadOnly, err)
+		assert.Equal(t, ErrReadOnly, store.ProfileDelete(fake.Profile.Id))
+		_, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte("changed"), 0)
+		assert.Equal(t, ErrReadOnly, err)
+		assert.Equal(t, ErrReadOnly, store.IgnitionDelete(fake.IgnitionYAMLName))
+		_, err = store.GenericPut("generic.tmpl", []byte("changed"), 0)
+		assert.Equal(t, ErrReadOnly, err)
+		assert.Equal(t, ErrReadOnly, store.GenericDelete("generic.tmpl"))
+		_, err = store.CloudPut("cloud.tmpl", []byte("changed"), 0)
+		assert.Equal(t, ErrReadOnly, err)
+		assert.Equal(t, ErrReadOnly, store.CloudDelete("cloud.tmpl"))
+		_, err = store.PartialPut("partial.tmpl", []byte("changed"), 0)
+		assert.Equal(t, ErrReadOnly, err)
+		assert.Equal(t, ErrReadOnly, store.PartialDelete("partial.tmpl"))
+		assert.Equal(t, ErrReadOnly, store.(Whiteouter).WhiteoutPut("profiles", fake.Profile.Id))
+		assert.Equal(t, ErrReadOnly, store.(Whiteouter).WhiteoutDelete("profiles", fake.Profile.Id))
+	}
+
+	// assert that the working tree is unchanged
+	status, err := runGit(dir, "status", "--porcelain")
+	assert.Nil(t, err)
+	assert.Empty(t, status)
+	template, err := root.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+}
+
+func TestGitYAML(t *testing.T) {
+	store, dir := setupGit(t, &fake.FixedStore{})
+	defer os.RemoveAll(dir)
+	err := ioutil.WriteFile(filepath.Join(dir, "groups", "node1.yaml"), []byte("id: node1\nprofile: p\n"), defaultFileMode)
+	assert.Nil(t, err)
+	_, err = runGit(dir, "add", "--all")
+	assert.Nil(t, err)
+	_, err = runGit(dir, "commit", "--quiet", "--message", "Add node1")
+	assert.Nil(t, err)
+
+	// assert that YAML groups are listed and their deletion committed
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(groups)) {
+		assert.Equal(t, "node1", groups[0].Id)
+	}
+	assert.Nil(t, store.GroupDelete("node1"))
+	err = store.(Historian).HistoryAppend(&storagepb.Revision{Kind: "groups", Name: "node1", Author: "alice", Deleted: true})
+	assert.Nil(t, err)
+	status, err := runGit(dir, "status", "--porcelain")
+	assert.Nil(t, err)
+	assert.Empty(t, status)
+	files, err := runGit(dir, "show", "--format=%an", "--name-status")
+	assert.Nil(t, err)
+	assert.Contains(t, files, "alice")
+	assert.Contains(t, files, "D\tgroups/node1.yaml")
+}
+
+func TestGitConditionalPut(t *testing.T) {
+	store, dir := setupGit(t, &fake.FixedStore{})
+	defer os.RemoveAll(dir)
+	testConditionalPut(t, store)
+}
+
+func TestGitTemplates(t *testing.T) {
+	store, dir := setupGit(t, &fake.FixedStore{})
+	defer os.RemoveAll(dir)
+	testTemplates(t, store)
+}
+
+func TestGitHistory(t *testing.T) {
+	store, dir := setupGit(t, &fake.FixedStore{})
+	defer os.RemoveAll(dir)
+	testHistory(t, store)
+}
+
+func TestGitNamespaces(t *testing.T) {
+	store, dir := setupGit(t, &fake.FixedStore{})
+	defer os.RemoveAll(dir)
+	testNamespaces(t, store)
+
+	// assert that namespaced writes are committed with their full path
+	teamA, err := store.(Namespacer).Namespace("team-a")
+	assert.Nil(t, err)
+	_, err = teamA.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	err = teamA.(Historian).HistoryAppend(&storagepb.Revision{Kind: "groups", Name: fake.Group.Id, Author: "alice"})
+	assert.Nil(t, err)
+	log, err := runGit(dir, "log", "-1", "--format=%an %s")
+	assert.Nil(t, err)
+	assert.Equal(t, "alice Update namespaces/team-a/groups/"+fake.Group.Id, strings.TrimSpace(log))
+}
+
+func TestNewGitStore_Invalid(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// assert that the root must be in a working tree and the ref must exist
+	_, err = NewGitStore(&GitConfig{Root: dir})
+	assert.Error(t, err)
+	_, err = runGit(dir, "init", "--quiet")
+	assert.Nil(t, err)
+	_, err = NewGitStore(&GitConfig{Root: dir, Ref: "missing"})
+	assert.Error(t, err)
+}
+
+func TestGitPath(t *testing.T) {
+	assert.Equal(t, "./groups/a.json", gitPath(filepath.Join("groups", "a.json")))
+	assert.Equal(t, "./profiles/p", gitPath("../profiles/p"))
+	assert.Equal(t, "./b", gitPath("/a/../b"))
+}
+
+// setupGit writes a -data-path tree mirroring a given fixedStore, commits it
+// to a new git repository, and returns a git Store for the working tree. The
+// test is skipped if git is not installed. The caller must remove the
+// returned directory when finished.
+func setupGit(t *testing.T, fixedStore *fake.FixedStore) (Store, string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir, err := setup(fixedStore)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"commit", "--quiet", "--allow-empty", "--message", "Initial commit", "--date", "@0 +0000"},
+	} {
+		if out, err := runGit(dir, args...); err != nil {
+			os.RemoveAll(dir)
+			t.Fatalf("git %s: %v: %s", args[0], err, out)
+		}
+	}
+	store, err := NewGitStore(&GitConfig{Root: dir})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return store, dir
+}
+
+// runGit runs a git command as the "test" user in a directory and returns
+// its output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
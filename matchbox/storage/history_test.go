@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+func TestFileHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	testHistory(t, NewFileStore(&Config{Root: dir}))
+}
+
+// testRecording asserts that a Recorder appends a Revision, with the new
+// resource version of puts, for each successful write of a Recording and none
+// for failed writes.
+func testRecording(t *testing.T, store Store) {
+	recorder, ok := store.(Recorder)
+	if !assert.True(t, ok, "store must implement Recorder") {
+		return
+	}
+	put := &storagepb.Revision{Kind: "ignition", Name: "a", Time: 100, Author: "alice", Content: []byte("one")}
+	version, err := recorder.Recording(put).IgnitionPut("a", []byte("one"), 0)
+	assert.Nil(t, err)
+	conflict := &storagepb.Revision{Kind: "ignition", Name: "a", Content: []byte("two")}
+	_, err = recorder.Recording(conflict).IgnitionPut("a", []byte("two"), version+100)
+	assert.Equal(t, ErrVersionConflict, err)
+	deleted := &storagepb.Revision{Kind: "ignition", Name: "a", Time: 200, Author: "bob", Deleted: true}
+	assert.Nil(t, recorder.Recording(deleted).IgnitionDelete("a"))
+	missing := &storagepb.Revision{Kind: "ignition", Name: "a", Deleted: true}
+	err = recorder.Recording(missing).IgnitionDelete("a")
+	assert.True(t, os.IsNotExist(err))
+
+	history, err := recorder.History("ignition", "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Revision{
+		{Kind: "ignition", Name: "a", ResourceVersion: version, Time: 100, Author: "alice", Content: []byte("one")},
+		{Kind: "ignition", Name: "a", Time: 200, Author: "bob", Deleted: true},
+	}, history)
+}
+
+// testHistory asserts that a Historian returns the Revisions recorded for a
+// resource in order, and only those.
+func testHistory(t *testing.T, store Store) {
+	historian, ok := store.(Historian)
+	if !assert.True(t, ok, "store must implement Historian") {
+		return
+	}
+	revisions := []*storagepb.Revision{
+		{Kind: "ignition", Name: "a", ResourceVersion: 1, Time: 100, Author: "alice", Content: []byte("one")},
+		{Kind: "ignition", Name: "a/b", ResourceVersion: 2, Content: []byte("nested")},
+		{Kind: "generic", Name: "a", ResourceVersion: 3, Content: []byte("other kind")},
+		{Kind: "ignition", Name: "a", ResourceVersion: 4, Time: 200, Author: "bob", Content: []byte("two")},
+		{Kind: "ignition", Name: "a", Time: 300, Author: "alice", Deleted: true},
+	}
+	for _, revision := range revisions {
+		assert.Nil(t, historian.HistoryAppend(revision))
+	}
+
+	history, err := historian.History("ignition", "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Revision{revisions[0], revisions[3], revisions[4]}, history)
+	history, err = historian.History("ignition", "a/b")
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Revision{revisions[1]}, history)
+	history, err = historian.History("groups", "a")
+	assert.Nil(t, err)
+	assert.Empty(t, history)
+}
@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// migrateKinds are the resource kinds copied by Migrate, in the order they
+// are written, so templates and Profiles exist before resources refer to
+// them.
+var migrateKinds = []string{"partials", "ignition", "generic", "cloud", "profiles", "groups"}
+
+// MigrateAuthor is the author of the Revisions recorded by Migrate.
+const MigrateAuthor = "matchbox migrate"
+
+// A Resource identifies a Group, Profile, or template by kind and name.
+type Resource struct {
+	Kind string
+	Name string
+}
+
+func (r Resource) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+// A Diff summarizes how the resources of a destination Store differ from
+// those of a source Store. Resources are ordered by kind, then name.
+type Diff struct {
+	// resources of the source missing from the destination
+	Created []Resource
+	// resources whose contents differ
+	Updated []Resource
+	// resources with the same contents in both Stores
+	Unchanged []Resource
+	// resources of the destination missing from the source
+	Extra []Resource
+	// Groups and Profiles of the source which cannot be parsed, if the
+	// source is a Checker. Lists skip them, so they cannot be migrated.
+	Unreadable []*storagepb.Problem
+}
+
+// Changes returns the resources a migration writes to the destination.
+func (d *Diff) Changes() []Resource {
+	changes := make([]Resource, 0, len(d.Created)+len(d.Updated))
+	changes = append(changes, d.Created...)
+	return append(changes, d.Updated...)
+}
+
+// DiffStores compares the Groups, Profiles, and templates of a source Store
+// with those of a destination Store. Resource versions and namespaces are
+// ignored, since they are assigned by each Store.
+func DiffStores(src, dst Store) (*Diff, error) {
+	diff := new(Diff)
+	if checker, ok := src.(Checker); ok {
+		problems, err := checker.Check()
+		if err != nil {
+			return nil, err
+		}
+		diff.Unreadable = problems
+	}
+	for _, kind := range migrateKinds {
+		srcContents, err := contents(src, kind)
+		if err != nil {
+			return nil, err
+		}
+		dstContents, err := contents(dst, kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range sortedNames(srcContents) {
+			resource := Resource{Kind: kind, Name: name}
+			dstContent, ok := dstContents[name]
+			switch {
+			case !ok:
+				diff.Created = append(diff.Created, resource)
+			case !bytes.Equal(srcContents[name], dstContent):
+				diff.Updated = append(diff.Updated, resource)
+			default:
+				diff.Unchanged = append(diff.Unchanged, resource)
+			}
+		}
+		for _, name := range sortedNames(dstContents) {
+			if _, ok := srcContents[name]; !ok {
+				diff.Extra = append(diff.Extra, Resource{Kind: kind, Name: name})
+			}
+		}
+	}
+	return diff, nil
+}
+
+// Migrate copies the Groups, Profiles, and templates of a source Store to a
+// destination Store, overwriting destination resources whose contents
+// differ. Resources only in the destination are kept. If the destination is
+// a Historian, a Revision by MigrateAuthor is recorded for each write. If
+// the source has unreadable resources, nothing is written. Migrate returns
+// the Diff of the Stores before the migration.
+func Migrate(src, dst Store) (*Diff, error) {
+	diff, err := DiffStores(src, dst)
+	if err != nil {
+		return nil, err
+	}
+	if err := unreadableError(diff); err != nil {
+		return diff, err
+	}
+	for _, resource := range diff.Changes() {
+		if err := migrate(src, dst, resource); err != nil {
+			return diff, fmt.Errorf("storage: failed to migrate %s: %v", resource, err)
+		}
+	}
+	return diff, nil
+}
+
+// Verify returns an error if a source Store has unreadable resources, or if
+// a destination Store lacks resources of the source or has different
+// contents for them, such as after a Migrate.
+func Verify(src, dst Store) error {
+	diff, err := DiffStores(src, dst)
+	if err != nil {
+		return err
+	}
+	if err := unreadableError(diff); err != nil {
+		return err
+	}
+	if changes := diff.Changes(); len(changes) > 0 {
+		return fmt.Errorf("storage: %d resources differ from the source, including %s", len(changes), changes[0])
+	}
+	return nil
+}
+
+// unreadableError returns an error if the source of a Diff has unreadable
+// resources, which would otherwise be silently left behind.
+func unreadableError(diff *Diff) error {
+	if len(diff.Unreadable) == 0 {
+		return nil
+	}
+	problem := diff.Unreadable[0]
+	return fmt.Errorf("storage: %d source resources cannot be read, including %s/%s: %s", len(diff.Unreadable), problem.Kind, problem.Name, problem.Message)
+}
+
+// migrate copies a resource of a source Store to a destination Store.
+func migrate(src, dst Store, resource Resource) error {
+	var version int64
+	var content []byte
+	var err error
+	switch resource.Kind {
+	case "groups":
+		var group *storagepb.Group
+		if group, err = src.GroupGet(resource.Name); err != nil {
+			return err
+		}
+		group = unversionedGroup(group)
+		if version, err = dst.GroupPut(group); err != nil {
+			return err
+		}
+		content, err = marshalContent(group)
+	case "profiles":
+		var profile *storagepb.Profile
+		if profile, err = src.ProfileGet(resource.Name); err != nil {
+			return err
+		}
+		profile = unversionedProfile(profile)
+		if version, err = dst.ProfilePut(profile); err != nil {
+			return err
+		}
+		content, err = marshalContent(profile)
+	default:
+		var template string
+		if template, err = getTemplate(src, resource.Kind, resource.Name); err != nil {
+			return err
+		}
+		content = []byte(template)
+		version, err = putTemplate(dst, resource.Kind, resource.Name, content)
+	}
+	if err != nil {
+		return err
+	}
+	historian, ok := dst.(Historian)
+	if !ok {
+		return nil
+	}
+	return historian.HistoryAppend(&storagepb.Revision{
+		Kind:            resource.Kind,
+		Name:            resource.Name,
+		ResourceVersion: version,
+		Time:            time.Now().Unix(),
+		Author:          MigrateAuthor,
+		Content:         content,
+	})
+}
+
+// contents returns the contents of the resources of a kind of a Store keyed
+// by name, encoded the same way as recorded Revision contents.
+func contents(store Store, kind string) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	switch kind {
+	case "groups":
+		groups, err := store.GroupList()
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			content, err := marshalContent(unversionedGroup(group))
+			if err != nil {
+				return nil, err
+			}
+			contents[group.Id] = content
+		}
+	case "profiles":
+		profiles, err := store.ProfileList()
+		if err != nil {
+			return nil, err
+		}
+		for _, profile := range profiles {
+			content, err := marshalContent(unversionedProfile(profile))
+			if err != nil {
+				return nil, err
+			}
+			contents[profile.Id] = content
+		}
+	default:
+		names, err := layerTemplateList(store, kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			template, err := getTemplate(store, kind, name)
+			if err != nil {
+				return nil, err
+			}
+			contents[name] = []byte(template)
+		}
+	}
+	return contents, nil
+}
+
+// marshalContent returns the JSON encoding of a Group or Profile, with their
+// metadata as objects so equal metadata encodes equally.
+func marshalContent(resource interface{}) ([]byte, error) {
+	switch r := resource.(type) {
+	case *storagepb.Group:
+		richGroup, err := r.ToRichGroup()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(richGroup)
+	case *storagepb.Profile:
+		richProfile, err := r.ToRichProfile()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(richProfile)
+	}
+	return json.Marshal(resource)
+}
+
+// unversionedGroup returns a copy of a Group without its resource version
+// and namespace.
+func unversionedGroup(group *storagepb.Group) *storagepb.Group {
+	copied := withGroupVersion(group, 0)
+	copied.Namespace = ""
+	return copied
+}
+
+// unversionedProfile returns a copy of a Profile without its resource
+// version and namespace.
+func unversionedProfile(profile *storagepb.Profile) *storagepb.Profile {
+	copied := withProfileVersion(profile, 0)
+	copied.Namespace = ""
+	return copied
+}
+
+// getTemplate returns a template of a kind of a Store.
+func getTemplate(store Store, kind, name string) (string, error) {
+	switch kind {
+	case "ignition":
+		return store.IgnitionGet(name)
+	case "generic":
+		return store.GenericGet(name)
+	case "cloud":
+		return store.CloudGet(name)
+	case "partials":
+		return store.PartialGet(name)
+	}
+	return "", errUnknownKind
+}
+
+// putTemplate unconditionally writes a template of a kind to a Store.
+func putTemplate(store Store, kind, name string, config []byte) (int64, error) {
+	switch kind {
+	case "ignition":
+		return store.IgnitionPut(name, config, 0)
+	case "generic":
+		return store.GenericPut(name, config, 0)
+	case "cloud":
+		return store.CloudPut(name, config, 0)
+	case "partials":
+		return store.PartialPut(name, config, 0)
+	}
+	return 0, errUnknownKind
+}
+
+// sortedNames returns the sorted keys of a map of contents.
+func sortedNames(contents map[string][]byte) []string {
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
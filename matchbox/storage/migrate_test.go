@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		Groups:          map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML},
+		GenericConfigs:  map[string]string{fake.GenericName: fake.Generic},
+		CloudConfigs:    map[string]string{"cloudcfg.yaml": "#cloud-config"},
+		Partials:        map[string]string{"users.yaml": "passwd: {}"},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	src := NewFileStore(&Config{Root: dir})
+	dst, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	_, err = dst.IgnitionPut(fake.IgnitionYAMLName, []byte("stale"), 0)
+	assert.Nil(t, err)
+	_, err = dst.GenericPut("extra.tmpl", []byte("extra"), 0)
+	assert.Nil(t, err)
+
+	// assert that a diff does not write to the destination
+	diff, err := DiffStores(src, dst)
+	assert.Nil(t, err)
+	expected := &Diff{
+		Created: []Resource{
+			{Kind: "partials", Name: "users.yaml"},
+			{Kind: "generic", Name: fake.GenericName},
+			{Kind: "cloud", Name: "cloudcfg.yaml"},
+			{Kind: "profiles", Name: fake.Profile.Id},
+			{Kind: "groups", Name: fake.Group.Id},
+		},
+		Updated: []Resource{{Kind: "ignition", Name: fake.IgnitionYAMLName}},
+		Extra:   []Resource{{Kind: "generic", Name: "extra.tmpl"}},
+	}
+	assert.Equal(t, expected, diff)
+	assert.Error(t, Verify(src, dst))
+
+	// assert that Migrate copies created and updated resources and keeps
+	// extra resources
+	diff, err = Migrate(src, dst)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, diff)
+	assert.Nil(t, Verify(src, dst))
+	group, err := dst.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Selector, group.Selector)
+	template, err := dst.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+	_, err = dst.GenericGet("extra.tmpl")
+	assert.Nil(t, err)
+
+	// assert that writes are recorded in the destination's history
+	revisions, err := dst.(Historian).History("profiles", fake.Profile.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(revisions)) {
+		assert.Equal(t, MigrateAuthor, revisions[0].Author)
+	}
+
+	// assert that a repeated migration writes nothing
+	diff, err = Migrate(src, dst)
+	assert.Nil(t, err)
+	assert.Empty(t, diff.Changes())
+	assert.Equal(t, 6, len(diff.Unchanged))
+}
+
+func TestMigrate_Unreadable(t *testing.T) {
+	dir, err := setupBroken()
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	src := NewFileStore(&Config{Root: dir})
+	dst, cleanup, err := setupBolt(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that:
+	// - unreadable source resources are reported
+	// - nothing is migrated or verified while there are any
+	diff, err := DiffStores(src, dst)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(diff.Unreadable))
+	diff, err = Migrate(src, dst)
+	assert.EqualError(t, err, `storage: 3 source resources cannot be read, including groups/bad-json: `+diff.Unreadable[0].Message)
+	groups, err := dst.GroupList()
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+	assert.Error(t, Verify(src, dst))
+}
+
+func TestDiffStores_IgnoresVersions(t *testing.T) {
+	srcDir, err := ioutil.TempDir("", "src")
+	assert.Nil(t, err)
+	defer os.RemoveAll(srcDir)
+	dstDir, err := ioutil.TempDir("", "dst")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dstDir)
+	src := NewFileStore(&Config{Root: srcDir})
+	dst := NewFileStore(&Config{Root: dstDir})
+
+	// assert that resources with different versions but equal contents are
+	// unchanged
+	for i := 0; i < 2; i++ {
+		_, err = src.ProfilePut(fake.Profile)
+		assert.Nil(t, err)
+	}
+	_, err = dst.ProfilePut(fake.Profile)
+	assert.Nil(t, err)
+	diff, err := DiffStores(src, dst)
+	assert.Nil(t, err)
+	assert.Equal(t, []Resource{{Kind: "profiles", Name: fake.Profile.Id}}, diff.Unchanged)
+	assert.Empty(t, diff.Changes())
+}
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"regexp"
+)
+
+// namespacesDir is the directory, key prefix, or bucket below which Stores
+// keep the resources of namespaces other than the default namespace.
+const namespacesDir = "namespaces"
+
+// namespaceRegexp matches DNS labels of lowercase letters, digits, and dashes.
+var namespaceRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ValidNamespace returns true if the name is a valid namespace name: a DNS
+// label of at most 63 lowercase letters, digits, and dashes. The empty name
+// of the default namespace is not a valid namespace name.
+func ValidNamespace(name string) bool {
+	return len(name) <= 63 && namespaceRegexp.MatchString(name)
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestFileNamespaces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	testNamespaces(t, NewFileStore(&Config{Root: dir}))
+}
+
+func TestValidNamespace(t *testing.T) {
+	for _, name := range []string{"a", "team-a", "0", "a-0-b"} {
+		assert.True(t, ValidNamespace(name), name)
+	}
+	for _, name := range []string{"", "Team", "-a", "a-", "a/b", "..", "a_b", string(make([]byte, 64))} {
+		assert.False(t, ValidNamespace(name), name)
+	}
+}
+
+// testNamespaces asserts that a Namespacer keeps the resources of namespaces
+// apart from each other and from the default namespace.
+func testNamespaces(t *testing.T, store Store) {
+	namespacer, ok := store.(Namespacer)
+	if !assert.True(t, ok, "store must implement Namespacer") {
+		return
+	}
+	for _, name := range []string{"", "Team-A", "../team-a"} {
+		_, err := namespacer.Namespace(name)
+		assert.Equal(t, ErrInvalidNamespace, err)
+	}
+	teamA, err := namespacer.Namespace("team-a")
+	assert.Nil(t, err)
+	teamB, err := namespacer.Namespace("team-b")
+	assert.Nil(t, err)
+	exists, err := namespacer.HasNamespace("team-a")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	// assert that equal names in different namespaces do not collide
+	group := &storagepb.Group{Id: fake.Group.Id, Profile: "team-a-profile"}
+	_, err = store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	_, err = teamA.GroupPut(group)
+	assert.Nil(t, err)
+	_, err = teamA.IgnitionPut("a/b.yaml", []byte("team-a"), 0)
+	assert.Nil(t, err)
+
+	got, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Profile, got.Profile)
+	got, err = teamA.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, group.Profile, got.Profile)
+	_, err = teamB.GroupGet(fake.Group.Id)
+	assert.True(t, os.IsNotExist(err))
+
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+	groups, err = teamA.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+	groups, err = teamB.GroupList()
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+	profiles, err := teamB.ProfileList()
+	assert.Nil(t, err)
+	assert.Empty(t, profiles)
+	assert.True(t, os.IsNotExist(teamB.GroupDelete(fake.Group.Id)))
+
+	// assert that writes create namespaces, but reads do not
+	exists, err = namespacer.HasNamespace("team-a")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	exists, err = namespacer.HasNamespace("team-b")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	names, err := store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+	names, err = teamA.IgnitionList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a/b.yaml"}, names)
+	template, err := teamA.IgnitionGet("a/b.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a", template)
+
+	// assert that deletes only affect their namespace
+	assert.Nil(t, teamA.GroupDelete(fake.Group.Id))
+	_, err = store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+
+	// assert that history is recorded per namespace
+	if historian, ok := teamA.(Historian); ok {
+		revision := &storagepb.Revision{Kind: "groups", Name: fake.Group.Id, Author: "alice", Deleted: true}
+		assert.Nil(t, historian.HistoryAppend(revision))
+		history, err := historian.History("groups", fake.Group.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, []*storagepb.Revision{revision}, history)
+		history, err = store.(Historian).History("groups", fake.Group.Id)
+		assert.Nil(t, err)
+		assert.Empty(t, history)
+	}
+}
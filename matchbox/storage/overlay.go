@@ -0,0 +1,621 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+var (
+	errNoLayers        = errors.New("storage: No overlay layers provided")
+	errNoWhiteouts     = errors.New("storage: Top overlay layer does not record whiteouts")
+	errNoMinVersions   = errors.New("storage: Top overlay layer cannot write resources with a minimum version")
+	errLayerNamespaces = errors.New("storage: Overlay layer does not support namespaces")
+	errUnknownKind     = errors.New("storage: Unknown resource kind")
+)
+
+// OverlayConfig initializes an overlayStore.
+type OverlayConfig struct {
+	// Stores from the bottom layer to the top layer. The top layer must be a
+	// Whiteouter and a file or git Store if there are several layers.
+	Layers []Store
+	Logger *logrus.Logger
+}
+
+// overlayStore implements the Store interface by composing layers of Stores.
+// Reads fall through the layers from the top to the bottom, so resources of
+// higher layers hide those of lower layers with the same name. Writes only
+// go to the top layer. Deleting a resource of a lower layer records a
+// whiteout in the top layer, which hides the resource from reads. Whiteouts
+// recorded by lower layers hide the resources of the layers below them.
+//
+// A resource's version is its version in the layer it is read from. Writes
+// made through the overlayStore are reported to its watchers.
+type overlayStore struct {
+	layers   []Store
+	logger   *logrus.Logger
+	watchers watchHub
+	// serializes writes so conditional puts can check versions
+	mu sync.Mutex
+}
+
+// minVersionStore is a Store which can write a resource with a version
+// greater than a minimum version, so a resource copied up from a lower layer
+// gets a greater version than it has there in a single write.
+type minVersionStore interface {
+	groupPut(group *storagepb.Group, min int64) (int64, error)
+	profilePut(profile *storagepb.Profile, min int64) (int64, error)
+	templatePut(kind, name string, config []byte, expected, min int64) (int64, error)
+}
+
+// NewOverlayStore returns a new Store of the given layers.
+func NewOverlayStore(config *OverlayConfig) (Store, error) {
+	if len(config.Layers) == 0 {
+		return nil, errNoLayers
+	}
+	if len(config.Layers) > 1 {
+		top := config.Layers[len(config.Layers)-1]
+		if _, ok := top.(Whiteouter); !ok {
+			return nil, errNoWhiteouts
+		}
+		if _, ok := top.(minVersionStore); !ok {
+			return nil, errNoMinVersions
+		}
+	}
+	return &overlayStore{
+		layers: config.Layers,
+		logger: config.Logger,
+	}, nil
+}
+
+// Namespace returns an overlayStore of the namespaces of each layer.
+func (s *overlayStore) Namespace(name string) (Store, error) {
+	if !ValidNamespace(name) {
+		return nil, ErrInvalidNamespace
+	}
+	layers := make([]Store, len(s.layers))
+	for i, layer := range s.layers {
+		namespacer, ok := layer.(Namespacer)
+		if !ok {
+			return nil, errLayerNamespaces
+		}
+		store, err := namespacer.Namespace(name)
+		if err != nil {
+			return nil, err
+		}
+		layers[i] = store
+	}
+	return NewOverlayStore(&OverlayConfig{Layers: layers, Logger: s.logger})
+}
+
+// HasNamespace returns true if any layer has the namespace.
+func (s *overlayStore) HasNamespace(name string) (bool, error) {
+	for _, layer := range s.layers {
+		namespacer, ok := layer.(Namespacer)
+		if !ok {
+			return false, errLayerNamespaces
+		}
+		if ok, err := namespacer.HasNamespace(name); ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// GroupPut writes the given Group to the top layer.
+func (s *overlayStore) GroupPut(group *storagepb.Group) (int64, error) {
+	version, err := s.put("groups", group.Id, group.ResourceVersion, func(min int64) (int64, error) {
+		if top, ok := s.top().(minVersionStore); ok {
+			return top.groupPut(withGroupVersion(group, 0), min)
+		}
+		return s.top().GroupPut(withGroupVersion(group, 0))
+	})
+	if err != nil {
+		return 0, err
+	}
+	event := putEvent("groups", group.Id)
+	event.Group = withGroupVersion(group, version)
+	s.watchers.publish(event)
+	return version, nil
+}
+
+// GroupGet returns a machine Group by id.
+func (s *overlayStore) GroupGet(id string) (*storagepb.Group, error) {
+	layer, err := s.layer("groups", id)
+	if err != nil {
+		return nil, err
+	}
+	return layer.GroupGet(id)
+}
+
+// GroupDelete deletes a machine Group by id.
+func (s *overlayStore) GroupDelete(id string) error {
+	return s.delete("groups", id, func(layer Store) error {
+		return layer.GroupDelete(id)
+	})
+}
+
+// GroupList lists the machine Groups of all layers.
+func (s *overlayStore) GroupList() ([]*storagepb.Group, error) {
+	var groups []*storagepb.Group
+	err := s.walk("groups", func(layer Store, hidden map[string]bool) error {
+		layerGroups, err := layer.GroupList()
+		for _, group := range layerGroups {
+			if !hidden[group.Id] {
+				hidden[group.Id] = true
+				groups = append(groups, group)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GroupVersion returns the Group versions and Group whiteouts of all
+// layers, which change whenever a layer's Groups change.
+func (s *overlayStore) GroupVersion() (string, error) {
+	var versions []string
+	for _, layer := range s.layers {
+		if versioner, ok := layer.(GroupVersioner); ok {
+			version, err := versioner.GroupVersion()
+			if err != nil {
+				return "", err
+			}
+			versions = append(versions, version)
+		}
+		whiteouts, err := layerWhiteouts(layer, "groups")
+		if err != nil {
+			return "", err
+		}
+		versions = append(versions, strings.Join(whiteouts, ","))
+	}
+	return strings.Join(versions, "\n"), nil
+}
+
+// ProfilePut writes the given Profile to the top layer.
+func (s *overlayStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	version, err := s.put("profiles", profile.Id, profile.ResourceVersion, func(min int64) (int64, error) {
+		if top, ok := s.top().(minVersionStore); ok {
+			return top.profilePut(withProfileVersion(profile, 0), min)
+		}
+		return s.top().ProfilePut(withProfileVersion(profile, 0))
+	})
+	if err != nil {
+		return 0, err
+	}
+	event := putEvent("profiles", profile.Id)
+	event.Profile = withProfileVersion(profile, version)
+	s.watchers.publish(event)
+	return version, nil
+}
+
+// ProfileGet gets a profile by id.
+func (s *overlayStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	layer, err := s.layer("profiles", id)
+	if err != nil {
+		return nil, err
+	}
+	return layer.ProfileGet(id)
+}
+
+// ProfileDelete deletes a profile by id.
+func (s *overlayStore) ProfileDelete(id string) error {
+	return s.delete("profiles", id, func(layer Store) error {
+		return layer.ProfileDelete(id)
+	})
+}
+
+// ProfileList lists the profiles of all layers.
+func (s *overlayStore) ProfileList() ([]*storagepb.Profile, error) {
+	var profiles []*storagepb.Profile
+	err := s.walk("profiles", func(layer Store, hidden map[string]bool) error {
+		layerProfiles, err := layer.ProfileList()
+		for _, profile := range layerProfiles {
+			if !hidden[profile.Id] {
+				hidden[profile.Id] = true
+				profiles = append(profiles, profile)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Check returns the Problems of all layers which are Checkers, including
+// those of resources hidden by higher layers.
+func (s *overlayStore) Check() ([]*storagepb.Problem, error) {
+	var problems []*storagepb.Problem
+	for i := len(s.layers) - 1; i >= 0; i-- {
+		checker, ok := s.layers[i].(Checker)
+		if !ok {
+			continue
+		}
+		layerProblems, err := checker.Check()
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, layerProblems...)
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Kind != problems[j].Kind {
+			return problems[i].Kind < problems[j].Kind
+		}
+		return problems[i].Name < problems[j].Name
+	})
+	return problems, nil
+}
+
+// IgnitionPut writes an Ignition template to the top layer.
+func (s *overlayStore) IgnitionPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("ignition", name, config, version)
+}
+
+// IgnitionGet gets an Ignition template by name.
+func (s *overlayStore) IgnitionGet(name string) (string, error) {
+	return s.templateGet("ignition", name)
+}
+
+// IgnitionVersion gets the resource version of an Ignition template.
+func (s *overlayStore) IgnitionVersion(name string) (int64, error) {
+	return s.version("ignition", name)
+}
+
+// IgnitionDelete deletes an Ignition template by name.
+func (s *overlayStore) IgnitionDelete(name string) error {
+	return s.templateDelete("ignition", name)
+}
+
+// IgnitionList lists the names of the Ignition templates of all layers.
+func (s *overlayStore) IgnitionList() ([]string, error) {
+	return s.templateList("ignition")
+}
+
+// GenericPut writes a Generic template to the top layer.
+func (s *overlayStore) GenericPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("generic", name, config, version)
+}
+
+// GenericGet gets a Generic template by name.
+func (s *overlayStore) GenericGet(name string) (string, error) {
+	return s.templateGet("generic", name)
+}
+
+// GenericVersion gets the resource version of a Generic template.
+func (s *overlayStore) GenericVersion(name string) (int64, error) {
+	return s.version("generic", name)
+}
+
+// GenericDelete deletes a Generic template by name.
+func (s *overlayStore) GenericDelete(name string) error {
+	return s.templateDelete("generic", name)
+}
+
+// GenericList lists the names of the Generic templates of all layers.
+func (s *overlayStore) GenericList() ([]string, error) {
+	return s.templateList("generic")
+}
+
+// CloudPut writes a Cloud-Config template to the top layer.
+func (s *overlayStore) CloudPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("cloud", name, config, version)
+}
+
+// CloudGet gets a Cloud-Config template by name.
+func (s *overlayStore) CloudGet(name string) (string, error) {
+	return s.templateGet("cloud", name)
+}
+
+// CloudVersion gets the resource version of a Cloud-Config template.
+func (s *overlayStore) CloudVersion(name string) (int64, error) {
+	return s.version("cloud", name)
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *overlayStore) CloudDelete(name string) error {
+	return s.templateDelete("cloud", name)
+}
+
+// CloudList lists the names of the Cloud-Config templates of all layers.
+func (s *overlayStore) CloudList() ([]string, error) {
+	return s.templateList("cloud")
+}
+
+// PartialPut writes a partial template to the top layer.
+func (s *overlayStore) PartialPut(name string, config []byte, version int64) (int64, error) {
+	return s.templatePut("partials", name, config, version)
+}
+
+// PartialGet gets a partial template by name.
+func (s *overlayStore) PartialGet(name string) (string, error) {
+	return s.templateGet("partials", name)
+}
+
+// PartialVersion gets the resource version of a partial template.
+func (s *overlayStore) PartialVersion(name string) (int64, error) {
+	return s.version("partials", name)
+}
+
+// PartialDelete deletes a partial template by name.
+func (s *overlayStore) PartialDelete(name string) error {
+	return s.templateDelete("partials", name)
+}
+
+// PartialList lists the names of the partial templates of all layers.
+func (s *overlayStore) PartialList() ([]string, error) {
+	return s.templateList("partials")
+}
+
+// Watch returns a channel of Events for writes made through the
+// overlayStore.
+func (s *overlayStore) Watch(ctx context.Context) (<-chan *storagepb.Event, error) {
+	return s.watchers.watch(ctx), nil
+}
+
+// HistoryAppend records a Revision in the top layer, if it keeps a history.
+func (s *overlayStore) HistoryAppend(revision *storagepb.Revision) error {
+	if historian, ok := s.top().(Historian); ok {
+		return historian.HistoryAppend(revision)
+	}
+	return nil
+}
+
+// History returns the Revisions recorded in the top layer, oldest first.
+func (s *overlayStore) History(kind, name string) ([]*storagepb.Revision, error) {
+	if historian, ok := s.top().(Historian); ok {
+		return historian.History(kind, name)
+	}
+	return nil, nil
+}
+
+// top returns the top layer, which receives all writes.
+func (s *overlayStore) top() Store {
+	return s.layers[len(s.layers)-1]
+}
+
+// layer returns the top-most layer which has a resource. The error
+// satisfies os.IsNotExist if no layer has the resource or it is whited out.
+func (s *overlayStore) layer(kind, name string) (Store, error) {
+	for i := len(s.layers) - 1; i >= 0; i-- {
+		layer := s.layers[i]
+		_, err := layerVersion(layer, kind, name)
+		if err == nil {
+			return layer, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		whiteouts, err := layerWhiteouts(layer, kind)
+		if err != nil {
+			return nil, err
+		}
+		if containsString(whiteouts, name) {
+			break
+		}
+	}
+	return nil, notExist("get", path.Join(kind, name))
+}
+
+// version returns the resource version of a resource in the top-most layer
+// which has it.
+func (s *overlayStore) version(kind, name string) (int64, error) {
+	layer, err := s.layer(kind, name)
+	if err != nil {
+		return 0, err
+	}
+	return layerVersion(layer, kind, name)
+}
+
+// put writes a resource to the top layer with a given put function, unless
+// a non-zero expected version differs from the current version, and
+// removes the resource's whiteout. The put function is called with the
+// current version, which the new version must be greater than, since a
+// resource copied up from a lower layer may have a greater version there
+// than the top layer would assign.
+func (s *overlayStore) put(kind, name string, expected int64, put func(min int64) (int64, error)) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, err := s.version(kind, name)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if expected != 0 && expected != current {
+		return 0, ErrVersionConflict
+	}
+	version, err := put(current)
+	if err != nil {
+		return 0, err
+	}
+	if whiteouter, ok := s.top().(Whiteouter); ok {
+		if err := whiteouter.WhiteoutDelete(kind, name); err != nil {
+			return 0, err
+		}
+	}
+	return version, nil
+}
+
+// delete deletes a resource from the top layer with a given delete function
+// and records a whiteout if a lower layer still has the resource.
+func (s *overlayStore) delete(kind, name string, del func(layer Store) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	layer, err := s.layer(kind, name)
+	if err != nil {
+		return err
+	}
+	if layer == s.top() {
+		if err := del(layer); err != nil {
+			return err
+		}
+		if _, err := s.layer(kind, name); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			s.watchers.publish(deleteEvent(kind, name))
+			return nil
+		}
+	}
+	if err := s.top().(Whiteouter).WhiteoutPut(kind, name); err != nil {
+		return err
+	}
+	s.watchers.publish(deleteEvent(kind, name))
+	return nil
+}
+
+// walk calls a function for each layer, from the top to the bottom, with the
+// names of the resources of a kind which are hidden by higher layers. The
+// function should add the names of the layer's resources it visits.
+func (s *overlayStore) walk(kind string, fn func(layer Store, hidden map[string]bool) error) error {
+	hidden := make(map[string]bool)
+	for i := len(s.layers) - 1; i >= 0; i-- {
+		layer := s.layers[i]
+		if err := fn(layer, hidden); err != nil {
+			return err
+		}
+		whiteouts, err := layerWhiteouts(layer, kind)
+		if err != nil {
+			return err
+		}
+		for _, name := range whiteouts {
+			hidden[name] = true
+		}
+	}
+	return nil
+}
+
+// templatePut writes a template of the given kind to the top layer and
+// publishes the Event.
+func (s *overlayStore) templatePut(kind, name string, config []byte, expected int64) (int64, error) {
+	version, err := s.put(kind, name, expected, func(min int64) (int64, error) {
+		if top, ok := s.top().(minVersionStore); ok {
+			return top.templatePut(kind, name, config, 0, min)
+		}
+		return putTemplate(s.top(), kind, name, config)
+	})
+	if err != nil {
+		return 0, err
+	}
+	event := putEvent(kind, name)
+	event.Template = config
+	s.watchers.publish(event)
+	return version, nil
+}
+
+// templateGet returns a template of the given kind from the top-most layer
+// which has it.
+func (s *overlayStore) templateGet(kind, name string) (string, error) {
+	layer, err := s.layer(kind, name)
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case "ignition":
+		return layer.IgnitionGet(name)
+	case "generic":
+		return layer.GenericGet(name)
+	case "partials":
+		return layer.PartialGet(name)
+	default:
+		return layer.CloudGet(name)
+	}
+}
+
+// templateDelete deletes a template of the given kind.
+func (s *overlayStore) templateDelete(kind, name string) error {
+	return s.delete(kind, name, func(layer Store) error {
+		switch kind {
+		case "ignition":
+			return layer.IgnitionDelete(name)
+		case "generic":
+			return layer.GenericDelete(name)
+		case "partials":
+			return layer.PartialDelete(name)
+		default:
+			return layer.CloudDelete(name)
+		}
+	})
+}
+
+// templateList returns the sorted names of the templates of a kind of all
+// layers.
+func (s *overlayStore) templateList(kind string) ([]string, error) {
+	var names []string
+	err := s.walk(kind, func(layer Store, hidden map[string]bool) error {
+		layerNames, err := layerTemplateList(layer, kind)
+		for _, name := range layerNames {
+			if !hidden[name] {
+				hidden[name] = true
+				names = append(names, name)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// layerVersion returns the resource version of a resource of a layer.
+func layerVersion(layer Store, kind, name string) (int64, error) {
+	switch kind {
+	case "groups":
+		group, err := layer.GroupGet(name)
+		if err != nil {
+			return 0, err
+		}
+		return group.ResourceVersion, nil
+	case "profiles":
+		profile, err := layer.ProfileGet(name)
+		if err != nil {
+			return 0, err
+		}
+		return profile.ResourceVersion, nil
+	case "ignition":
+		return layer.IgnitionVersion(name)
+	case "generic":
+		return layer.GenericVersion(name)
+	case "cloud":
+		return layer.CloudVersion(name)
+	case "partials":
+		return layer.PartialVersion(name)
+	}
+	return 0, errUnknownKind
+}
+
+// layerTemplateList returns the names of the templates of a kind of a layer.
+func layerTemplateList(layer Store, kind string) ([]string, error) {
+	switch kind {
+	case "ignition":
+		return layer.IgnitionList()
+	case "generic":
+		return layer.GenericList()
+	case "partials":
+		return layer.PartialList()
+	default:
+		return layer.CloudList()
+	}
+}
+
+// layerWhiteouts returns the whiteouts of a kind of a layer, if it records
+// whiteouts.
+func layerWhiteouts(layer Store, kind string) ([]string, error) {
+	if whiteouter, ok := layer.(Whiteouter); ok {
+		return whiteouter.Whiteouts(kind)
+	}
+	return nil, nil
+}
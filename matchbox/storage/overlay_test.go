@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestOverlay(t *testing.T) {
+	store, base, cleanup, err := setupOverlay(&fake.FixedStore{
+		Groups:          map[string]*storagepb.Group{fake.Group.Id: fake.Group, fake.GroupNoMetadata.Id: fake.GroupNoMetadata},
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML, "base.yaml": "base"},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	// assert that reads fall through to the base layer
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, withGroupVersion(fake.Group, 1), group)
+	template, err := store.IgnitionGet("base.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "base", template)
+
+	// assert that writes go to the top layer and hide the base resource
+	version, err := store.IgnitionPut(fake.IgnitionYAMLName, []byte("top"), 1)
+	assert.Nil(t, err)
+	assert.True(t, version > 1, "versions must not repeat")
+	template, err = store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, "top", template)
+	template, err = base.IgnitionGet(fake.IgnitionYAMLName)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, template)
+	_, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte("stale"), 1)
+	assert.Equal(t, ErrVersionConflict, err)
+
+	// assert that deletes of base resources are recorded as whiteouts
+	assert.Nil(t, store.GroupDelete(fake.Group.Id))
+	_, err = store.GroupGet(fake.Group.Id)
+	assert.True(t, os.IsNotExist(err))
+	_, err = base.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Group{withGroupVersion(fake.GroupNoMetadata, 1)}, groups)
+	assert.True(t, os.IsNotExist(store.GroupDelete(fake.Group.Id)))
+
+	assert.Nil(t, store.IgnitionDelete(fake.IgnitionYAMLName))
+	_, err = store.IgnitionGet(fake.IgnitionYAMLName)
+	assert.True(t, os.IsNotExist(err))
+	names, err := store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"base.yaml"}, names)
+
+	// assert that a put after a delete removes the whiteout
+	_, err = store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	groups, err = store.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(groups))
+}
+
+func TestOverlay_CopyUp(t *testing.T) {
+	store, base, cleanup, err := setupOverlay(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+	})
+	assert.Nil(t, err)
+	defer cleanup()
+	// the base layer has a version a database would reach after many writes
+	versionFile := filepath.Join(base.(*fileStore).root, versionsDir, "groups", fake.Group.Id+".json")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(versionFile), 0755))
+	assert.Nil(t, ioutil.WriteFile(versionFile, []byte("1000"), 0644))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := store.(*overlayStore).top().Watch(ctx)
+	assert.Nil(t, err)
+
+	// assert that a copied up resource gets a greater version in one write
+	version, err := store.GroupPut(withGroupVersion(fake.Group, 1000))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1001), version)
+	assert.Equal(t, 1, len(events))
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1001), group.ResourceVersion)
+}
+
+func TestOverlayTemplates(t *testing.T) {
+	store, _, cleanup, err := setupOverlay(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testTemplates(t, store)
+}
+
+func TestOverlayConditionalPut(t *testing.T) {
+	store, _, cleanup, err := setupOverlay(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testConditionalPut(t, store)
+}
+
+func TestOverlayHistory(t *testing.T) {
+	store, _, cleanup, err := setupOverlay(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testHistory(t, store)
+}
+
+func TestOverlayWatch(t *testing.T) {
+	store, _, cleanup, err := setupOverlay(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testWatch(t, store)
+}
+
+func TestOverlayNamespaces(t *testing.T) {
+	store, _, cleanup, err := setupOverlay(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer cleanup()
+	testNamespaces(t, store)
+}
+
+func TestNewOverlayStore_Invalid(t *testing.T) {
+	_, err := NewOverlayStore(&OverlayConfig{})
+	assert.Equal(t, errNoLayers, err)
+	_, err = NewOverlayStore(&OverlayConfig{Layers: []Store{&fake.EmptyStore{}, &fake.EmptyStore{}}})
+	assert.Equal(t, errNoWhiteouts, err)
+}
+
+// setupOverlay writes a -data-path tree mirroring a given fixedStore as the
+// base layer and returns an overlay Store of the base layer and an empty top
+// layer, as well as the base layer. The caller must call the returned
+// cleanup function when finished.
+func setupOverlay(fixedStore *fake.FixedStore) (Store, Store, func(), error) {
+	dir, err := setup(fixedStore)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	top, err := ioutil.TempDir("", "top")
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, nil, err
+	}
+	cleanup := func() {
+		os.RemoveAll(dir)
+		os.RemoveAll(top)
+	}
+	base := NewFileStore(&Config{Root: dir})
+	store, err := NewOverlayStore(&OverlayConfig{
+		Layers: []Store{base, NewFileStore(&Config{Root: filepath.Join(top, "data")})},
+	})
+	if err != nil {
+		cleanup()
+		return nil, nil, nil, err
+	}
+	return store, base, cleanup, nil
+}
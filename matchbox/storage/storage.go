@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"errors"
 
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
@@ -10,12 +11,30 @@ import (
 var (
 	ErrGroupNotFound   = errors.New("storage: No Group found")
 	ErrProfileNotFound = errors.New("storage: No Profile found")
+	// ErrVersionConflict is returned by a conditional put when the stored
+	// resource does not have the expected resource version.
+	ErrVersionConflict = storagepb.ErrVersionConflict
+	// ErrInvalidNamespace is returned for namespace names which are not DNS
+	// labels.
+	ErrInvalidNamespace = errors.New("storage: Invalid namespace name")
+	// ErrReadOnly is returned by writes to a Store which serves a fixed
+	// state, such as a git Store pinned to a ref.
+	ErrReadOnly = errors.New("storage: Store is read-only")
 )
 
 // A Store stores machine Groups, Profiles, and Configs.
+//
+// Every stored resource has a resource version which increases whenever it
+// is written. A put with a non-zero resource version is conditional: it fails
+// with ErrVersionConflict unless the stored resource has that version. Puts
+// return the new resource version and never modify their arguments.
+//
+// Partial templates are shared by the other templates, which include them by
+// name. Template names may contain slashes. Template lists return the sorted names
+// of all templates of a kind, including nested ones (e.g. "tests/a.yaml").
 type Store interface {
 	// GroupPut creates or updates a Group.
-	GroupPut(group *storagepb.Group) error
+	GroupPut(group *storagepb.Group) (int64, error)
 	// GroupGet returns a machine Group by id.
 	GroupGet(id string) (*storagepb.Group, error)
 	// GroupDelete deletes a machine Group by id.
@@ -24,7 +43,7 @@ type Store interface {
 	GroupList() ([]*storagepb.Group, error)
 
 	// ProfilePut creates or updates a Profile.
-	ProfilePut(profile *storagepb.Profile) error
+	ProfilePut(profile *storagepb.Profile) (int64, error)
 	// ProfileGet gets a profile by id.
 	ProfileGet(id string) (*storagepb.Profile, error)
 	// ProfileDelete deletes a profile by id.
@@ -33,19 +52,111 @@ type Store interface {
 	ProfileList() ([]*storagepb.Profile, error)
 
 	// IgnitionPut creates or updates an Ignition template.
-	IgnitionPut(name string, config []byte) error
+	IgnitionPut(name string, config []byte, version int64) (int64, error)
 	// IgnitionGet gets an Ignition template by name.
 	IgnitionGet(name string) (string, error)
+	// IgnitionVersion gets the resource version of an Ignition template.
+	IgnitionVersion(name string) (int64, error)
 	// IgnitionDelete deletes an Ignition template by name.
 	IgnitionDelete(name string) error
+	// IgnitionList lists the names of all Ignition templates.
+	IgnitionList() ([]string, error)
 
 	// GenericPut creates or updates a Generic template.
-	GenericPut(name string, config []byte) error
+	GenericPut(name string, config []byte, version int64) (int64, error)
 	// GenericGet gets a Generic template by name.
 	GenericGet(name string) (string, error)
+	// GenericVersion gets the resource version of a Generic template.
+	GenericVersion(name string) (int64, error)
 	// GenericDelete deletes a Generic template by name.
 	GenericDelete(name string) error
+	// GenericList lists the names of all Generic templates.
+	GenericList() ([]string, error)
 
+	// CloudPut creates or updates a Cloud-Config template.
+	CloudPut(name string, config []byte, version int64) (int64, error)
 	// CloudGet gets a Cloud-Config template by name.
 	CloudGet(name string) (string, error)
+	// CloudVersion gets the resource version of a Cloud-Config template.
+	CloudVersion(name string) (int64, error)
+	// CloudDelete deletes a Cloud-Config template by name.
+	CloudDelete(name string) error
+	// CloudList lists the names of all Cloud-Config templates.
+	CloudList() ([]string, error)
+
+	// PartialPut creates or updates a partial template.
+	PartialPut(name string, config []byte, version int64) (int64, error)
+	// PartialGet gets a partial template by name.
+	PartialGet(name string) (string, error)
+	// PartialVersion gets the resource version of a partial template.
+	PartialVersion(name string) (int64, error)
+	// PartialDelete deletes a partial template by name.
+	PartialDelete(name string) error
+	// PartialList lists the names of all partial templates.
+	PartialList() ([]string, error)
+
+	// Watch returns a channel of Events for changes to Groups, Profiles, and
+	// templates made after the call. Put Events carry the written resource,
+	// delete Events only its kind and name. The channel is closed when the
+	// context is done or the watch fails (e.g. the receiver fell behind).
+	Watch(ctx context.Context) (<-chan *storagepb.Event, error)
+}
+
+// A GroupVersioner is a Store whose Groups may be changed by writers other
+// than matchbox, such as files edited under a data directory. GroupVersion
+// returns an opaque value which changes whenever the stored Groups change and
+// is much cheaper to compute than listing the Groups.
+type GroupVersioner interface {
+	GroupVersion() (string, error)
+}
+
+// A Historian is a Store which keeps a history of the writes to its
+// resources. Writers append a Revision after each successful put or delete,
+// since only they know the author of the write.
+type Historian interface {
+	// HistoryAppend records a Revision of a resource.
+	HistoryAppend(revision *storagepb.Revision) error
+	// History returns the recorded Revisions of a resource, oldest first.
+	History(kind, name string) ([]*storagepb.Revision, error)
+}
+
+// A Recorder is a Historian which appends the Revision of a write in the
+// same transaction as the write, so a write is never without its Revision.
+// Recording returns a Store whose puts and deletes also append the given
+// Revision, with the new resource version of puts.
+type Recorder interface {
+	Historian
+	Recording(revision *storagepb.Revision) Store
+}
+
+// A Namespacer is a Store which partitions resources into namespaces, so
+// resources with the same name in different namespaces do not collide. The
+// Store itself holds the default namespace. Namespace returns a Store of the
+// resources of the named namespace, or ErrInvalidNamespace if the name is not
+// a valid namespace name. Namespaces are created by the first write to them,
+// never by reads, and HasNamespace returns true once a namespace has been
+// created. Callers should reuse the returned Store, since Stores only report
+// their own writes to watchers.
+type Namespacer interface {
+	Namespace(name string) (Store, error)
+	HasNamespace(name string) (bool, error)
+}
+
+// A Whiteouter is a Store which records whiteouts, markers which hide the
+// resources of lower layers of an overlay Store. Names of whiteouts are the
+// ids of Groups and Profiles or the names of templates.
+type Whiteouter interface {
+	// WhiteoutPut records a whiteout of a resource.
+	WhiteoutPut(kind, name string) error
+	// WhiteoutDelete removes the whiteout of a resource, if any.
+	WhiteoutDelete(kind, name string) error
+	// Whiteouts returns the sorted names of the whiteouts of a kind.
+	Whiteouts(kind string) ([]string, error)
+}
+
+// A Checker is a Store which may hold Groups or Profiles that cannot be
+// parsed, such as hand-edited files, which lists skip. Check returns a
+// Problem for each of them, ordered by kind and name.
+type Checker interface {
+	Check() ([]*storagepb.Problem, error)
 }
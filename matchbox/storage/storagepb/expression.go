@@ -0,0 +1,193 @@
+package storagepb
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MatchExpression operators
+const (
+	OperatorIn           = "In"
+	OperatorNotIn        = "NotIn"
+	OperatorExists       = "Exists"
+	OperatorDoesNotExist = "DoesNotExist"
+	OperatorRegex        = "Regex"
+	OperatorCIDR         = "CIDR"
+)
+
+var (
+	errExpressionKey    = errors.New("match expression requires a key")
+	errExpressionValues = errors.New("match expression requires values")
+)
+
+// operators are the MatchExpression operators by their lowercase names.
+var operators = map[string]string{
+	"in":           OperatorIn,
+	"notin":        OperatorNotIn,
+	"exists":       OperatorExists,
+	"doesnotexist": OperatorDoesNotExist,
+	"regex":        OperatorRegex,
+	"cidr":         OperatorCIDR,
+}
+
+// regexps caches compiled Regex expression values, since Groups are matched
+// on every request.
+var regexps = struct {
+	sync.RWMutex
+	m map[string]*regexp.Regexp
+}{m: make(map[string]*regexp.Regexp)}
+
+// Matches returns true if the given labels satisfy the expression, false
+// otherwise. Invalid expressions match nothing.
+func (e *MatchExpression) Matches(labels map[string]string) bool {
+	value, ok := labels[e.Key]
+	switch operators[strings.ToLower(e.Operator)] {
+	case OperatorIn:
+		return ok && containsValue(e.Values, value)
+	case OperatorNotIn:
+		return !ok || !containsValue(e.Values, value)
+	case OperatorExists:
+		return ok
+	case OperatorDoesNotExist:
+		return !ok
+	case OperatorRegex:
+		if !ok || len(e.Values) != 1 {
+			return false
+		}
+		re, err := compileRegexp(e.Values[0])
+		return err == nil && re.MatchString(value)
+	case OperatorCIDR:
+		ip := net.ParseIP(value)
+		if !ok || ip == nil {
+			return false
+		}
+		for _, cidr := range e.Values {
+			if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Normalize validates the expression, spells its operator canonically (e.g.
+// "notin" as "NotIn"), and normalizes the MAC addresses of "mac" values.
+func (e *MatchExpression) Normalize() error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	e.Operator = operators[strings.ToLower(e.Operator)]
+	if strings.ToLower(e.Key) == "mac" && (e.Operator == OperatorIn || e.Operator == OperatorNotIn) {
+		for i, value := range e.Values {
+			macAddr, err := net.ParseMAC(value)
+			if err != nil {
+				return err
+			}
+			e.Values[i] = macAddr.String()
+		}
+	}
+	return nil
+}
+
+// validate returns an error if the expression has no key, an unknown
+// operator, or values which do not suit its operator.
+func (e *MatchExpression) validate() error {
+	if e.Key == "" {
+		return errExpressionKey
+	}
+	operator, ok := operators[strings.ToLower(e.Operator)]
+	if !ok {
+		return fmt.Errorf("match expression on %q has unknown operator %q", e.Key, e.Operator)
+	}
+	switch operator {
+	case OperatorIn, OperatorNotIn, OperatorCIDR:
+		if len(e.Values) == 0 {
+			return errExpressionValues
+		}
+	case OperatorExists, OperatorDoesNotExist:
+		if len(e.Values) != 0 {
+			return fmt.Errorf("match expression %s on %q takes no values", operator, e.Key)
+		}
+	case OperatorRegex:
+		if len(e.Values) != 1 {
+			return fmt.Errorf("match expression Regex on %q takes one value", e.Key)
+		}
+		if _, err := compileRegexp(e.Values[0]); err != nil {
+			return err
+		}
+	}
+	if operator == OperatorCIDR {
+		for _, cidr := range e.Values {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// disjoint returns true if no label value can satisfy both expressions on
+// the same key. It may return false for expressions which are disjoint, such
+// as two Regex expressions, but never true for expressions which overlap.
+func (e *MatchExpression) disjoint(other *MatchExpression) bool {
+	a, b := operators[strings.ToLower(e.Operator)], operators[strings.ToLower(other.Operator)]
+	if a == OperatorDoesNotExist || b == OperatorDoesNotExist {
+		// only NotIn and DoesNotExist are satisfied by a missing label
+		return (a != OperatorNotIn && a != OperatorDoesNotExist) || (b != OperatorNotIn && b != OperatorDoesNotExist)
+	}
+	if b == OperatorIn {
+		e, other = other, e
+		a = b
+	}
+	if a != OperatorIn {
+		return false
+	}
+	for _, value := range e.Values {
+		if other.Matches(map[string]string{other.Key: value}) {
+			return false
+		}
+	}
+	return true
+}
+
+// requirement returns the expression as a string for comparisons, such as
+// "mac In (52:54:00:a1:9c:ae,52:54:00:b2:2f:86)".
+func (e *MatchExpression) requirement() string {
+	values := make([]string, len(e.Values))
+	copy(values, e.Values)
+	sort.Strings(values)
+	return fmt.Sprintf("%s %s (%s)", e.Key, e.Operator, strings.Join(values, ","))
+}
+
+// compileRegexp returns a compiled regular expression, which is cached.
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	regexps.RLock()
+	re, ok := regexps.m[expr]
+	regexps.RUnlock()
+	if ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexps.Lock()
+	regexps.m[expr] = re
+	regexps.Unlock()
+	return re, nil
+}
+
+// containsValue returns true if the values contain the value.
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
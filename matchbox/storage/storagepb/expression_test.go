@@ -0,0 +1,165 @@
+package storagepb
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchExpressionMatches(t *testing.T) {
+	labels := map[string]string{
+		"mac":      "52:da:00:89:d8:10",
+		"hostname": "worker-1",
+		"os":       "flatcar",
+		"ip":       "10.1.2.3",
+	}
+	cases := []struct {
+		expr     *MatchExpression
+		expected bool
+	}{
+		{&MatchExpression{Key: "mac", Operator: OperatorIn, Values: []string{"52:da:00:89:d8:10", "52:da:00:89:d8:11"}}, true},
+		{&MatchExpression{Key: "mac", Operator: OperatorIn, Values: []string{"52:da:00:89:d8:11"}}, false},
+		{&MatchExpression{Key: "serial", Operator: OperatorIn, Values: []string{"a"}}, false},
+		{&MatchExpression{Key: "os", Operator: OperatorNotIn, Values: []string{"flatcar"}}, false},
+		{&MatchExpression{Key: "os", Operator: OperatorNotIn, Values: []string{"fedora"}}, true},
+		{&MatchExpression{Key: "serial", Operator: OperatorNotIn, Values: []string{"a"}}, true},
+		{&MatchExpression{Key: "hostname", Operator: OperatorExists}, true},
+		{&MatchExpression{Key: "serial", Operator: OperatorExists}, false},
+		{&MatchExpression{Key: "serial", Operator: OperatorDoesNotExist}, true},
+		{&MatchExpression{Key: "os", Operator: OperatorDoesNotExist}, false},
+		{&MatchExpression{Key: "hostname", Operator: OperatorRegex, Values: []string{"^worker-"}}, true},
+		{&MatchExpression{Key: "hostname", Operator: OperatorRegex, Values: []string{"^controller-"}}, false},
+		{&MatchExpression{Key: "hostname", Operator: OperatorRegex, Values: []string{"("}}, false},
+		{&MatchExpression{Key: "ip", Operator: OperatorCIDR, Values: []string{"10.0.0.0/16", "10.1.0.0/16"}}, true},
+		{&MatchExpression{Key: "ip", Operator: OperatorCIDR, Values: []string{"10.0.0.0/16"}}, false},
+		{&MatchExpression{Key: "os", Operator: OperatorCIDR, Values: []string{"10.1.0.0/16"}}, false},
+		{&MatchExpression{Key: "os", Operator: "Unknown"}, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.expr.Matches(labels), c.expr.requirement())
+	}
+}
+
+func TestMatchExpressionNormalize(t *testing.T) {
+	cases := []struct {
+		expr       *MatchExpression
+		normalized *MatchExpression
+		valid      bool
+	}{
+		{&MatchExpression{Key: "os", Operator: "notin", Values: []string{"flatcar"}}, &MatchExpression{Key: "os", Operator: OperatorNotIn, Values: []string{"flatcar"}}, true},
+		{&MatchExpression{Key: "MAC", Operator: "In", Values: []string{"52-DA-00-89-D8-10"}}, &MatchExpression{Key: "MAC", Operator: OperatorIn, Values: []string{"52:da:00:89:d8:10"}}, true},
+		{&MatchExpression{Key: "serial", Operator: "EXISTS"}, &MatchExpression{Key: "serial", Operator: OperatorExists}, true},
+		{&MatchExpression{Key: "mac", Operator: "In", Values: []string{"not-a-mac"}}, nil, false},
+		{&MatchExpression{Operator: "Exists"}, nil, false},
+		{&MatchExpression{Key: "os", Operator: "Equals", Values: []string{"flatcar"}}, nil, false},
+		{&MatchExpression{Key: "os", Operator: "In"}, nil, false},
+		{&MatchExpression{Key: "os", Operator: "Exists", Values: []string{"flatcar"}}, nil, false},
+		{&MatchExpression{Key: "hostname", Operator: "Regex", Values: []string{"a", "b"}}, nil, false},
+		{&MatchExpression{Key: "hostname", Operator: "Regex", Values: []string{"("}}, nil, false},
+		{&MatchExpression{Key: "ip", Operator: "CIDR", Values: []string{"10.1.0.0"}}, nil, false},
+	}
+	// assert that:
+	// - operators are spelled canonically and "mac" values are normalized
+	// - invalid expressions cause a normalization error
+	for _, c := range cases {
+		err := c.expr.Normalize()
+		assert.Equal(t, c.valid, err == nil, c.expr.requirement())
+		if c.valid {
+			assert.Equal(t, c.normalized, c.expr)
+		}
+	}
+}
+
+func TestGroupMatchesExpressions(t *testing.T) {
+	group := &Group{
+		Selector: map[string]string{"region": "a"},
+		MatchExpressions: []*MatchExpression{
+			{Key: "hostname", Operator: OperatorRegex, Values: []string{"^worker-"}},
+			{Key: "os", Operator: OperatorNotIn, Values: []string{"flatcar"}},
+		},
+	}
+	// assert that Group selectors and all match expressions must be satisfied
+	assert.True(t, group.Matches(map[string]string{"region": "a", "hostname": "worker-1"}))
+	assert.False(t, group.Matches(map[string]string{"region": "b", "hostname": "worker-1"}))
+	assert.False(t, group.Matches(map[string]string{"region": "a", "hostname": "controller-1"}))
+	assert.False(t, group.Matches(map[string]string{"region": "a", "hostname": "worker-1", "os": "flatcar"}))
+}
+
+func TestGroupParseExpressions(t *testing.T) {
+	group, err := ParseGroup([]byte(`{"id":"workers","profile":"worker","match_expressions":[{"key":"mac","operator":"in","values":["52-DA-00-89-D8-10"]}]}`))
+	assert.Nil(t, err)
+	expected := []*MatchExpression{{Key: "mac", Operator: OperatorIn, Values: []string{"52:da:00:89:d8:10"}}}
+	assert.Equal(t, expected, group.MatchExpressions)
+
+	_, err = ParseGroup([]byte(`{"id":"workers","profile":"worker","match_expressions":[{"key":"os","operator":"equals"}]}`))
+	assert.Error(t, err)
+}
+
+func TestGroupSortExpressions(t *testing.T) {
+	selector := &Group{
+		Name:     "group with one selector",
+		Selector: map[string]string{"region": "a"},
+	}
+	expression := &Group{
+		Name:             "group with one expression",
+		MatchExpressions: []*MatchExpression{{Key: "region", Operator: OperatorExists}},
+	}
+	selectorAndExpression := &Group{
+		Name:             "group with a selector and an expression",
+		Selector:         map[string]string{"region": "a"},
+		MatchExpressions: []*MatchExpression{{Key: "zone", Operator: OperatorExists}},
+	}
+	twoSelectors := &Group{
+		Name:     "group with two selectors",
+		Selector: map[string]string{"region": "a", "zone": "z"},
+	}
+	groups := []*Group{twoSelectors, selector, selectorAndExpression, expression}
+	sort.Sort(ByReqs(groups))
+	// assert that:
+	// - Groups are sorted by increasing number of requirements
+	// - when equal, Groups with fewer exact selectors sort first
+	assert.Equal(t, []*Group{expression, selector, selectorAndExpression, twoSelectors}, groups)
+}
+
+func TestGroupAmbiguous(t *testing.T) {
+	group := &Group{Selector: map[string]string{"region": "a"}}
+	cases := []struct {
+		other     *Group
+		ambiguous bool
+	}{
+		{&Group{Selector: map[string]string{"zone": "z"}}, true},
+		{&Group{Selector: map[string]string{"region": "b"}}, false},
+		{&Group{Selector: map[string]string{"zone": "z"}, Priority: 1}, false},
+		{&Group{Selector: map[string]string{"region": "a", "zone": "z"}}, false},
+		{&Group{MatchExpressions: []*MatchExpression{{Key: "region", Operator: OperatorExists}}}, false},
+	}
+	// assert that Groups are ambiguous if they have the same priority, number
+	// of requirements, and number of selectors, and could match the same labels
+	for _, c := range cases {
+		assert.Equal(t, c.ambiguous, group.Ambiguous(c.other), c.other.selectorString())
+	}
+}
+
+func TestMatchExpressionDisjoint(t *testing.T) {
+	cases := []struct {
+		a, b     *MatchExpression
+		disjoint bool
+	}{
+		{&MatchExpression{Key: "os", Operator: OperatorIn, Values: []string{"a", "b"}}, &MatchExpression{Key: "os", Operator: OperatorIn, Values: []string{"b"}}, false},
+		{&MatchExpression{Key: "os", Operator: OperatorIn, Values: []string{"a"}}, &MatchExpression{Key: "os", Operator: OperatorIn, Values: []string{"b"}}, true},
+		{&MatchExpression{Key: "os", Operator: OperatorNotIn, Values: []string{"a"}}, &MatchExpression{Key: "os", Operator: OperatorIn, Values: []string{"a"}}, true},
+		{&MatchExpression{Key: "os", Operator: OperatorIn, Values: []string{"a"}}, &MatchExpression{Key: "os", Operator: OperatorExists}, false},
+		{&MatchExpression{Key: "os", Operator: OperatorIn, Values: []string{"a"}}, &MatchExpression{Key: "os", Operator: OperatorDoesNotExist}, true},
+		{&MatchExpression{Key: "os", Operator: OperatorNotIn, Values: []string{"a"}}, &MatchExpression{Key: "os", Operator: OperatorDoesNotExist}, false},
+		{&MatchExpression{Key: "os", Operator: OperatorExists}, &MatchExpression{Key: "os", Operator: OperatorDoesNotExist}, true},
+		{&MatchExpression{Key: "ip", Operator: OperatorIn, Values: []string{"10.1.2.3"}}, &MatchExpression{Key: "ip", Operator: OperatorCIDR, Values: []string{"10.2.0.0/16"}}, true},
+		{&MatchExpression{Key: "host", Operator: OperatorIn, Values: []string{"worker-1"}}, &MatchExpression{Key: "host", Operator: OperatorRegex, Values: []string{"^worker-"}}, false},
+		// disjoint patterns are not detected
+		{&MatchExpression{Key: "host", Operator: OperatorRegex, Values: []string{"^a"}}, &MatchExpression{Key: "host", Operator: OperatorRegex, Values: []string{"^b"}}, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.disjoint, c.a.disjoint(c.b), c.a.requirement()+" / "+c.b.requirement())
+		assert.Equal(t, c.disjoint, c.b.disjoint(c.a), c.b.requirement()+" / "+c.a.requirement())
+	}
+}
@@ -10,6 +10,9 @@ import (
 
 var (
 	ErrProfileRequired = errors.New("Group requires a Profile")
+	// ErrVersionConflict is returned by a conditional put when the stored
+	// resource does not have the expected resource version.
+	ErrVersionConflict = errors.New("storage: Resource version conflict")
 )
 
 // ParseGroup parses bytes into a Group.
@@ -34,29 +37,52 @@ func (g *Group) Copy() *Group {
 	for k, v := range g.Selector {
 		selectors[k] = v
 	}
+	var expressions []*MatchExpression
+	for _, expr := range g.MatchExpressions {
+		values := make([]string, len(expr.Values))
+		copy(values, expr.Values)
+		expressions = append(expressions, &MatchExpression{
+			Key:      expr.Key,
+			Operator: expr.Operator,
+			Values:   values,
+		})
+	}
 	return &Group{
-		Id:       g.Id,
-		Name:     g.Name,
-		Profile:  g.Profile,
-		Selector: selectors,
-		Metadata: g.Metadata,
+		Id:               g.Id,
+		Name:             g.Name,
+		Profile:          g.Profile,
+		Selector:         selectors,
+		MatchExpressions: expressions,
+		Metadata:         g.Metadata,
+		Priority:         g.Priority,
 	}
 }
 
 // Matches returns true if the given labels satisfy all the selector
-// requirements, false otherwise.
+// requirements and match expressions, false otherwise.
 func (g *Group) Matches(labels map[string]string) bool {
 	for key, val := range g.Selector {
 		if labels == nil || labels[key] != val {
 			return false
 		}
 	}
+	for _, expr := range g.MatchExpressions {
+		if !expr.Matches(labels) {
+			return false
+		}
+	}
 	return true
 }
 
 // Normalize normalizes Group selectors according to reserved selector rules
-// which require "mac" addresses to be valid, normalized MAC addresses.
+// which require "mac" addresses to be valid, normalized MAC addresses, and
+// validates and normalizes Group match expressions.
 func (g *Group) Normalize() error {
+	for _, expr := range g.MatchExpressions {
+		if err := expr.Normalize(); err != nil {
+			return err
+		}
+	}
 	for key, val := range g.Selector {
 		switch strings.ToLower(key) {
 		case "mac":
@@ -80,16 +106,57 @@ func (g *Group) AssertValid() error {
 	if g.Profile == "" {
 		return ErrProfileRequired
 	}
+	for _, expr := range g.MatchExpressions {
+		if err := expr.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// selectorString returns Group selectors as a string of sorted key value
-// pairs for comparisons.
+// Ambiguous returns true if the Groups have the same rank, that is the same
+// priority, number of requirements, and number of exact selectors, and could
+// both match the same labels. Which of them is selected then depends only on
+// their sorted requirement strings.
+func (g *Group) Ambiguous(other *Group) bool {
+	if g.Priority != other.Priority || g.requirements() != other.requirements() || len(g.Selector) != len(other.Selector) {
+		return false
+	}
+	for _, a := range g.expressions() {
+		for _, b := range other.expressions() {
+			if a.Key == b.Key && a.disjoint(b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// expressions returns the Group selectors as In match expressions, followed
+// by the Group match expressions.
+func (g *Group) expressions() []*MatchExpression {
+	exprs := make([]*MatchExpression, 0, g.requirements())
+	for key, value := range g.Selector {
+		exprs = append(exprs, &MatchExpression{Key: key, Operator: OperatorIn, Values: []string{value}})
+	}
+	return append(exprs, g.MatchExpressions...)
+}
+
+// requirements returns the number of Group selectors and match expressions.
+func (g *Group) requirements() int {
+	return len(g.Selector) + len(g.MatchExpressions)
+}
+
+// selectorString returns Group selectors and match expressions as a string of
+// sorted requirements for comparisons.
 func (g *Group) selectorString() string {
-	reqs := make([]string, 0, len(g.Selector))
+	reqs := make([]string, 0, g.requirements())
 	for key, value := range g.Selector {
 		reqs = append(reqs, key+"="+value)
 	}
+	for _, expr := range g.MatchExpressions {
+		reqs = append(reqs, expr.requirement())
+	}
 	// sort by "key=value" pairs for a deterministic ordering
 	sort.StringSlice(reqs).Sort()
 	return strings.Join(reqs, ",")
@@ -106,18 +173,23 @@ func (g *Group) ToRichGroup() (*RichGroup, error) {
 		}
 	}
 	return &RichGroup{
-		Id:       g.Id,
-		Name:     g.Name,
-		Profile:  g.Profile,
-		Selector: g.Selector,
-		Metadata: metadata,
+		Id:               g.Id,
+		Name:             g.Name,
+		Profile:          g.Profile,
+		Selector:         g.Selector,
+		MatchExpressions: g.MatchExpressions,
+		Metadata:         metadata,
+		Priority:         g.Priority,
 	}, nil
 }
 
 // ByReqs defines a collection of Group structs which have a deterministic
-// sorted order by increasing number of Requirements, then by sorted key/value
-// strings. For example, a Group with Requirements {a:b, c:d} should be ordered
-// after one with {a:b} and before one with {a:d, c:d}.
+// sorted order by increasing priority, then by increasing number of
+// Requirements, then by increasing number
+// of exact selectors, then by sorted requirement strings. For example, a Group
+// with Requirements {a:b, c:d} should be ordered after one with {a:b} and
+// before one with {a:d, c:d}. A Group with {a:b} and the expression "c exists"
+// should be ordered before one with {a:b, c:d}, which is more specific.
 type ByReqs []*Group
 
 func (groups ByReqs) Len() int {
@@ -129,10 +201,16 @@ func (groups ByReqs) Swap(i, j int) {
 }
 
 func (groups ByReqs) Less(i, j int) bool {
-	if len(groups[i].Selector) == len(groups[j].Selector) {
-		return groups[i].selectorString() < groups[j].selectorString()
+	if groups[i].Priority != groups[j].Priority {
+		return groups[i].Priority < groups[j].Priority
+	}
+	if groups[i].requirements() != groups[j].requirements() {
+		return groups[i].requirements() < groups[j].requirements()
+	}
+	if len(groups[i].Selector) != len(groups[j].Selector) {
+		return len(groups[i].Selector) < len(groups[j].Selector)
 	}
-	return len(groups[i].Selector) < len(groups[j].Selector)
+	return groups[i].selectorString() < groups[j].selectorString()
 }
 
 // RichGroup is a user provided Group definition.
@@ -145,8 +223,12 @@ type RichGroup struct {
 	Profile string `json:"profile,omitempty"`
 	// Selectors to match machines
 	Selector map[string]string `json:"selector,omitempty"`
+	// Match expressions which machines must also satisfy
+	MatchExpressions []*MatchExpression `json:"match_expressions,omitempty"`
 	// Metadata
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Priority over other Groups
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ToGroup converts a user provided RichGroup into a Group which can be
@@ -161,10 +243,12 @@ func (rg *RichGroup) ToGroup() (*Group, error) {
 		}
 	}
 	return &Group{
-		Id:       rg.Id,
-		Name:     rg.Name,
-		Profile:  rg.Profile,
-		Selector: rg.Selector,
-		Metadata: metadata,
+		Id:               rg.Id,
+		Name:             rg.Name,
+		Profile:          rg.Profile,
+		Selector:         rg.Selector,
+		MatchExpressions: rg.MatchExpressions,
+		Metadata:         metadata,
+		Priority:         rg.Priority,
 	}, nil
 }
@@ -159,8 +159,17 @@ func TestGroupSort(t *testing.T) {
 		{[]*Group{twoConditions, dualConditions, oneCondition}, []*Group{oneCondition, twoConditions, dualConditions}},
 		{[]*Group{testGroup, testGroupWithoutProfile, oneCondition, twoConditions, dualConditions}, []*Group{oneCondition, testGroupWithoutProfile, testGroup, twoConditions, dualConditions}},
 	}
+	prioritized := &Group{
+		Name:     "group with a priority",
+		Priority: 1,
+	}
+	cases = append(cases, struct {
+		input    []*Group
+		expected []*Group
+	}{[]*Group{prioritized, twoConditions, oneCondition}, []*Group{oneCondition, twoConditions, prioritized}})
 	// assert that
 	// - Group ordering is deterministic
+	// - Groups are sorted by increasing priority
 	// - Groups are sorted by increasing Selector length
 	// - when Selectors are equal in length, sort by key=value strings.
 	for _, c := range cases {
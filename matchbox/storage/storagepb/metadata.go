@@ -0,0 +1,46 @@
+package storagepb
+
+import (
+	"encoding/json"
+
+	"github.com/coreos/matchbox/matchbox/secret"
+)
+
+// MergeMetadata returns JSON encoded metadata objects deep merged in order,
+// so later metadata takes precedence, or nil if all of them are nil. Objects
+// are merged member by member, while other values, including arrays and
+// secret values, of later metadata replace those of earlier metadata.
+func MergeMetadata(metadata ...[]byte) ([]byte, error) {
+	var merged map[string]interface{}
+	for _, data := range metadata {
+		if data == nil {
+			continue
+		}
+		object := make(map[string]interface{})
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+		if merged == nil {
+			merged = object
+			continue
+		}
+		mergeObjects(merged, object)
+	}
+	if merged == nil {
+		return nil, nil
+	}
+	return json.Marshal(merged)
+}
+
+// mergeObjects deep merges the members of the src object into dst.
+func mergeObjects(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcObject, ok := value.(map[string]interface{})
+		dstObject, dstOk := dst[key].(map[string]interface{})
+		if ok && dstOk && !secret.IsValue(srcObject) && !secret.IsValue(dstObject) {
+			mergeObjects(dstObject, srcObject)
+			continue
+		}
+		dst[key] = value
+	}
+}
@@ -3,17 +3,37 @@ package storagepb
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/container-linux-config-transpiler/config/platform"
+)
+
+// Profile Ignition template formats
+const (
+	IgnitionFormatContainerLinuxConfig = "container-linux-config"
+	IgnitionFormatButane               = "butane"
 )
 
+// IgnitionVersions are the Ignition spec versions which Ignition configs can
+// be served as: 2.1.0 for Container Linux Configs and raw spec 2.x configs,
+// and 3.0.0 through 3.5.0 for Butane and raw spec 3.x configs.
+var IgnitionVersions = []string{"2.1.0", "3.0.0", "3.1.0", "3.2.0", "3.3.0", "3.4.0", "3.5.0"}
+
 var (
-	ErrIdRequired = errors.New("Id is required")
+	ErrIdRequired              = errors.New("Id is required")
+	ErrInvalidIgnitionFormat   = errors.New("Ignition format must be container-linux-config or butane")
+	ErrInvalidIgnitionVersion  = fmt.Errorf("Ignition version must be one of %s", strings.Join(IgnitionVersions, ", "))
+	ErrInvalidIgnitionPlatform = errors.New("Ignition platform is not a Container Linux Config platform")
 )
 
 // ParseProfile parses bytes into a Profile.
 func ParseProfile(data []byte) (*Profile, error) {
-	profile := new(Profile)
-	err := json.Unmarshal(data, profile)
-	return profile, err
+	richProfile := new(RichProfile)
+	if err := json.Unmarshal(data, richProfile); err != nil {
+		return nil, err
+	}
+	return richProfile.ToProfile()
 }
 
 // AssertValid validates a Profile. Returns nil if there are no validation
@@ -23,21 +43,220 @@ func (p *Profile) AssertValid() error {
 	if p.Id == "" {
 		return ErrIdRequired
 	}
+	switch p.IgnitionFormat {
+	case "", IgnitionFormatContainerLinuxConfig, IgnitionFormatButane:
+	default:
+		return ErrInvalidIgnitionFormat
+	}
+	if p.IgnitionVersion != "" && !containsValue(IgnitionVersions, p.IgnitionVersion) {
+		return ErrInvalidIgnitionVersion
+	}
+	if !platform.IsSupportedPlatform(p.IgnitionPlatform) {
+		return ErrInvalidIgnitionPlatform
+	}
 	return nil
 }
 
 func (p *Profile) Copy() *Profile {
 	return &Profile{
-		Id:         p.Id,
-		Name:       p.Name,
-		IgnitionId: p.IgnitionId,
-		CloudId:    p.CloudId,
-		GenericId:  p.GenericId,
-		Boot:       p.Boot.Copy(),
+		Id:               p.Id,
+		Name:             p.Name,
+		IgnitionId:       p.IgnitionId,
+		IgnitionIds:      append([]string(nil), p.IgnitionIds...),
+		IgnitionFormat:   p.IgnitionFormat,
+		IgnitionVersion:  p.IgnitionVersion,
+		IgnitionPlatform: p.IgnitionPlatform,
+		CloudId:          p.CloudId,
+		GenericId:        p.GenericId,
+		Boot:             p.Boot.Copy(),
+		Parent:           p.Parent,
+		Metadata:         p.Metadata,
+	}
+}
+
+// IgnitionTemplates returns the names of the Ignition templates whose configs
+// are merged, in order, into a machine's Ignition config: the ignition id, if
+// set, followed by the ignition ids.
+func (p *Profile) IgnitionTemplates() []string {
+	var names []string
+	if p.IgnitionId != "" {
+		names = append(names, p.IgnitionId)
 	}
+	for _, name := range p.IgnitionIds {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Inherit returns a copy of the Profile with the template ids and boot
+// settings it does not set inherited from the parent Profile. Kernel args
+// are the parent's args, except those whose name (the part before any "=")
+// the Profile sets too, followed by the Profile's args. Ignition ids are
+// likewise the parent's ignition ids the Profile does not list, followed by
+// the Profile's. Metadata is the parent's metadata deep merged with the
+// Profile's.
+func (p *Profile) Inherit(parent *Profile) (*Profile, error) {
+	metadata, err := MergeMetadata(parent.Metadata, p.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	profile := p.Copy()
+	profile.Metadata = metadata
+	profile.ResourceVersion = p.ResourceVersion
+	profile.Namespace = p.Namespace
+	if profile.IgnitionId == "" {
+		profile.IgnitionId = parent.IgnitionId
+	}
+	profile.IgnitionIds = inheritIgnitionIds(parent.IgnitionIds, profile.IgnitionIds)
+	if profile.IgnitionFormat == "" {
+		profile.IgnitionFormat = parent.IgnitionFormat
+	}
+	if profile.IgnitionVersion == "" {
+		profile.IgnitionVersion = parent.IgnitionVersion
+	}
+	if profile.IgnitionPlatform == "" {
+		profile.IgnitionPlatform = parent.IgnitionPlatform
+	}
+	if profile.CloudId == "" {
+		profile.CloudId = parent.CloudId
+	}
+	if profile.GenericId == "" {
+		profile.GenericId = parent.GenericId
+	}
+	if parent.Boot == nil {
+		return profile, nil
+	}
+	if profile.Boot == nil {
+		profile.Boot = parent.Boot.Copy()
+		return profile, nil
+	}
+	if profile.Boot.Kernel == "" {
+		profile.Boot.Kernel = parent.Boot.Kernel
+	}
+	if len(profile.Boot.Initrd) == 0 {
+		profile.Boot.Initrd = append(profile.Boot.Initrd, parent.Boot.Initrd...)
+	}
+	overridden := make(map[string]bool)
+	for _, arg := range profile.Boot.Args {
+		overridden[argName(arg)] = true
+	}
+	var args []string
+	for _, arg := range parent.Boot.Args {
+		if !overridden[argName(arg)] {
+			args = append(args, arg)
+		}
+	}
+	profile.Boot.Args = append(args, profile.Boot.Args...)
+	return profile, nil
+}
+
+// ToRichProfile converts a Profile into a RichProfile suitable for writing
+// and user manipulation.
+func (p *Profile) ToRichProfile() (*RichProfile, error) {
+	var metadata map[string]interface{}
+	if p.Metadata != nil {
+		if err := json.Unmarshal(p.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+	}
+	return &RichProfile{
+		Id:               p.Id,
+		Name:             p.Name,
+		IgnitionId:       p.IgnitionId,
+		IgnitionIds:      p.IgnitionIds,
+		IgnitionFormat:   p.IgnitionFormat,
+		IgnitionVersion:  p.IgnitionVersion,
+		IgnitionPlatform: p.IgnitionPlatform,
+		CloudId:          p.CloudId,
+		Boot:             p.Boot,
+		GenericId:        p.GenericId,
+		Parent:           p.Parent,
+		Metadata:         metadata,
+	}, nil
+}
+
+// RichProfile is a user provided Profile definition.
+type RichProfile struct {
+	// machine readable Id
+	Id string `json:"id,omitempty"`
+	// Human readable name
+	Name string `json:"name,omitempty"`
+	// Ignition template name
+	IgnitionId string `json:"ignition_id,omitempty"`
+	// Ignition template names of fragments merged after the ignition id
+	IgnitionIds []string `json:"ignition_ids,omitempty"`
+	// format of Ignition templates, container-linux-config or butane
+	IgnitionFormat string `json:"ignition_format,omitempty"`
+	// Ignition spec version of served Ignition configs
+	IgnitionVersion string `json:"ignition_version,omitempty"`
+	// platform of Container Linux Config dynamic data
+	IgnitionPlatform string `json:"ignition_platform,omitempty"`
+	// Cloud-Config template name
+	CloudId string `json:"cloud_id,omitempty"`
+	// network boot settings
+	Boot *NetBoot `json:"boot,omitempty"`
+	// generic template name
+	GenericId string `json:"generic_id,omitempty"`
+	// parent Profile id
+	Parent string `json:"parent,omitempty"`
+	// default metadata of Groups
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ToProfile converts a user provided RichProfile into a Profile which can be
+// serialized as a protocol buffer.
+func (rp *RichProfile) ToProfile() (*Profile, error) {
+	var metadata []byte
+	if rp.Metadata != nil {
+		var err error
+		if metadata, err = json.Marshal(rp.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	return &Profile{
+		Id:               rp.Id,
+		Name:             rp.Name,
+		IgnitionId:       rp.IgnitionId,
+		IgnitionIds:      rp.IgnitionIds,
+		IgnitionFormat:   rp.IgnitionFormat,
+		IgnitionVersion:  rp.IgnitionVersion,
+		IgnitionPlatform: rp.IgnitionPlatform,
+		CloudId:          rp.CloudId,
+		Boot:             rp.Boot,
+		GenericId:        rp.GenericId,
+		Parent:           rp.Parent,
+		Metadata:         metadata,
+	}, nil
+}
+
+// inheritIgnitionIds returns the parent's ignition ids which are not in ids,
+// followed by ids.
+func inheritIgnitionIds(parent, ids []string) []string {
+	listed := make(map[string]bool)
+	for _, id := range ids {
+		listed[id] = true
+	}
+	var inherited []string
+	for _, id := range parent {
+		if !listed[id] {
+			inherited = append(inherited, id)
+		}
+	}
+	return append(inherited, ids...)
+}
+
+// argName returns the name of a kernel arg, such as "console" for
+// "console=ttyS0".
+func argName(arg string) string {
+	return strings.SplitN(arg, "=", 2)[0]
 }
 
 func (b *NetBoot) Copy() *NetBoot {
+	if b == nil {
+		return nil
+	}
 	initrd := make([]string, len(b.Initrd))
 	copy(initrd, b.Initrd)
 	args := make([]string, len(b.Args))
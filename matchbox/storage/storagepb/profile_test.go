@@ -1,6 +1,7 @@
 package storagepb
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,6 +21,7 @@ func TestProfileParse(t *testing.T) {
 		profile *Profile
 	}{
 		{`{"id": "id", "cloud_id": "cloud.yaml", "ignition_id": "ignition.json"}`, testProfile},
+		{`{"id": "id", "ignition_id": "fcos.yaml", "ignition_format": "butane"}`, &Profile{Id: "id", IgnitionId: "fcos.yaml", IgnitionFormat: IgnitionFormatButane}},
 	}
 	for _, c := range cases {
 		profile, _ := ParseProfile([]byte(c.json))
@@ -34,6 +36,17 @@ func TestProfileValidate(t *testing.T) {
 	}{
 		{testProfile, true},
 		{&Profile{Id: "a1b2c3d4"}, true},
+		{&Profile{Id: "a1b2c3d4", IgnitionFormat: IgnitionFormatButane}, true},
+		{&Profile{Id: "a1b2c3d4", IgnitionFormat: IgnitionFormatContainerLinuxConfig}, true},
+		{&Profile{Id: "a1b2c3d4", IgnitionFormat: "fcct"}, false},
+		{&Profile{Id: "a1b2c3d4", IgnitionVersion: "3.4.0"}, true},
+		{&Profile{Id: "a1b2c3d4", IgnitionVersion: "3.4"}, false},
+		{&Profile{Id: "a1b2c3d4", IgnitionVersion: "2.1.0"}, true},
+		{&Profile{Id: "a1b2c3d4", IgnitionVersion: "2.0.0"}, false},
+		{&Profile{Id: "a1b2c3d4", IgnitionVersion: "2.2.0"}, false},
+		{&Profile{Id: "a1b2c3d4", IgnitionVersion: "9.0.0"}, false},
+		{&Profile{Id: "a1b2c3d4", IgnitionPlatform: "ec2"}, true},
+		{&Profile{Id: "a1b2c3d4", IgnitionPlatform: "aws"}, false},
 		{&Profile{}, false},
 	}
 	for _, c := range cases {
@@ -92,3 +105,101 @@ func TestNetBootCopy(t *testing.T) {
 	assert.NotEqual(t, boot.Initrd, clone.Initrd)
 	assert.NotEqual(t, boot.Args, clone.Args)
 }
+
+func TestProfileInherit(t *testing.T) {
+	parent := &Profile{
+		Id:               "base",
+		IgnitionId:       "base.yaml",
+		IgnitionFormat:   IgnitionFormatButane,
+		IgnitionVersion:  "3.4.0",
+		IgnitionPlatform: "ec2",
+		CloudId:          "base.cloud",
+		Boot: &NetBoot{
+			Kernel: "/image/kernel",
+			Initrd: []string{"/image/initrd"},
+			Args:   []string{"console=tty0", "console=ttyS0", "coreos.autologin", "root=/dev/sda"},
+		},
+	}
+	child := &Profile{
+		Id:         "worker",
+		Parent:     "base",
+		IgnitionId: "worker.yaml",
+		Boot: &NetBoot{
+			Args: []string{"console=ttyS1", "worker=true"},
+		},
+	}
+	// assert that:
+	// - unset template ids, Ignition settings, and boot settings are
+	//   inherited
+	// - args replace parent args of the same name and are appended
+	// - the Profiles are not modified
+	resolved, err := child.Inherit(parent)
+	assert.Nil(t, err)
+	assert.Equal(t, "worker", resolved.Id)
+	assert.Equal(t, "base", resolved.Parent)
+	assert.Equal(t, "worker.yaml", resolved.IgnitionId)
+	assert.Equal(t, "base.cloud", resolved.CloudId)
+	assert.Equal(t, IgnitionFormatButane, resolved.IgnitionFormat)
+	assert.Equal(t, "3.4.0", resolved.IgnitionVersion)
+	assert.Equal(t, "ec2", resolved.IgnitionPlatform)
+	assert.Equal(t, "/image/kernel", resolved.Boot.Kernel)
+	assert.Equal(t, []string{"/image/initrd"}, resolved.Boot.Initrd)
+	assert.Equal(t, []string{"coreos.autologin", "root=/dev/sda", "console=ttyS1", "worker=true"}, resolved.Boot.Args)
+	assert.Equal(t, []string{"console=ttyS1", "worker=true"}, child.Boot.Args)
+	assert.Equal(t, "", child.Boot.Kernel)
+
+	// assert that a Profile without boot settings inherits the parent's
+	resolved, err = (&Profile{Id: "worker", Parent: "base"}).Inherit(parent)
+	assert.Nil(t, err)
+	assert.Equal(t, parent.Boot, resolved.Boot)
+	resolved.Boot.Args[0] = "changed"
+	assert.Equal(t, "console=tty0", parent.Boot.Args[0])
+}
+
+func TestProfileIgnitionIds(t *testing.T) {
+	profile, err := ParseProfile([]byte(`{"id":"worker","ignition_id":"base.yaml","ignition_ids":["worker.yaml","site.ign"]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"worker.yaml", "site.ign"}, profile.IgnitionIds)
+	assert.Equal(t, []string{"base.yaml", "worker.yaml", "site.ign"}, profile.IgnitionTemplates())
+	assert.Equal(t, []string{"site.ign"}, (&Profile{IgnitionIds: []string{"", "site.ign"}}).IgnitionTemplates())
+	assert.Nil(t, (&Profile{}).IgnitionTemplates())
+
+	// assert that copies do not share ignition ids
+	clone := profile.Copy()
+	clone.IgnitionIds[0] = "changed.yaml"
+	assert.Equal(t, "worker.yaml", profile.IgnitionIds[0])
+
+	// assert that a Profile's ignition ids follow the parent's ignition ids
+	// it does not list
+	parent := &Profile{Id: "site", IgnitionId: "base.yaml", IgnitionIds: []string{"site.ign", "proxy.yaml"}}
+	child := &Profile{Id: "worker", Parent: "site", IgnitionIds: []string{"worker.yaml", "proxy.yaml"}}
+	resolved, err := child.Inherit(parent)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"base.yaml", "site.ign", "worker.yaml", "proxy.yaml"}, resolved.IgnitionTemplates())
+	assert.Equal(t, []string{"worker.yaml", "proxy.yaml"}, child.IgnitionIds)
+}
+
+func TestProfileInheritMetadata(t *testing.T) {
+	parent := &Profile{Id: "base", Metadata: []byte(`{"dns_service_ip":"10.3.0.10","image":{"name":"etcd","version":"3.2"}}`)}
+	child := &Profile{Id: "worker", Parent: "base", Metadata: []byte(`{"image":{"version":"3.3"}}`)}
+	// assert that the parent's metadata is deep merged under the Profile's
+	resolved, err := child.Inherit(parent)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"dns_service_ip":"10.3.0.10","image":{"name":"etcd","version":"3.3"}}`, string(resolved.Metadata))
+
+	_, err = child.Inherit(&Profile{Id: "base", Metadata: []byte(`not json`)})
+	assert.Error(t, err)
+}
+
+func TestProfileParseMetadata(t *testing.T) {
+	data := []byte(`{"id":"etcd","ignition_id":"etcd.yaml","metadata":{"etcd_version":"3.3"}}`)
+	// assert that metadata is parsed from and written as an object
+	profile, err := ParseProfile(data)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"etcd_version":"3.3"}`, string(profile.Metadata))
+	richProfile, err := profile.ToRichProfile()
+	assert.Nil(t, err)
+	encoded, err := json.Marshal(richProfile)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(data), string(encoded))
+}
@@ -10,8 +10,12 @@ It is generated from these files:
 
 It has these top-level messages:
 	Group
+	MatchExpression
 	Profile
 	NetBoot
+	Event
+	Revision
+	Problem
 */
 package storagepb
 
@@ -30,6 +34,27 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type Event_Type int32
+
+const (
+	Event_PUT    Event_Type = 0
+	Event_DELETE Event_Type = 1
+)
+
+var Event_Type_name = map[int32]string{
+	0: "PUT",
+	1: "DELETE",
+}
+var Event_Type_value = map[string]int32{
+	"PUT":    0,
+	"DELETE": 1,
+}
+
+func (x Event_Type) String() string {
+	return proto.EnumName(Event_Type_name, int32(x))
+}
+func (Event_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4, 0} }
+
 // Group selects one or more machines and matches them to a Profile.
 type Group struct {
 	// machine readable Id
@@ -42,6 +67,16 @@ type Group struct {
 	Selector map[string]string `protobuf:"bytes,4,rep,name=selector" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// JSON encoded metadata
 	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// version of the stored Group, increased on every write
+	ResourceVersion int64 `protobuf:"varint,6,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// namespace of the stored Group, empty for the default namespace (output
+	// only)
+	Namespace string `protobuf:"bytes,7,opt,name=namespace" json:"namespace,omitempty"`
+	// expressions machines must satisfy in addition to the selectors
+	MatchExpressions []*MatchExpression `protobuf:"bytes,8,rep,name=match_expressions,json=matchExpressions" json:"match_expressions,omitempty"`
+	// Groups with a higher priority are selected before Groups with a lower
+	// priority, regardless of their requirements
+	Priority int32 `protobuf:"varint,9,opt,name=priority" json:"priority,omitempty"`
 }
 
 func (m *Group) Reset()                    { *m = Group{} }
@@ -84,6 +119,71 @@ func (m *Group) GetMetadata() []byte {
 	return nil
 }
 
+func (m *Group) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *Group) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Group) GetMatchExpressions() []*MatchExpression {
+	if m != nil {
+		return m.MatchExpressions
+	}
+	return nil
+}
+
+func (m *Group) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+// MatchExpression is a requirement on a machine label, such as a set of
+// allowed values or a pattern.
+type MatchExpression struct {
+	// label key
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// In, NotIn, Exists, DoesNotExist, Regex, or CIDR
+	Operator string `protobuf:"bytes,2,opt,name=operator" json:"operator,omitempty"`
+	// values of In and NotIn, a regular expression for Regex, or CIDR blocks
+	Values []string `protobuf:"bytes,3,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *MatchExpression) Reset()                    { *m = MatchExpression{} }
+func (m *MatchExpression) String() string            { return proto.CompactTextString(m) }
+func (*MatchExpression) ProtoMessage()               {}
+func (*MatchExpression) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *MatchExpression) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *MatchExpression) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *MatchExpression) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // Profile defines the boot and provisioning behavior of a group of machines.
 type Profile struct {
 	// profile id
@@ -98,12 +198,33 @@ type Profile struct {
 	Boot *NetBoot `protobuf:"bytes,5,opt,name=boot" json:"boot,omitempty"`
 	// generic config id
 	GenericId string `protobuf:"bytes,6,opt,name=generic_id,json=genericId" json:"generic_id,omitempty"`
+	// version of the stored Profile, increased on every write
+	ResourceVersion int64 `protobuf:"varint,7,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// namespace of the stored Profile, empty for the default namespace (output
+	// only)
+	Namespace string `protobuf:"bytes,8,opt,name=namespace" json:"namespace,omitempty"`
+	// id of the parent Profile whose boot settings and template ids this
+	// Profile inherits and overrides
+	Parent string `protobuf:"bytes,9,opt,name=parent" json:"parent,omitempty"`
+	// JSON encoded default metadata of the Groups using the Profile
+	Metadata []byte `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// ignition ids of config fragments merged, in order, after the ignition id
+	IgnitionIds []string `protobuf:"bytes,11,rep,name=ignition_ids,json=ignitionIds" json:"ignition_ids,omitempty"`
+	// format of ignition templates other than raw Ignition (.ign, .ignition)
+	// and Butane (.bu, .butane) files: container-linux-config (default) or
+	// butane
+	IgnitionFormat string `protobuf:"bytes,12,opt,name=ignition_format,json=ignitionFormat" json:"ignition_format,omitempty"`
+	// Ignition spec version of served Ignition configs, by default that of the
+	// ignition templates
+	IgnitionVersion string `protobuf:"bytes,13,opt,name=ignition_version,json=ignitionVersion" json:"ignition_version,omitempty"`
+	// platform of Container Linux Config dynamic data, such as ec2 or gce
+	IgnitionPlatform string `protobuf:"bytes,14,opt,name=ignition_platform,json=ignitionPlatform" json:"ignition_platform,omitempty"`
 }
 
 func (m *Profile) Reset()                    { *m = Profile{} }
 func (m *Profile) String() string            { return proto.CompactTextString(m) }
 func (*Profile) ProtoMessage()               {}
-func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *Profile) GetId() string {
 	if m != nil {
@@ -147,6 +268,62 @@ func (m *Profile) GetGenericId() string {
 	return ""
 }
 
+func (m *Profile) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *Profile) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Profile) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *Profile) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Profile) GetIgnitionIds() []string {
+	if m != nil {
+		return m.IgnitionIds
+	}
+	return nil
+}
+
+func (m *Profile) GetIgnitionFormat() string {
+	if m != nil {
+		return m.IgnitionFormat
+	}
+	return ""
+}
+
+func (m *Profile) GetIgnitionVersion() string {
+	if m != nil {
+		return m.IgnitionVersion
+	}
+	return ""
+}
+
+func (m *Profile) GetIgnitionPlatform() string {
+	if m != nil {
+		return m.IgnitionPlatform
+	}
+	return ""
+}
+
 // NetBoot describes network or PXE boot settings for a machine.
 type NetBoot struct {
 	// the URL of the kernel image
@@ -160,7 +337,7 @@ type NetBoot struct {
 func (m *NetBoot) Reset()                    { *m = NetBoot{} }
 func (m *NetBoot) String() string            { return proto.CompactTextString(m) }
 func (*NetBoot) ProtoMessage()               {}
-func (*NetBoot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*NetBoot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *NetBoot) GetKernel() string {
 	if m != nil {
@@ -183,35 +360,266 @@ func (m *NetBoot) GetArgs() []string {
 	return nil
 }
 
+// Event describes a change to a stored resource.
+type Event struct {
+	// put or delete
+	Type Event_Type `protobuf:"varint,1,opt,name=type,enum=storagepb.Event.Type" json:"type,omitempty"`
+	// resource kind (groups, profiles, ignition, generic, cloud, or partials)
+	Kind string `protobuf:"bytes,2,opt,name=kind" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// store revision of the change
+	Revision int64 `protobuf:"varint,4,opt,name=revision" json:"revision,omitempty"`
+	// Group, if kind is groups
+	Group *Group `protobuf:"bytes,5,opt,name=group" json:"group,omitempty"`
+	// Profile, if kind is profiles
+	Profile *Profile `protobuf:"bytes,6,opt,name=profile" json:"profile,omitempty"`
+	// template contents, if kind is ignition, generic, cloud, or partials
+	Template []byte `protobuf:"bytes,7,opt,name=template,proto3" json:"template,omitempty"`
+	// namespace of the resource, empty for the default namespace
+	Namespace string `protobuf:"bytes,8,opt,name=namespace" json:"namespace,omitempty"`
+	// epoch of an in-memory revision, which restarts at 1 with matchbox, so
+	// revisions only compare within an epoch; 0 if the store persists revisions
+	Epoch int64 `protobuf:"varint,9,opt,name=epoch" json:"epoch,omitempty"`
+}
+
+func (m *Event) Reset()                    { *m = Event{} }
+func (m *Event) String() string            { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()               {}
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Event) GetType() Event_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Event_PUT
+}
+
+func (m *Event) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Event) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Event) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Event) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *Event) GetProfile() *Profile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *Event) GetTemplate() []byte {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+func (m *Event) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Event) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+// Revision is a recorded write of a stored resource.
+type Revision struct {
+	// resource kind (groups, profiles, ignition, generic, cloud, or partials)
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// resource version written, 0 for deletions
+	ResourceVersion int64 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
+	// Unix time of the write in seconds
+	Time int64 `protobuf:"varint,4,opt,name=time" json:"time,omitempty"`
+	// author of the write, such as a client certificate common name
+	Author string `protobuf:"bytes,5,opt,name=author" json:"author,omitempty"`
+	// whether the resource was deleted
+	Deleted bool `protobuf:"varint,6,opt,name=deleted" json:"deleted,omitempty"`
+	// JSON encoded Group or Profile or template contents, unless deleted
+	Content []byte `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (m *Revision) Reset()                    { *m = Revision{} }
+func (m *Revision) String() string            { return proto.CompactTextString(m) }
+func (*Revision) ProtoMessage()               {}
+func (*Revision) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *Revision) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Revision) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Revision) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
+func (m *Revision) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Revision) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Revision) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *Revision) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+// Problem describes an invalid or inconsistent stored resource.
+type Problem struct {
+	// resource kind (groups, profiles, ignition, generic, cloud, or partials)
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// description of the problem
+	Message string `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *Problem) Reset()                    { *m = Problem{} }
+func (m *Problem) String() string            { return proto.CompactTextString(m) }
+func (*Problem) ProtoMessage()               {}
+func (*Problem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *Problem) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Problem) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Problem) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Group)(nil), "storagepb.Group")
+	proto.RegisterType((*MatchExpression)(nil), "storagepb.MatchExpression")
 	proto.RegisterType((*Profile)(nil), "storagepb.Profile")
 	proto.RegisterType((*NetBoot)(nil), "storagepb.NetBoot")
+	proto.RegisterType((*Event)(nil), "storagepb.Event")
+	proto.RegisterType((*Revision)(nil), "storagepb.Revision")
+	proto.RegisterType((*Problem)(nil), "storagepb.Problem")
+	proto.RegisterEnum("storagepb.Event.Type", Event_Type_name, Event_Type_value)
 }
 
 func init() { proto.RegisterFile("storage.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 334 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x91, 0x4f, 0x4b, 0xf3, 0x40,
-	0x10, 0xc6, 0xc9, 0x9f, 0x36, 0xc9, 0xf4, 0xed, 0x4b, 0x19, 0x44, 0xd6, 0x82, 0x1a, 0x7a, 0x90,
-	0x9e, 0x72, 0xa8, 0x17, 0xa9, 0x37, 0x41, 0xa4, 0x1e, 0x44, 0xe2, 0x55, 0x90, 0x6d, 0x76, 0x0c,
-	0x4b, 0xd3, 0xdd, 0xb0, 0xdd, 0x0a, 0xfd, 0x56, 0x7e, 0x1e, 0x3f, 0x8d, 0x64, 0xbb, 0x2d, 0x7a,
-	0xf3, 0x36, 0xbf, 0x67, 0x27, 0x33, 0xcf, 0x33, 0x81, 0xe1, 0xc6, 0x6a, 0xc3, 0x6b, 0x2a, 0x5a,
-	0xa3, 0xad, 0xc6, 0xcc, 0x63, 0xbb, 0x9c, 0x7c, 0x05, 0xd0, 0x7b, 0x30, 0x7a, 0xdb, 0xe2, 0x7f,
-	0x08, 0xa5, 0x60, 0x41, 0x1e, 0x4c, 0xb3, 0x32, 0x94, 0x02, 0x11, 0x62, 0xc5, 0xd7, 0xc4, 0x42,
-	0xa7, 0xb8, 0x1a, 0x19, 0x24, 0xad, 0xd1, 0xef, 0xb2, 0x21, 0x16, 0x39, 0xf9, 0x80, 0x38, 0x87,
-	0x74, 0x43, 0x0d, 0x55, 0x56, 0x1b, 0x16, 0xe7, 0xd1, 0x74, 0x30, 0xbb, 0x28, 0x8e, 0x5b, 0x0a,
-	0xb7, 0xa1, 0x78, 0xf1, 0x0d, 0xf7, 0xca, 0x9a, 0x5d, 0x79, 0xec, 0xc7, 0x31, 0xa4, 0x6b, 0xb2,
-	0x5c, 0x70, 0xcb, 0x59, 0x2f, 0x0f, 0xa6, 0xff, 0xca, 0x23, 0x8f, 0x6f, 0x61, 0xf8, 0xeb, 0x33,
-	0x1c, 0x41, 0xb4, 0xa2, 0x9d, 0xf7, 0xd9, 0x95, 0x78, 0x02, 0xbd, 0x0f, 0xde, 0x6c, 0x0f, 0x4e,
-	0xf7, 0x30, 0x0f, 0x6f, 0x82, 0xc9, 0x67, 0x00, 0xc9, 0xb3, 0x37, 0xf8, 0x97, 0x78, 0x97, 0x30,
-	0x90, 0xb5, 0x92, 0x56, 0x6a, 0xf5, 0x26, 0x85, 0x8f, 0x08, 0x07, 0x69, 0x21, 0xf0, 0x0c, 0xd2,
-	0xaa, 0xd1, 0x5b, 0xd1, 0xbd, 0xc6, 0xfb, 0x03, 0x38, 0x5e, 0x08, 0xbc, 0x82, 0x78, 0xa9, 0xb5,
-	0x75, 0x01, 0x06, 0x33, 0xfc, 0x11, 0xfe, 0x89, 0xec, 0x9d, 0xd6, 0xb6, 0x74, 0xef, 0x78, 0x0e,
-	0x50, 0x93, 0x22, 0x23, 0xab, 0x6e, 0x48, 0xdf, 0x0d, 0xc9, 0xbc, 0xb2, 0x10, 0x93, 0x57, 0x48,
-	0x7c, 0x3f, 0x9e, 0x42, 0x7f, 0x45, 0x46, 0x51, 0xe3, 0x5d, 0x7b, 0xea, 0x74, 0xa9, 0xa4, 0x35,
-	0x82, 0x85, 0x79, 0xd4, 0xe9, 0x7b, 0xea, 0x12, 0x71, 0x53, 0x6f, 0xdc, 0xf9, 0xb3, 0xd2, 0xd5,
-	0x8f, 0x71, 0x1a, 0x8d, 0xe2, 0x32, 0xa9, 0xd6, 0xa2, 0x91, 0x8a, 0x96, 0x7d, 0xf7, 0xff, 0xaf,
-	0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x10, 0x74, 0x65, 0xd8, 0x10, 0x02, 0x00, 0x00,
+	// 757 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x55, 0xcd, 0x6e, 0xe3, 0x36,
+	0x10, 0xae, 0x2c, 0xd9, 0x92, 0xc6, 0x8e, 0xe3, 0x10, 0x69, 0xc0, 0xba, 0x7f, 0xae, 0x0e, 0xa9,
+	0x83, 0x16, 0x3e, 0xa4, 0x97, 0x22, 0xbd, 0x15, 0x75, 0x83, 0xf4, 0x0f, 0x01, 0x9b, 0xb6, 0x97,
+	0x02, 0x81, 0x2c, 0x4d, 0x1c, 0x22, 0x92, 0x28, 0x50, 0xb4, 0xb1, 0x7e, 0x83, 0x7d, 0xa5, 0x3d,
+	0xec, 0x43, 0xec, 0x1b, 0x2d, 0x48, 0x51, 0xb2, 0xe3, 0x35, 0xb0, 0xb9, 0xf1, 0x1b, 0x8e, 0x86,
+	0x9c, 0xef, 0xfb, 0x38, 0x82, 0xa3, 0x4a, 0x09, 0x19, 0x2f, 0x71, 0x56, 0x4a, 0xa1, 0x04, 0x09,
+	0x2d, 0x2c, 0x17, 0xd1, 0x6b, 0x17, 0xba, 0xd7, 0x52, 0xac, 0x4a, 0x32, 0x84, 0x0e, 0x4f, 0xa9,
+	0x33, 0x71, 0xa6, 0x21, 0xeb, 0xf0, 0x94, 0x10, 0xf0, 0x8a, 0x38, 0x47, 0xda, 0x31, 0x11, 0xb3,
+	0x26, 0x14, 0xfc, 0x52, 0x8a, 0x07, 0x9e, 0x21, 0x75, 0x4d, 0xb8, 0x81, 0xe4, 0x0a, 0x82, 0x0a,
+	0x33, 0x4c, 0x94, 0x90, 0xd4, 0x9b, 0xb8, 0xd3, 0xfe, 0xe5, 0x57, 0xb3, 0xf6, 0x94, 0x99, 0x39,
+	0x61, 0xf6, 0xb7, 0x4d, 0x98, 0x17, 0x4a, 0x6e, 0x58, 0x9b, 0x4f, 0xc6, 0x10, 0xe4, 0xa8, 0xe2,
+	0x34, 0x56, 0x31, 0xed, 0x4e, 0x9c, 0xe9, 0x80, 0xb5, 0x98, 0x5c, 0xc0, 0x48, 0x62, 0x25, 0x56,
+	0x32, 0xc1, 0xfb, 0x35, 0xca, 0x8a, 0x8b, 0x82, 0xf6, 0x26, 0xce, 0xd4, 0x65, 0xc7, 0x4d, 0xfc,
+	0xdf, 0x3a, 0x4c, 0xbe, 0x80, 0x50, 0x5f, 0xb2, 0x2a, 0xe3, 0x04, 0xa9, 0x6f, 0xae, 0xb7, 0x0d,
+	0x90, 0x6b, 0x38, 0xc9, 0x63, 0x95, 0x3c, 0xde, 0xe3, 0xab, 0x52, 0x62, 0xa5, 0xbf, 0xa8, 0x68,
+	0x60, 0x6e, 0x3a, 0xde, 0xb9, 0xe9, 0x9f, 0x3a, 0x67, 0xde, 0xa6, 0xb0, 0x51, 0xfe, 0x3c, 0x50,
+	0xe9, 0xdb, 0x96, 0x92, 0x0b, 0xc9, 0xd5, 0x86, 0x86, 0x13, 0x67, 0xda, 0x65, 0x2d, 0x1e, 0xff,
+	0x04, 0x47, 0xcf, 0x9a, 0x24, 0x23, 0x70, 0x9f, 0x70, 0x63, 0x59, 0xd5, 0x4b, 0x72, 0x0a, 0xdd,
+	0x75, 0x9c, 0xad, 0x1a, 0x5e, 0x6b, 0x70, 0xd5, 0xf9, 0xd1, 0x89, 0xfe, 0x83, 0xe3, 0xbd, 0xd3,
+	0x0f, 0x7c, 0x3e, 0x86, 0x40, 0x94, 0x28, 0x63, 0xcd, 0x73, 0x5d, 0xa1, 0xc5, 0xe4, 0x0c, 0x7a,
+	0xa6, 0x5a, 0x45, 0xdd, 0x89, 0x3b, 0x0d, 0x99, 0x45, 0xd1, 0x3b, 0x17, 0xfc, 0x5b, 0xab, 0xd3,
+	0x4b, 0x54, 0xfe, 0x1a, 0xfa, 0x7c, 0x59, 0x70, 0xc5, 0x45, 0x71, 0xcf, 0x53, 0xab, 0x34, 0x34,
+	0xa1, 0x9b, 0x94, 0x7c, 0x06, 0x41, 0x92, 0x89, 0x55, 0xaa, 0x77, 0xbd, 0xda, 0x07, 0x06, 0xdf,
+	0xa4, 0xe4, 0x1c, 0xbc, 0x85, 0x10, 0xca, 0xe8, 0xd8, 0xbf, 0x24, 0x3b, 0xcc, 0xfe, 0x85, 0xea,
+	0x67, 0x21, 0x14, 0x33, 0xfb, 0xe4, 0x4b, 0x80, 0x25, 0x16, 0x28, 0x79, 0xa2, 0x8b, 0xf4, 0x6a,
+	0xb5, 0x6c, 0xe4, 0x26, 0x3d, 0x28, 0xbb, 0xff, 0x02, 0xd9, 0x83, 0x7d, 0xd9, 0xcf, 0xa0, 0x57,
+	0xc6, 0x12, 0x0b, 0x65, 0xb4, 0x0a, 0x99, 0x45, 0xcf, 0x3c, 0x07, 0x7b, 0x9e, 0xfb, 0x06, 0x06,
+	0x3b, 0xfd, 0x57, 0xb4, 0x6f, 0xd8, 0xec, 0x6f, 0x09, 0xa8, 0xc8, 0xb7, 0x70, 0xdc, 0xa6, 0x3c,
+	0x08, 0x99, 0xc7, 0x8a, 0x0e, 0x4c, 0xfd, 0x61, 0x13, 0xfe, 0xd5, 0x44, 0x75, 0x23, 0x6d, 0x62,
+	0xd3, 0xc8, 0x91, 0xc9, 0x6c, 0x0b, 0x34, 0x8d, 0x7c, 0x07, 0x27, 0x6d, 0x6a, 0x99, 0xc5, 0x4a,
+	0xd7, 0xa5, 0x43, 0x93, 0xdb, 0xd6, 0xb8, 0xb5, 0xf1, 0xe8, 0x7f, 0xf0, 0x2d, 0xa1, 0xba, 0xc5,
+	0x27, 0x94, 0x05, 0x66, 0x56, 0x56, 0x8b, 0x74, 0x9c, 0x17, 0x5c, 0xc9, 0x94, 0x76, 0x6a, 0x3b,
+	0xd4, 0x48, 0x4b, 0x1e, 0xcb, 0x65, 0x65, 0x9e, 0x69, 0xc8, 0xcc, 0xfa, 0x37, 0x2f, 0x70, 0x47,
+	0x1e, 0xf3, 0x93, 0x3c, 0xcd, 0x78, 0x81, 0xd1, 0x9b, 0x0e, 0x74, 0xe7, 0x6b, 0xcd, 0xd3, 0x05,
+	0x78, 0x6a, 0x53, 0xa2, 0x29, 0x3d, 0xbc, 0xfc, 0x74, 0x47, 0x4f, 0xb3, 0x3f, 0xbb, 0xdb, 0x94,
+	0xc8, 0x4c, 0x8a, 0xae, 0xfb, 0xc4, 0x8b, 0xb4, 0xb1, 0x92, 0x5e, 0xb7, 0xf6, 0x72, 0x77, 0xec,
+	0x35, 0x86, 0x40, 0xe2, 0x9a, 0x1b, 0x2a, 0x3c, 0xa3, 0x69, 0x8b, 0xc9, 0x39, 0x74, 0x97, 0x7a,
+	0x56, 0x58, 0xff, 0x8c, 0xf6, 0x67, 0x08, 0xab, 0xb7, 0xc9, 0xf7, 0xdb, 0x41, 0xd4, 0xfb, 0xc0,
+	0x69, 0xd6, 0xeb, 0xdb, 0xe1, 0x34, 0x86, 0x40, 0x61, 0xae, 0x39, 0xad, 0x07, 0xc3, 0x80, 0xb5,
+	0xf8, 0x23, 0xf6, 0x39, 0x85, 0x2e, 0x96, 0x22, 0x79, 0x34, 0xee, 0x71, 0x59, 0x0d, 0xa2, 0xcf,
+	0xc1, 0xd3, 0x7d, 0x13, 0x1f, 0xdc, 0xdb, 0x7f, 0xee, 0x46, 0x9f, 0x10, 0x80, 0xde, 0x2f, 0xf3,
+	0x3f, 0xe6, 0x77, 0xf3, 0x91, 0x13, 0xbd, 0x75, 0x20, 0x60, 0x4d, 0x3f, 0x0d, 0x27, 0xce, 0x01,
+	0x4e, 0x76, 0x9f, 0xdc, 0x21, 0xbf, 0xbb, 0x87, 0xfd, 0x4e, 0xc0, 0x53, 0x3c, 0x47, 0x4b, 0x9d,
+	0x59, 0x6b, 0xa9, 0xe3, 0x95, 0x7a, 0x14, 0xd2, 0xf0, 0x16, 0x32, 0x8b, 0xf4, 0xbc, 0x4e, 0x31,
+	0x43, 0x85, 0xf5, 0x13, 0x0b, 0x58, 0x03, 0xf5, 0x4e, 0x22, 0x0a, 0xa5, 0x1f, 0x46, 0xcd, 0x48,
+	0x03, 0xa3, 0xdf, 0xcd, 0xb0, 0x58, 0x64, 0x98, 0xbf, 0xf8, 0xf6, 0x14, 0xfc, 0x1c, 0xab, 0x2a,
+	0x5e, 0xb6, 0xbf, 0x05, 0x0b, 0x17, 0x3d, 0xf3, 0xc3, 0xf9, 0xe1, 0xfd, 0x00, 0x32, 0xf9, 0xda,
+	0xf2, 0x81, 0x06, 0x00, 0x00,
 }
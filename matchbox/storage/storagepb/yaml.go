@@ -0,0 +1,134 @@
+package storagepb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IsYAML returns true if a file name has a YAML extension (.yaml or .yml).
+func IsYAML(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// ParseGroupFile parses the contents of a group file into a Group, as YAML
+// if the file name has a YAML extension and as JSON otherwise.
+func ParseGroupFile(name string, data []byte) (*Group, error) {
+	data, err := fileJSON(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGroup(data)
+}
+
+// ParseProfileFile parses the contents of a profile file into a Profile, as
+// YAML if the file name has a YAML extension and as JSON otherwise.
+func ParseProfileFile(name string, data []byte) (*Profile, error) {
+	data, err := fileJSON(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProfile(data)
+}
+
+// fileJSON returns the JSON contents of a file which may be YAML.
+func fileJSON(name string, data []byte) ([]byte, error) {
+	if !IsYAML(name) {
+		return data, nil
+	}
+	return YAMLToJSON(data)
+}
+
+// YAMLToJSON converts a YAML document to JSON. Mapping keys which are not
+// strings, such as numbers, are formatted as strings.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonValue(value))
+}
+
+// JSONToYAML converts a JSON document to YAML, keeping the order of object
+// keys.
+func JSONToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeOrdered(dec)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(value)
+}
+
+// jsonValue returns a decoded YAML value with its mappings converted to maps
+// with string keys, which can be encoded as JSON.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprint(key)] = jsonValue(val)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, val := range v {
+			list[i] = jsonValue(val)
+		}
+		return list
+	}
+	return value
+}
+
+// decodeOrdered decodes the next JSON value of a decoder, with objects as
+// yaml.MapSlices so their keys keep their order.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '{' {
+			m := yaml.MapSlice{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeOrdered(dec)
+				if err != nil {
+					return nil, err
+				}
+				m = append(m, yaml.MapItem{Key: key, Value: value})
+			}
+			_, err := dec.Token()
+			return m, err
+		}
+		list := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := dec.Token()
+		return list, err
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	}
+	return token, nil
+}
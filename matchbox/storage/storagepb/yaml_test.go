@@ -0,0 +1,79 @@
+package storagepb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testGroupYAML = `id: node1
+name: test group
+profile: g1h2i3j4
+selector:
+  uuid: a1b2c3d4
+  mac: 52:DA:00:89:D8:10
+metadata:
+  some-key: some-val
+`
+
+func TestParseGroupFile(t *testing.T) {
+	// assert that YAML and JSON group files parse to the same Group
+	for _, name := range []string{"node1.yaml", "node1.yml"} {
+		group, err := ParseGroupFile(name, []byte(testGroupYAML))
+		assert.Nil(t, err)
+		assert.Equal(t, testGroup, group)
+	}
+	group, err := ParseGroupFile("node1.json", []byte(`{"id":"node1","name":"test group","profile":"g1h2i3j4","selector":{"uuid":"a1b2c3d4","mac":"52:da:00:89:d8:10"},"metadata":{"some-key":"some-val"}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, testGroup, group)
+	_, err = ParseGroupFile("node1.yaml", []byte("id: [node1"))
+	assert.Error(t, err)
+}
+
+func TestParseProfileFile(t *testing.T) {
+	profile, err := ParseProfileFile("p.yaml", []byte("id: p\nignition_id: p.yaml\nboot:\n  kernel: /vmlinuz\n  args: [a=b, c]\n"))
+	assert.Nil(t, err)
+	expected := &Profile{
+		Id:         "p",
+		IgnitionId: "p.yaml",
+		Boot:       &NetBoot{Kernel: "/vmlinuz", Args: []string{"a=b", "c"}},
+	}
+	assert.Equal(t, expected, profile)
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	// assert that mapping keys which are not strings become strings
+	data, err := YAMLToJSON([]byte("a:\n  1: one\n  true: [2, 3.5]\n"))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"a": {"1": "one", "true": [2, 3.5]}}`, string(data))
+}
+
+func TestJSONToYAML(t *testing.T) {
+	// assert that object keys keep their order and numbers their type
+	data, err := JSONToYAML([]byte(`{"id": "node1", "selector": {"uuid": "a1", "mac": "52:da:00:89:d8:10"}, "metadata": {"count": 3, "ratio": 0.5, "keys": ["ssh-rsa AAAA"], "empty": {}}}`))
+	assert.Nil(t, err)
+	expected := `id: node1
+selector:
+  uuid: a1
+  mac: 52:da:00:89:d8:10
+metadata:
+  count: 3
+  ratio: 0.5
+  keys:
+  - ssh-rsa AAAA
+  empty: {}
+`
+	assert.Equal(t, expected, string(data))
+
+	// assert that Groups round-trip through YAML
+	richGroup, err := testGroup.ToRichGroup()
+	assert.Nil(t, err)
+	data, err = json.Marshal(richGroup)
+	assert.Nil(t, err)
+	data, err = JSONToYAML(data)
+	assert.Nil(t, err)
+	group, err := ParseGroupFile("node1.yaml", data)
+	assert.Nil(t, err)
+	assert.Equal(t, testGroup, group)
+}
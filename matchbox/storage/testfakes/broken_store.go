@@ -1,6 +1,7 @@
 package testfakes
 
 import (
+	"context"
 	"errors"
 
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
@@ -14,8 +15,8 @@ var (
 type BrokenStore struct{}
 
 // GroupPut returns an error.
-func (s *BrokenStore) GroupPut(group *storagepb.Group) error {
-	return errIntentional
+func (s *BrokenStore) GroupPut(group *storagepb.Group) (int64, error) {
+	return 0, errIntentional
 }
 
 // GroupGet returns an error.
@@ -34,8 +35,8 @@ func (s *BrokenStore) GroupList() (groups []*storagepb.Group, err error) {
 }
 
 // ProfilePut returns an error.
-func (s *BrokenStore) ProfilePut(profile *storagepb.Profile) error {
-	return errIntentional
+func (s *BrokenStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	return 0, errIntentional
 }
 
 // ProfileGet returns an error.
@@ -54,8 +55,8 @@ func (s *BrokenStore) ProfileList() (profiles []*storagepb.Profile, err error) {
 }
 
 // IgnitionPut returns an error.
-func (s *BrokenStore) IgnitionPut(name string, config []byte) error {
-	return errIntentional
+func (s *BrokenStore) IgnitionPut(name string, config []byte, version int64) (int64, error) {
+	return 0, errIntentional
 }
 
 // IgnitionGet returns an error.
@@ -63,14 +64,24 @@ func (s *BrokenStore) IgnitionGet(name string) (string, error) {
 	return "", errIntentional
 }
 
+// IgnitionVersion returns an error.
+func (s *BrokenStore) IgnitionVersion(name string) (int64, error) {
+	return 0, errIntentional
+}
+
 // IgnitionDelete returns an error.
 func (s *BrokenStore) IgnitionDelete(name string) error {
 	return errIntentional
 }
 
+// IgnitionList returns an error.
+func (s *BrokenStore) IgnitionList() ([]string, error) {
+	return nil, errIntentional
+}
+
 // GenericPut returns an error.
-func (s *BrokenStore) GenericPut(name string, config []byte) error {
-	return errIntentional
+func (s *BrokenStore) GenericPut(name string, config []byte, version int64) (int64, error) {
+	return 0, errIntentional
 }
 
 // GenericGet returns an error.
@@ -78,12 +89,72 @@ func (s *BrokenStore) GenericGet(name string) (string, error) {
 	return "", errIntentional
 }
 
+// GenericVersion returns an error.
+func (s *BrokenStore) GenericVersion(name string) (int64, error) {
+	return 0, errIntentional
+}
+
 // GenericDelete returns an error.
 func (s *BrokenStore) GenericDelete(name string) error {
 	return errIntentional
 }
 
+// GenericList returns an error.
+func (s *BrokenStore) GenericList() ([]string, error) {
+	return nil, errIntentional
+}
+
+// CloudPut returns an error.
+func (s *BrokenStore) CloudPut(name string, config []byte, version int64) (int64, error) {
+	return 0, errIntentional
+}
+
 // CloudGet returns an error.
 func (s *BrokenStore) CloudGet(name string) (string, error) {
 	return "", errIntentional
 }
+
+// CloudVersion returns an error.
+func (s *BrokenStore) CloudVersion(name string) (int64, error) {
+	return 0, errIntentional
+}
+
+// CloudDelete returns an error.
+func (s *BrokenStore) CloudDelete(name string) error {
+	return errIntentional
+}
+
+// CloudList returns an error.
+func (s *BrokenStore) CloudList() ([]string, error) {
+	return nil, errIntentional
+}
+
+// PartialPut returns an error.
+func (s *BrokenStore) PartialPut(name string, config []byte, version int64) (int64, error) {
+	return 0, errIntentional
+}
+
+// PartialGet returns an error.
+func (s *BrokenStore) PartialGet(name string) (string, error) {
+	return "", errIntentional
+}
+
+// PartialVersion returns an error.
+func (s *BrokenStore) PartialVersion(name string) (int64, error) {
+	return 0, errIntentional
+}
+
+// PartialDelete returns an error.
+func (s *BrokenStore) PartialDelete(name string) error {
+	return errIntentional
+}
+
+// PartialList returns an error.
+func (s *BrokenStore) PartialList() ([]string, error) {
+	return nil, errIntentional
+}
+
+// Watch returns an error.
+func (s *BrokenStore) Watch(ctx context.Context) (<-chan *storagepb.Event, error) {
+	return nil, errIntentional
+}
@@ -1,6 +1,7 @@
 package testfakes
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
@@ -10,13 +11,13 @@ import (
 type EmptyStore struct{}
 
 // GroupPut returns an error writing any Group.
-func (s *EmptyStore) GroupPut(group *storagepb.Group) error {
-	return fmt.Errorf("emptyStore does not accept Groups")
+func (s *EmptyStore) GroupPut(group *storagepb.Group) (int64, error) {
+	return 0, fmt.Errorf("emptyStore does not accept Groups")
 }
 
 // GroupGet returns a group not found error.
 func (s *EmptyStore) GroupGet(id string) (*storagepb.Group, error) {
-	return nil, fmt.Errorf("Group not found")
+	return nil, notExist("groups", id)
 }
 
 // GroupDelete returns a nil error (successful deletion).
@@ -30,13 +31,13 @@ func (s *EmptyStore) GroupList() (groups []*storagepb.Group, err error) {
 }
 
 // ProfilePut returns an error writing any Profile.
-func (s *EmptyStore) ProfilePut(profile *storagepb.Profile) error {
-	return fmt.Errorf("emptyStore does not accept Profiles")
+func (s *EmptyStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	return 0, fmt.Errorf("emptyStore does not accept Profiles")
 }
 
 // ProfileGet returns a profile not found error.
 func (s *EmptyStore) ProfileGet(id string) (*storagepb.Profile, error) {
-	return nil, fmt.Errorf("Profile not found")
+	return nil, notExist("profiles", id)
 }
 
 // ProfileDelete returns a nil error (successful deletion).
@@ -50,13 +51,18 @@ func (s *EmptyStore) ProfileList() (profiles []*storagepb.Profile, err error) {
 }
 
 // IgnitionPut returns an error writing any Ignition template.
-func (s *EmptyStore) IgnitionPut(name string, config []byte) error {
-	return fmt.Errorf("emptyStore does not accept Ignition templates")
+func (s *EmptyStore) IgnitionPut(name string, config []byte, version int64) (int64, error) {
+	return 0, fmt.Errorf("emptyStore does not accept Ignition templates")
 }
 
 // IgnitionGet get returns an Ignition template not found error.
 func (s *EmptyStore) IgnitionGet(name string) (string, error) {
-	return "", fmt.Errorf("no Ignition template %s", name)
+	return "", notExist("ignition", name)
+}
+
+// IgnitionVersion returns an Ignition template not found error.
+func (s *EmptyStore) IgnitionVersion(name string) (int64, error) {
+	return 0, notExist("ignition", name)
 }
 
 // IgnitionDelete returns a nil error (successful deletion).
@@ -64,14 +70,24 @@ func (s *EmptyStore) IgnitionDelete(name string) error {
 	return nil
 }
 
+// IgnitionList returns an empty list of Ignition template names.
+func (s *EmptyStore) IgnitionList() (names []string, err error) {
+	return names, nil
+}
+
 // GenericPut returns an error writing any Generic template.
-func (s *EmptyStore) GenericPut(name string, config []byte) error {
-	return fmt.Errorf("emptyStore does not accept Generic templates")
+func (s *EmptyStore) GenericPut(name string, config []byte, version int64) (int64, error) {
+	return 0, fmt.Errorf("emptyStore does not accept Generic templates")
 }
 
 // GenericGet get returns an Generic template not found error.
 func (s *EmptyStore) GenericGet(name string) (string, error) {
-	return "", fmt.Errorf("no Generic template %s", name)
+	return "", notExist("generic", name)
+}
+
+// GenericVersion returns a Generic template not found error.
+func (s *EmptyStore) GenericVersion(name string) (int64, error) {
+	return 0, notExist("generic", name)
 }
 
 // GenericDelete returns a nil error (successful deletion).
@@ -79,7 +95,68 @@ func (s *EmptyStore) GenericDelete(name string) error {
 	return nil
 }
 
+// GenericList returns an empty list of Generic template names.
+func (s *EmptyStore) GenericList() (names []string, err error) {
+	return names, nil
+}
+
+// CloudPut returns an error writing any Cloud-Config template.
+func (s *EmptyStore) CloudPut(name string, config []byte, version int64) (int64, error) {
+	return 0, fmt.Errorf("emptyStore does not accept Cloud-Config templates")
+}
+
 // CloudGet returns a Cloud-config template not found error.
 func (s *EmptyStore) CloudGet(name string) (string, error) {
-	return "", fmt.Errorf("no Cloud-Config template %s", name)
+	return "", notExist("cloud", name)
+}
+
+// CloudVersion returns a Cloud-Config template not found error.
+func (s *EmptyStore) CloudVersion(name string) (int64, error) {
+	return 0, notExist("cloud", name)
+}
+
+// CloudDelete returns a nil error (successful deletion).
+func (s *EmptyStore) CloudDelete(name string) error {
+	return nil
+}
+
+// CloudList returns an empty list of Cloud-Config template names.
+func (s *EmptyStore) CloudList() (names []string, err error) {
+	return names, nil
+}
+
+// PartialPut returns an error writing any partial template.
+func (s *EmptyStore) PartialPut(name string, config []byte, version int64) (int64, error) {
+	return 0, fmt.Errorf("emptyStore does not accept partial templates")
+}
+
+// PartialGet returns a partial template not found error.
+func (s *EmptyStore) PartialGet(name string) (string, error) {
+	return "", notExist("partials", name)
+}
+
+// PartialVersion returns a partial template not found error.
+func (s *EmptyStore) PartialVersion(name string) (int64, error) {
+	return 0, notExist("partials", name)
+}
+
+// PartialDelete returns a nil error (successful deletion).
+func (s *EmptyStore) PartialDelete(name string) error {
+	return nil
+}
+
+// PartialList returns an empty list of partial template names.
+func (s *EmptyStore) PartialList() (names []string, err error) {
+	return names, nil
+}
+
+// Watch returns a channel without Events, which is closed when the context
+// is done.
+func (s *EmptyStore) Watch(ctx context.Context) (<-chan *storagepb.Event, error) {
+	ch := make(chan *storagepb.Event)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
 }
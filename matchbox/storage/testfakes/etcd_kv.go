@@ -0,0 +1,274 @@
+package testfakes
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/coreos/matchbox/matchbox/storage/etcdserverpb"
+)
+
+// EtcdKV is an in-memory etcd v3 KV and Watch server for testing purposes.
+// It implements the Range, Put, DeleteRange, Txn, and Watch semantics the
+// etcd Store relies on.
+type EtcdKV struct {
+	mu       sync.Mutex
+	revision int64
+	kvs      map[string]*pb.KeyValue
+	watchers map[*etcdWatcher]struct{}
+}
+
+// etcdWatcher receives the events for keys in [key, end).
+type etcdWatcher struct {
+	key, end []byte
+	events   chan *pb.Event
+}
+
+// NewEtcdKV returns a new empty EtcdKV.
+func NewEtcdKV() *EtcdKV {
+	return &EtcdKV{
+		kvs:      make(map[string]*pb.KeyValue),
+		watchers: make(map[*etcdWatcher]struct{}),
+	}
+}
+
+// Range returns the key-values in the requested range, sorted by key.
+func (s *EtcdKV) Range(ctx context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rangeKeys(req), nil
+}
+
+// Put writes a key-value and bumps the store revision.
+func (s *EtcdKV) Put(ctx context.Context, req *pb.PutRequest) (*pb.PutResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(req, s.revision+1), nil
+}
+
+// DeleteRange deletes the key-values in the requested range.
+func (s *EtcdKV) DeleteRange(ctx context.Context, req *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deleteRange(req, s.revision+1), nil
+}
+
+// Txn atomically applies the success or failure requests, depending on
+// whether all comparisons hold. All writes of a Txn have the same revision.
+func (s *EtcdKV) Txn(ctx context.Context, req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	succeeded := true
+	for _, cmp := range req.Compare {
+		if !s.compare(cmp) {
+			succeeded = false
+			break
+		}
+	}
+	ops := req.Success
+	if !succeeded {
+		ops = req.Failure
+	}
+	resp := &pb.TxnResponse{Succeeded: succeeded}
+	revision := s.revision + 1
+	for _, op := range ops {
+		switch {
+		case op.RequestRange != nil:
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{ResponseRange: s.rangeKeys(op.RequestRange)})
+		case op.RequestPut != nil:
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{ResponsePut: s.put(op.RequestPut, revision)})
+		case op.RequestDeleteRange != nil:
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{ResponseDeleteRange: s.deleteRange(op.RequestDeleteRange, revision)})
+		}
+	}
+	resp.Header = s.header()
+	return resp, nil
+}
+
+// compare evaluates a comparison against the current key-value, whose
+// fields are zero if the key does not exist. The caller must hold the lock.
+func (s *EtcdKV) compare(cmp *pb.Compare) bool {
+	kv := s.kvs[string(cmp.Key)]
+	if kv == nil {
+		kv = &pb.KeyValue{}
+	}
+	var result int
+	switch cmp.Target {
+	case pb.Compare_VERSION:
+		result = compareInt(kv.Version, cmp.Version)
+	case pb.Compare_CREATE:
+		result = compareInt(kv.CreateRevision, cmp.CreateRevision)
+	case pb.Compare_MOD:
+		result = compareInt(kv.ModRevision, cmp.ModRevision)
+	case pb.Compare_VALUE:
+		result = bytes.Compare(kv.Value, cmp.Value)
+	default:
+		return false
+	}
+	switch cmp.Result {
+	case pb.Compare_EQUAL:
+		return result == 0
+	case pb.Compare_GREATER:
+		return result > 0
+	case pb.Compare_LESS:
+		return result < 0
+	case pb.Compare_NOT_EQUAL:
+		return result != 0
+	}
+	return false
+}
+
+// rangeKeys implements Range. The caller must hold the lock.
+func (s *EtcdKV) rangeKeys(req *pb.RangeRequest) *pb.RangeResponse {
+	matches := s.match(req.Key, req.RangeEnd)
+	resp := &pb.RangeResponse{Header: s.header(), Count: int64(len(matches))}
+	if req.CountOnly {
+		return resp
+	}
+	for _, kv := range matches {
+		if req.Limit > 0 && int64(len(resp.Kvs)) == req.Limit {
+			resp.More = true
+			break
+		}
+		resp.Kvs = append(resp.Kvs, copyKeyValue(kv, req.KeysOnly))
+	}
+	return resp
+}
+
+// put implements Put at the given revision. The caller must hold the lock.
+func (s *EtcdKV) put(req *pb.PutRequest, revision int64) *pb.PutResponse {
+	s.revision = revision
+	key := string(req.Key)
+	prev := s.kvs[key]
+	kv := &pb.KeyValue{
+		Key:            req.Key,
+		Value:          req.Value,
+		CreateRevision: s.revision,
+		ModRevision:    s.revision,
+		Version:        1,
+		Lease:          req.Lease,
+	}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+	}
+	s.kvs[key] = kv
+	s.notify(&pb.Event{Type: pb.Event_PUT, Kv: copyKeyValue(kv, false)})
+	resp := &pb.PutResponse{Header: s.header()}
+	if req.PrevKv && prev != nil {
+		resp.PrevKv = copyKeyValue(prev, false)
+	}
+	return resp
+}
+
+// deleteRange implements DeleteRange at the given revision, if any keys are
+// deleted. The caller must hold the lock.
+func (s *EtcdKV) deleteRange(req *pb.DeleteRangeRequest, revision int64) *pb.DeleteRangeResponse {
+	matches := s.match(req.Key, req.RangeEnd)
+	if len(matches) > 0 {
+		s.revision = revision
+	}
+	resp := &pb.DeleteRangeResponse{Header: s.header(), Deleted: int64(len(matches))}
+	for _, kv := range matches {
+		delete(s.kvs, string(kv.Key))
+		s.notify(&pb.Event{Type: pb.Event_DELETE, Kv: &pb.KeyValue{Key: kv.Key, ModRevision: s.revision}})
+		if req.PrevKv {
+			resp.PrevKvs = append(resp.PrevKvs, kv)
+		}
+	}
+	return resp
+}
+
+// Watch serves a single watch per stream. Events for keys in the range of
+// the create request are sent until the stream is done.
+func (s *EtcdKV) Watch(stream pb.Watch_WatchServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	create := req.CreateRequest
+	if create == nil {
+		return stream.Send(&pb.WatchResponse{Header: s.header(), Canceled: true, CancelReason: "no create request"})
+	}
+	w := &etcdWatcher{key: create.Key, end: create.RangeEnd, events: make(chan *pb.Event, 100)}
+	s.mu.Lock()
+	s.watchers[w] = struct{}{}
+	header := s.header()
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, w)
+		s.mu.Unlock()
+	}()
+	if err := stream.Send(&pb.WatchResponse{Header: header, WatchId: 1, Created: true}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case event := <-w.events:
+			resp := &pb.WatchResponse{Header: &pb.ResponseHeader{Revision: event.Kv.ModRevision}, WatchId: 1, Events: []*pb.Event{event}}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// notify sends an event to the watchers of its key. The caller must hold the
+// lock.
+func (s *EtcdKV) notify(event *pb.Event) {
+	for w := range s.watchers {
+		if inRange(event.Kv.Key, w.key, w.end) {
+			w.events <- event
+		}
+	}
+}
+
+// match returns the key-values in [key, end), or the single key if end is
+// empty, sorted by key.
+func (s *EtcdKV) match(key, end []byte) []*pb.KeyValue {
+	var matches []*pb.KeyValue
+	for _, kv := range s.kvs {
+		if inRange(kv.Key, key, end) {
+			matches = append(matches, kv)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return bytes.Compare(matches[i].Key, matches[j].Key) < 0
+	})
+	return matches
+}
+
+// inRange returns true if k is in [key, end), or equals key if end is empty.
+func inRange(k, key, end []byte) bool {
+	if len(end) == 0 {
+		return bytes.Equal(k, key)
+	}
+	return bytes.Compare(k, key) >= 0 && (bytes.Equal(end, []byte{0}) || bytes.Compare(k, end) < 0)
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (s *EtcdKV) header() *pb.ResponseHeader {
+	return &pb.ResponseHeader{Revision: s.revision}
+}
+
+func copyKeyValue(kv *pb.KeyValue, keysOnly bool) *pb.KeyValue {
+	c := *kv
+	if keysOnly {
+		c.Value = nil
+	}
+	return &c
+}
@@ -1,18 +1,30 @@
 package testfakes
 
 import (
-	"fmt"
+	"context"
+	"os"
+	"sort"
+	"sync"
 
 	"github.com/coreos/matchbox/matchbox/storage/storagepb"
 )
 
-// FixedStore is used for testing purposes.
+// FixedStore is used for testing purposes. Resources in the maps have
+// resource version 1 until written through the FixedStore. Returned resources
+// are the stored ones, without resource versions.
 type FixedStore struct {
 	Groups          map[string]*storagepb.Group
 	Profiles        map[string]*storagepb.Profile
 	IgnitionConfigs map[string]string
 	CloudConfigs    map[string]string
 	GenericConfigs  map[string]string
+	Partials        map[string]string
+
+	mu       sync.Mutex
+	revision int64
+	versions map[string]int64
+	history  map[string][]*storagepb.Revision
+	watchers []chan *storagepb.Event
 }
 
 // NewFixedStore returns a new FixedStore.
@@ -23,13 +35,20 @@ func NewFixedStore() *FixedStore {
 		IgnitionConfigs: make(map[string]string),
 		CloudConfigs:    make(map[string]string),
 		GenericConfigs:  make(map[string]string),
+		Partials:        make(map[string]string),
 	}
 }
 
 // GroupPut write the given Group the Groups map.
-func (s *FixedStore) GroupPut(group *storagepb.Group) error {
+func (s *FixedStore) GroupPut(group *storagepb.Group) (int64, error) {
+	_, exists := s.Groups[group.Id]
+	version, err := s.write("groups", group.Id, exists, group.ResourceVersion)
+	if err != nil {
+		return 0, err
+	}
 	s.Groups[group.Id] = group
-	return nil
+	s.publish(&storagepb.Event{Type: storagepb.Event_PUT, Kind: "groups", Name: group.Id, Group: group, Revision: version})
+	return version, nil
 }
 
 // GroupGet returns the Group from the Groups map with the given id.
@@ -37,12 +56,13 @@ func (s *FixedStore) GroupGet(id string) (*storagepb.Group, error) {
 	if group, present := s.Groups[id]; present {
 		return group, nil
 	}
-	return nil, fmt.Errorf("Group not found")
+	return nil, notExist("groups", id)
 }
 
 // GroupDelete deletes the Group from the Groups map with the given id.
 func (s *FixedStore) GroupDelete(id string) error {
 	delete(s.Groups, id)
+	s.publish(&storagepb.Event{Type: storagepb.Event_DELETE, Kind: "groups", Name: id})
 	return nil
 }
 
@@ -58,9 +78,15 @@ func (s *FixedStore) GroupList() ([]*storagepb.Group, error) {
 }
 
 // ProfilePut writes the given Profile to the Profiles map.
-func (s *FixedStore) ProfilePut(profile *storagepb.Profile) error {
+func (s *FixedStore) ProfilePut(profile *storagepb.Profile) (int64, error) {
+	_, exists := s.Profiles[profile.Id]
+	version, err := s.write("profiles", profile.Id, exists, profile.ResourceVersion)
+	if err != nil {
+		return 0, err
+	}
 	s.Profiles[profile.Id] = profile
-	return nil
+	s.publish(&storagepb.Event{Type: storagepb.Event_PUT, Kind: "profiles", Name: profile.Id, Profile: profile, Revision: version})
+	return version, nil
 }
 
 // ProfileGet returns the Profile from the Profiles map with the given id.
@@ -68,12 +94,13 @@ func (s *FixedStore) ProfileGet(id string) (*storagepb.Profile, error) {
 	if profile, present := s.Profiles[id]; present {
 		return profile, nil
 	}
-	return nil, fmt.Errorf("Profile not found")
+	return nil, notExist("profiles", id)
 }
 
 // ProfileDelete deletes the Profile from the Profiles map with the given id.
 func (s *FixedStore) ProfileDelete(id string) error {
 	delete(s.Profiles, id)
+	s.publish(&storagepb.Event{Type: storagepb.Event_DELETE, Kind: "profiles", Name: id})
 	return nil
 }
 
@@ -89,9 +116,15 @@ func (s *FixedStore) ProfileList() ([]*storagepb.Profile, error) {
 }
 
 // IgnitionPut create or updates an Ignition template.
-func (s *FixedStore) IgnitionPut(name string, config []byte) error {
+func (s *FixedStore) IgnitionPut(name string, config []byte, expected int64) (int64, error) {
+	_, exists := s.IgnitionConfigs[name]
+	version, err := s.write("ignition", name, exists, expected)
+	if err != nil {
+		return 0, err
+	}
 	s.IgnitionConfigs[name] = string(config)
-	return nil
+	s.publish(&storagepb.Event{Type: storagepb.Event_PUT, Kind: "ignition", Name: name, Template: config, Revision: version})
+	return version, nil
 }
 
 // IgnitionGet returns an Ignition template by name.
@@ -99,19 +132,39 @@ func (s *FixedStore) IgnitionGet(name string) (string, error) {
 	if config, present := s.IgnitionConfigs[name]; present {
 		return config, nil
 	}
-	return "", fmt.Errorf("no Ignition template %s", name)
+	return "", notExist("ignition", name)
+}
+
+// IgnitionVersion returns the resource version of an Ignition template.
+func (s *FixedStore) IgnitionVersion(name string) (int64, error) {
+	if _, present := s.IgnitionConfigs[name]; present {
+		return s.version("ignition", name, true), nil
+	}
+	return 0, notExist("ignition", name)
 }
 
 // IgnitionDelete deletes an Ignition template by name.
 func (s *FixedStore) IgnitionDelete(name string) error {
 	delete(s.IgnitionConfigs, name)
+	s.publish(&storagepb.Event{Type: storagepb.Event_DELETE, Kind: "ignition", Name: name})
 	return nil
 }
 
+// IgnitionList returns the sorted names of the Ignition templates.
+func (s *FixedStore) IgnitionList() ([]string, error) {
+	return sortedNames(s.IgnitionConfigs), nil
+}
+
 // GenericPut create or updates an Generic template.
-func (s *FixedStore) GenericPut(name string, config []byte) error {
+func (s *FixedStore) GenericPut(name string, config []byte, expected int64) (int64, error) {
+	_, exists := s.GenericConfigs[name]
+	version, err := s.write("generic", name, exists, expected)
+	if err != nil {
+		return 0, err
+	}
 	s.GenericConfigs[name] = string(config)
-	return nil
+	s.publish(&storagepb.Event{Type: storagepb.Event_PUT, Kind: "generic", Name: name, Template: config, Revision: version})
+	return version, nil
 }
 
 // GenericGet returns an Generic template by name.
@@ -119,19 +172,205 @@ func (s *FixedStore) GenericGet(name string) (string, error) {
 	if config, present := s.GenericConfigs[name]; present {
 		return config, nil
 	}
-	return "", fmt.Errorf("no Generic template %s", name)
+	return "", notExist("generic", name)
+}
+
+// GenericVersion returns the resource version of a Generic template.
+func (s *FixedStore) GenericVersion(name string) (int64, error) {
+	if _, present := s.GenericConfigs[name]; present {
+		return s.version("generic", name, true), nil
+	}
+	return 0, notExist("generic", name)
 }
 
 // GenericDelete deletes an Generic template by name.
 func (s *FixedStore) GenericDelete(name string) error {
 	delete(s.GenericConfigs, name)
+	s.publish(&storagepb.Event{Type: storagepb.Event_DELETE, Kind: "generic", Name: name})
 	return nil
 }
 
+// GenericList returns the sorted names of the Generic templates.
+func (s *FixedStore) GenericList() ([]string, error) {
+	return sortedNames(s.GenericConfigs), nil
+}
+
+// CloudPut create or updates a Cloud-Config template.
+func (s *FixedStore) CloudPut(name string, config []byte, expected int64) (int64, error) {
+	_, exists := s.CloudConfigs[name]
+	version, err := s.write("cloud", name, exists, expected)
+	if err != nil {
+		return 0, err
+	}
+	s.CloudConfigs[name] = string(config)
+	s.publish(&storagepb.Event{Type: storagepb.Event_PUT, Kind: "cloud", Name: name, Template: config, Revision: version})
+	return version, nil
+}
+
 // CloudGet returns a Cloud-config template by name.
 func (s *FixedStore) CloudGet(name string) (string, error) {
 	if config, present := s.CloudConfigs[name]; present {
 		return config, nil
 	}
-	return "", fmt.Errorf("no Cloud-Config template %s", name)
+	return "", notExist("cloud", name)
+}
+
+// CloudVersion returns the resource version of a Cloud-Config template.
+func (s *FixedStore) CloudVersion(name string) (int64, error) {
+	if _, present := s.CloudConfigs[name]; present {
+		return s.version("cloud", name, true), nil
+	}
+	return 0, notExist("cloud", name)
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *FixedStore) CloudDelete(name string) error {
+	delete(s.CloudConfigs, name)
+	s.publish(&storagepb.Event{Type: storagepb.Event_DELETE, Kind: "cloud", Name: name})
+	return nil
+}
+
+// CloudList returns the sorted names of the Cloud-Config templates.
+func (s *FixedStore) CloudList() ([]string, error) {
+	return sortedNames(s.CloudConfigs), nil
+}
+
+// PartialPut create or updates a partial template.
+func (s *FixedStore) PartialPut(name string, config []byte, expected int64) (int64, error) {
+	_, exists := s.Partials[name]
+	version, err := s.write("partials", name, exists, expected)
+	if err != nil {
+		return 0, err
+	}
+	s.Partials[name] = string(config)
+	s.publish(&storagepb.Event{Type: storagepb.Event_PUT, Kind: "partials", Name: name, Template: config, Revision: version})
+	return version, nil
+}
+
+// PartialGet returns a partial template by name.
+func (s *FixedStore) PartialGet(name string) (string, error) {
+	if config, present := s.Partials[name]; present {
+		return config, nil
+	}
+	return "", notExist("partials", name)
+}
+
+// PartialVersion returns the resource version of a partial template.
+func (s *FixedStore) PartialVersion(name string) (int64, error) {
+	if _, present := s.Partials[name]; present {
+		return s.version("partials", name, true), nil
+	}
+	return 0, notExist("partials", name)
+}
+
+// PartialDelete deletes a partial template by name.
+func (s *FixedStore) PartialDelete(name string) error {
+	delete(s.Partials, name)
+	s.publish(&storagepb.Event{Type: storagepb.Event_DELETE, Kind: "partials", Name: name})
+	return nil
+}
+
+// PartialList returns the sorted names of the partial templates.
+func (s *FixedStore) PartialList() ([]string, error) {
+	return sortedNames(s.Partials), nil
+}
+
+// Watch returns a channel of Events for writes to the FixedStore, which is
+// closed when the context is done.
+func (s *FixedStore) Watch(ctx context.Context) (<-chan *storagepb.Event, error) {
+	ch := make(chan *storagepb.Event, 100)
+	s.mu.Lock()
+	s.watchers = append(s.watchers, ch)
+	s.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, watcher := range s.watchers {
+			if watcher == ch {
+				s.watchers = append(s.watchers[:i], s.watchers[i+1:]...)
+				close(ch)
+				break
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// HistoryAppend records a Revision of a resource.
+func (s *FixedStore) HistoryAppend(revision *storagepb.Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.history == nil {
+		s.history = make(map[string][]*storagepb.Revision)
+	}
+	key := revision.Kind + "/" + revision.Name
+	s.history[key] = append(s.history[key], revision)
+	return nil
+}
+
+// History returns the recorded Revisions of a resource, oldest first.
+func (s *FixedStore) History(kind, name string) ([]*storagepb.Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.history[kind+"/"+name], nil
+}
+
+// write checks a non-zero expected version against the current version of
+// a resource and returns the next revision as its new version.
+func (s *FixedStore) write(kind, name string, exists bool, expected int64) (int64, error) {
+	if expected != 0 && expected != s.version(kind, name, exists) {
+		return 0, storagepb.ErrVersionConflict
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.versions == nil {
+		s.versions = make(map[string]int64)
+	}
+	s.revision++
+	s.versions[kind+"/"+name] = s.revision
+	return s.revision, nil
+}
+
+// version returns the current version of a resource, 0 if it does not exist.
+func (s *FixedStore) version(kind, name string, exists bool) int64 {
+	if !exists {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version, ok := s.versions[kind+"/"+name]; ok {
+		return version
+	}
+	return 1
+}
+
+// publish sends an Event to watchers, assigning the next revision to Events
+// without one.
+func (s *FixedStore) publish(event *storagepb.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if event.Revision == 0 {
+		s.revision++
+		event.Revision = s.revision
+	}
+	for _, watcher := range s.watchers {
+		watcher <- event
+	}
+}
+
+// sortedNames returns the sorted keys of a map of templates.
+func sortedNames(templates map[string]string) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// notExist returns an error for a missing resource which satisfies
+// os.IsNotExist, like the errors of the real stores.
+func notExist(kind, name string) error {
+	return &os.PathError{Op: "get", Path: kind + "/" + name, Err: os.ErrNotExist}
 }
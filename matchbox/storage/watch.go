@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+)
+
+// watchBufferSize is the number of Events buffered for each watcher. A
+// watcher which falls further behind is closed.
+const watchBufferSize = 100
+
+// watchEpoch is the epoch of in-memory revisions, the time the process
+// started, so watchers can tell revisions of different processes apart.
+var watchEpoch = time.Now().UnixNano()
+
+// watchHub broadcasts the Events of a Store's own writes to its watchers.
+// The zero value is ready to use.
+type watchHub struct {
+	mu       sync.Mutex
+	revision int64
+	watchers map[chan *storagepb.Event]struct{}
+}
+
+// watch returns a channel of the Events published after the call, which is
+// closed when the context is done.
+func (h *watchHub) watch(ctx context.Context) <-chan *storagepb.Event {
+	ch := make(chan *storagepb.Event, watchBufferSize)
+	h.mu.Lock()
+	if h.watchers == nil {
+		h.watchers = make(map[chan *storagepb.Event]struct{})
+	}
+	h.watchers[ch] = struct{}{}
+	h.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(ch)
+	}()
+	return ch
+}
+
+// publish sends an Event to all watchers. Events without a revision are
+// assigned the next in-memory revision of the process's epoch. Watchers
+// whose buffer is full are closed rather than blocking the writer.
+func (h *watchHub) publish(event *storagepb.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if event.Revision == 0 {
+		h.revision++
+		event.Revision = h.revision
+		event.Epoch = watchEpoch
+	}
+	for ch := range h.watchers {
+		select {
+		case ch <- event:
+		default:
+			h.remove(ch)
+		}
+	}
+}
+
+// remove closes and forgets a watcher. The caller must hold the lock.
+func (h *watchHub) remove(ch chan *storagepb.Event) {
+	if _, ok := h.watchers[ch]; ok {
+		delete(h.watchers, ch)
+		close(ch)
+	}
+}
+
+// putEvent returns a put Event for a resource.
+func putEvent(kind, name string) *storagepb.Event {
+	return &storagepb.Event{Type: storagepb.Event_PUT, Kind: kind, Name: name}
+}
+
+// deleteEvent returns a delete Event for a resource.
+func deleteEvent(kind, name string) *storagepb.Event {
+	return &storagepb.Event{Type: storagepb.Event_DELETE, Kind: kind, Name: name}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coreos/matchbox/matchbox/storage/storagepb"
+	fake "github.com/coreos/matchbox/matchbox/storage/testfakes"
+)
+
+func TestFileWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	testWatch(t, NewFileStore(&Config{Root: dir}))
+}
+
+func TestWatchHub_SlowWatcher(t *testing.T) {
+	hub := &watchHub{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := hub.watch(ctx)
+	// assert that a watcher which falls behind is closed
+	for i := 0; i <= watchBufferSize; i++ {
+		hub.publish(putEvent("generic", "name"))
+	}
+	received := 0
+	for range ch {
+		received++
+	}
+	assert.Equal(t, watchBufferSize, received)
+}
+
+func TestWatchHub_Epoch(t *testing.T) {
+	hub := &watchHub{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := hub.watch(ctx)
+	// assert that in-memory revisions have the process's epoch and revisions
+	// of the store do not
+	hub.publish(putEvent("generic", "name"))
+	persisted := putEvent("generic", "name")
+	persisted.Revision = 42
+	hub.publish(persisted)
+	event := <-ch
+	assert.Equal(t, int64(1), event.Revision)
+	assert.Equal(t, watchEpoch, event.Epoch)
+	event = <-ch
+	assert.Equal(t, int64(42), event.Revision)
+	assert.Equal(t, int64(0), event.Epoch)
+}
+
+// testWatch asserts that a Store's watchers receive Events for its writes
+// in order, with the resource versions of written Groups and Profiles, and
+// that the Event channel is closed once the context is done.
+func testWatch(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := store.Watch(ctx)
+	if !assert.Nil(t, err) {
+		cancel()
+		return
+	}
+	groupVersion, err := store.GroupPut(fake.Group)
+	assert.Nil(t, err)
+	profileVersion, err := store.ProfilePut(fake.Profile)
+	assert.Nil(t, err)
+	_, err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), 0)
+	assert.Nil(t, err)
+	assert.Nil(t, store.GroupDelete(fake.Group.Id))
+
+	expected := []*storagepb.Event{
+		{Type: storagepb.Event_PUT, Kind: "groups", Name: fake.Group.Id, Group: withGroupVersion(fake.Group, groupVersion)},
+		{Type: storagepb.Event_PUT, Kind: "profiles", Name: fake.Profile.Id, Profile: withProfileVersion(fake.Profile, profileVersion)},
+		{Type: storagepb.Event_PUT, Kind: "ignition", Name: fake.IgnitionYAMLName, Template: []byte(fake.IgnitionYAML)},
+		{Type: storagepb.Event_DELETE, Kind: "groups", Name: fake.Group.Id},
+	}
+	var revision int64
+	for _, want := range expected {
+		select {
+		case event := <-events:
+			if assert.NotNil(t, event) {
+				assert.True(t, event.Revision > revision, "revisions must increase")
+				revision = event.Revision
+				assert.Contains(t, []int64{0, watchEpoch}, event.Epoch)
+				event.Revision, event.Epoch = 0, 0
+				assert.Equal(t, want, event)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s event", want.Kind)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "events channel must be closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the events channel to close")
+	}
+}